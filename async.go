@@ -0,0 +1,175 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// defaultAsyncLogQueueSize is the async log queue size used when none is configured.
+const defaultAsyncLogQueueSize = 2048
+
+// asyncItem is a queued record, or a flush marker when done is set.
+type asyncItem struct {
+	record sdklog.Record
+	done   chan struct{}
+}
+
+// asyncProcessor is a log processor that queues records on a bounded channel
+// and hands them to the next processor from a background worker, so emitting a
+// record never blocks on the exporter. Records are dropped when the queue is full.
+type asyncProcessor struct {
+	next    sdklog.Processor
+	queue   chan asyncItem
+	dropped metric.Int64Counter
+
+	// mu guards closed; senders hold it for reading so the queue is never
+	// closed while a send is in progress
+	mu     sync.RWMutex
+	closed bool
+
+	stopped chan struct{}
+
+	// registration reports the queue size; it is unregistered on Shutdown
+	registration metric.Registration
+}
+
+var _ sdklog.Processor = (*asyncProcessor)(nil)
+
+// newAsyncProcessor wraps next in an asyncProcessor with the given queue size
+// (defaultAsyncLogQueueSize if size is not positive) and starts its worker.
+// The queue metrics are recorded with mp; they are discarded if mp is nil.
+func newAsyncProcessor(next sdklog.Processor, size int, mp metric.MeterProvider) *asyncProcessor {
+	if size <= 0 {
+		size = defaultAsyncLogQueueSize
+	}
+
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+	meter := mp.Meter(selfMeterName)
+	dropped, err := meter.Int64Counter("telemetry.log.queue.dropped",
+		metric.WithDescription("Log records dropped because the async log queue was full."),
+		metric.WithUnit("{record}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	p := &asyncProcessor{
+		next:    next,
+		queue:   make(chan asyncItem, size),
+		dropped: dropped,
+		stopped: make(chan struct{}),
+	}
+
+	queueSize, err := meter.Int64ObservableUpDownCounter("telemetry.log.queue.size",
+		metric.WithDescription("Log records waiting in the async log queue."),
+		metric.WithUnit("{record}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	} else {
+		p.registration, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+			o.ObserveInt64(queueSize, int64(len(p.queue)))
+			return nil
+		}, queueSize)
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
+
+	go p.run()
+
+	return p
+}
+
+// run hands queued records to the next processor until the queue is closed.
+func (p *asyncProcessor) run() {
+	defer close(p.stopped)
+
+	ctx := context.Background()
+	for item := range p.queue {
+		if item.done != nil {
+			close(item.done)
+			continue
+		}
+		if err := p.next.OnEmit(ctx, &item.record); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// OnEmit queues a copy of the record, or drops it if the queue is full.
+func (p *asyncProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return nil
+	}
+
+	select {
+	case p.queue <- asyncItem{record: record.Clone()}:
+	default:
+		p.dropped.Add(ctx, 1)
+	}
+	return nil
+}
+
+// Enabled reports whether the next processor would process a record with the
+// given parameters.
+func (p *asyncProcessor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	return p.next.Enabled(ctx, param)
+}
+
+// ForceFlush waits for the records queued so far to reach the next processor,
+// then flushes it.
+func (p *asyncProcessor) ForceFlush(ctx context.Context) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return nil
+	}
+
+	done := make(chan struct{})
+	select {
+	case p.queue <- asyncItem{done: done}:
+		p.mu.RUnlock()
+	case <-ctx.Done():
+		p.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.next.ForceFlush(ctx)
+}
+
+// Shutdown stops accepting records, waits for the queue to drain, and shuts
+// down the next processor.
+func (p *asyncProcessor) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+		if p.registration != nil {
+			_ = p.registration.Unregister()
+		}
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.next.Shutdown(ctx)
+}
@@ -0,0 +1,125 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// blockingProcessor records OnEmit calls and blocks them until release is closed.
+type blockingProcessor struct {
+	release chan struct{}
+
+	mu      sync.Mutex
+	emitted int
+}
+
+func (p *blockingProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	<-p.release
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.emitted++
+	return nil
+}
+
+func (p *blockingProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (p *blockingProcessor) Shutdown(ctx context.Context) error                     { return nil }
+func (p *blockingProcessor) ForceFlush(ctx context.Context) error                   { return nil }
+
+func (p *blockingProcessor) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.emitted
+}
+
+func TestAsyncProcessor_DoesNotBlock(t *testing.T) {
+	ctx := context.Background()
+
+	next := &blockingProcessor{release: make(chan struct{})}
+	p := newAsyncProcessor(next, 2, nil)
+
+	// The worker takes the first record and blocks on it; two more fill the
+	// queue and the rest are dropped without blocking the caller
+	for i := 0; i < 10; i++ {
+		var record sdklog.Record
+		if err := p.OnEmit(ctx, &record); err != nil {
+			t.Fatalf("OnEmit() error = %v", err)
+		}
+	}
+
+	close(next.release)
+	if err := p.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	if got := next.count(); got < 1 || got > 3 {
+		t.Errorf("emitted %d records, want between 1 and 3 with a queue of 2", got)
+	}
+
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	var record sdklog.Record
+	if err := p.OnEmit(ctx, &record); err != nil {
+		t.Errorf("OnEmit() after Shutdown error = %v, want nil", err)
+	}
+}
+
+func TestAsyncProcessor_ForceFlushDrainsQueue(t *testing.T) {
+	ctx := context.Background()
+
+	next := &blockingProcessor{release: make(chan struct{})}
+	close(next.release)
+	p := newAsyncProcessor(next, 0, nil)
+	defer p.Shutdown(ctx)
+
+	for i := 0; i < 100; i++ {
+		var record sdklog.Record
+		_ = p.OnEmit(ctx, &record)
+	}
+
+	if err := p.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+	if got := next.count(); got != 100 {
+		t.Errorf("emitted %d records after ForceFlush, want 100", got)
+	}
+}
+
+func TestAsyncProcessor_MetricsFollowReconfigure(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	opts := func() *Options {
+		return &Options{
+			ServiceName:       "test-service",
+			MetricsExporter:   "manual",
+			CustomLogExporter: &telemetrytest.LogRecorder{},
+			AsyncLogs:         true,
+		}
+	}
+
+	tel, err := New(ctx, opts())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if err := tel.Reconfigure(ctx, opts()); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if _, ok := telemetrytest.FindMetric(rm, "telemetry.log.queue.size"); !ok {
+		t.Error("telemetry.log.queue.size not reported by the reconfigured meter provider")
+	}
+}
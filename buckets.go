@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	"maps"
+	"slices"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Names of the histogram bucket presets, for BucketPreset and
+// Options.HistogramBucketPresets.
+const (
+	// BucketsHTTPLatencyMs suits HTTP request latencies in milliseconds, from 5ms to 10s.
+	BucketsHTTPLatencyMs = "http_latency_ms"
+	// BucketsDBLatencyMs suits database query latencies in milliseconds, from 0.5ms to 5s.
+	BucketsDBLatencyMs = "db_latency_ms"
+	// BucketsPayloadBytes suits request and message sizes in bytes, from 64B to 64MiB.
+	BucketsPayloadBytes = "payload_bytes"
+)
+
+// bucketPresets are the bucket boundaries of each preset.
+var bucketPresets = map[string][]float64{
+	BucketsHTTPLatencyMs: {5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000},
+	BucketsDBLatencyMs:   {0.5, 1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000},
+	BucketsPayloadBytes:  {64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864},
+}
+
+// BucketPreset returns the bucket boundaries of the named preset, or nil if
+// there is no such preset. Use it when creating a histogram:
+//
+//	meter.Float64Histogram("db.query.duration", metric.WithUnit("ms"),
+//	    metric.WithExplicitBucketBoundaries(telemetry.BucketPreset(telemetry.BucketsDBLatencyMs)...))
+func BucketPreset(name string) []float64 {
+	return slices.Clone(bucketPresets[name])
+}
+
+// bucketPresetNames returns the preset names, sorted.
+func bucketPresetNames() []string {
+	return slices.Sorted(maps.Keys(bucketPresets))
+}
+
+// bucketPresetViews returns the views that apply Options.HistogramBucketPresets.
+func bucketPresetViews(presets map[string]string) []sdkmetric.View {
+	var views []sdkmetric.View
+	for _, pattern := range slices.Sorted(maps.Keys(presets)) {
+		views = append(views, sdkmetric.NewView(
+			sdkmetric.Instrument{Name: pattern, Kind: sdkmetric.InstrumentKindHistogram},
+			sdkmetric.Stream{Aggregation: sdkmetric.AggregationExplicitBucketHistogram{
+				Boundaries: BucketPreset(presets[pattern]),
+			}},
+		))
+	}
+	return views
+}
@@ -0,0 +1,74 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestBucketPreset(t *testing.T) {
+	for _, name := range []string{BucketsHTTPLatencyMs, BucketsDBLatencyMs, BucketsPayloadBytes} {
+		bounds := BucketPreset(name)
+		if len(bounds) == 0 || !slices.IsSorted(bounds) {
+			t.Errorf("BucketPreset(%q) = %v, want sorted boundaries", name, bounds)
+		}
+	}
+
+	if bounds := BucketPreset("bogus"); bounds != nil {
+		t.Errorf("BucketPreset(\"bogus\") = %v, want nil", bounds)
+	}
+
+	// The returned slice is a copy
+	BucketPreset(BucketsHTTPLatencyMs)[0] = -1
+	if BucketPreset(BucketsHTTPLatencyMs)[0] == -1 {
+		t.Error("BucketPreset() returned the preset itself, want a copy")
+	}
+}
+
+func TestTelemetry_HistogramBucketPresets(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:            "test-service",
+		MetricsExporter:        "manual",
+		HistogramBucketPresets: map[string]string{"db.*": BucketsDBLatencyMs},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	meter := tel.MeterProvider().Meter("test")
+	for _, name := range []string{"db.query.duration", "http.request.duration"} {
+		histogram, err := meter.Float64Histogram(name)
+		if err != nil {
+			t.Fatalf("Float64Histogram() error = %v", err)
+		}
+		histogram.Record(ctx, 3)
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+
+	bounds := map[string][]float64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if h, ok := m.Data.(metricdata.Histogram[float64]); ok {
+				bounds[m.Name] = h.DataPoints[0].Bounds
+			}
+		}
+	}
+	if got := bounds["db.query.duration"]; !slices.Equal(got, BucketPreset(BucketsDBLatencyMs)) {
+		t.Errorf("db.query.duration bounds = %v, want the db_latency_ms preset", got)
+	}
+	if got := bounds["http.request.duration"]; slices.Equal(got, BucketPreset(BucketsDBLatencyMs)) {
+		t.Error("http.request.duration bounds = the db_latency_ms preset, want the SDK default")
+	}
+}
@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// buildInfo is the subset of runtime/debug.BuildInfo reported as telemetry.
+type buildInfo struct {
+	goVersion     string
+	modulePath    string
+	moduleVersion string
+	revision      string
+	modified      bool
+}
+
+// readBuildInfo reads the build info of the running binary once. Binaries built
+// without module support only report the Go version.
+var readBuildInfo = sync.OnceValue(func() buildInfo {
+	info := buildInfo{goVersion: runtime.Version()}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.goVersion = bi.GoVersion
+	info.modulePath = bi.Main.Path
+	info.moduleVersion = bi.Main.Version
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.revision = setting.Value
+		case "vcs.modified":
+			info.modified = setting.Value == "true"
+		}
+	}
+	return info
+})
+
+// attributes returns the build info as attributes, omitting unknown values.
+func (b buildInfo) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.ProcessRuntimeName("go"),
+		semconv.ProcessRuntimeVersion(b.goVersion),
+	}
+	if b.modulePath != "" {
+		attrs = append(attrs, attribute.String("go.module.path", b.modulePath))
+	}
+	if b.moduleVersion != "" {
+		attrs = append(attrs, attribute.String("go.module.version", b.moduleVersion))
+	}
+	if b.revision != "" {
+		attrs = append(attrs,
+			attribute.String("vcs.revision", b.revision),
+			attribute.Bool("vcs.modified", b.modified),
+		)
+	}
+	return attrs
+}
+
+// registerBuildInfo registers the build_info gauge on mp. It always reports 1,
+// with the build info as attributes, so dashboards can join on the deployed
+// version and revision.
+func registerBuildInfo(mp metric.MeterProvider) {
+	attrs := metric.WithAttributes(readBuildInfo().attributes()...)
+
+	_, err := mp.Meter(selfMeterName).Int64ObservableGauge("build_info",
+		metric.WithDescription("Build information of the running binary; always 1."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(1, attrs)
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
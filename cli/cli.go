@@ -0,0 +1,102 @@
+// Package cli provides urfave/cli flags for the common telemetry settings and
+// builds Options from them.
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// Flag names.
+const (
+	LogLevelFlag         = "log-level"
+	OTLPEndpointFlag     = "otlp-endpoint"
+	MetricsExporterFlag  = "metrics-exporter"
+	TraceSampleRatioFlag = "trace-sample-ratio"
+)
+
+// Category groups the flags in the help output.
+const Category = "Telemetry"
+
+// Flags returns the --log-level, --otlp-endpoint, --metrics-exporter, and
+// --trace-sample-ratio flags, to append to an app's or command's flags.
+//
+//	app := &cli.App{
+//		Flags:  append(appFlags, telemetrycli.Flags()...),
+//		Action: run,
+//	}
+func Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     LogLevelFlag,
+			Usage:    "lowest log severity exported (trace, debug, info, warn, error, fatal)",
+			Category: Category,
+		},
+		&cli.StringFlag{
+			Name:     OTLPEndpointFlag,
+			Usage:    "OTLP gRPC endpoint for traces, metrics, and logs (e.g., collector:4317)",
+			Category: Category,
+		},
+		&cli.StringFlag{
+			Name:     MetricsExporterFlag,
+			Usage:    "metrics exporter (otlp, prometheus, none)",
+			Category: Category,
+		},
+		&cli.Float64Flag{
+			Name:     TraceSampleRatioFlag,
+			Usage:    "fraction of new traces sampled (0 to 1); child spans follow their parent",
+			Category: Category,
+		},
+	}
+}
+
+// Options returns Options built from the flags set on c, with the app's name
+// and version as the service name and version. Flags that aren't set leave
+// their fields empty, so the defaults and environment variables apply; the
+// environment variables applied by telemetry.New still override the flags.
+//
+//	opts, err := telemetrycli.Options(c)
+//	if err != nil {
+//		return err
+//	}
+//	t, err := telemetry.New(c.Context, opts)
+func Options(c *cli.Context) (*telemetry.Options, error) {
+	opts := &telemetry.Options{}
+	if c.App != nil {
+		opts.ServiceName = c.App.Name
+		opts.ServiceVersion = c.App.Version
+	}
+
+	if c.IsSet(LogLevelFlag) {
+		severity, err := telemetry.ParseSeverity(c.String(LogLevelFlag))
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", LogLevelFlag, err)
+		}
+		opts.LogsMinSeverity = severity
+	}
+
+	if c.IsSet(OTLPEndpointFlag) {
+		endpoint := c.String(OTLPEndpointFlag)
+		opts.TracesEndpoint = endpoint
+		opts.MetricsEndpoint = endpoint
+		opts.LogsEndpoint = endpoint
+	}
+
+	if c.IsSet(MetricsExporterFlag) {
+		opts.MetricsExporter = c.String(MetricsExporterFlag)
+	}
+
+	if c.IsSet(TraceSampleRatioFlag) {
+		ratio := c.Float64(TraceSampleRatioFlag)
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("--%s: ratio %v is not between 0 and 1", TraceSampleRatioFlag, ratio)
+		}
+		opts.TracesSampler = "parentbased_traceidratio"
+		opts.TracesSamplerRatio = ratio
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/urfave/cli/v2"
+	otellog "go.opentelemetry.io/otel/log"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// run parses args with Flags and returns the Options built from them.
+func run(t *testing.T, args ...string) (*telemetry.Options, error) {
+	t.Helper()
+
+	var opts *telemetry.Options
+	var optsErr error
+	app := &cli.App{
+		Name:    "mycli",
+		Version: "1.2.3",
+		Flags:   Flags(),
+		Action: func(c *cli.Context) error {
+			opts, optsErr = Options(c)
+			return nil
+		},
+	}
+	if err := app.Run(append([]string{"mycli"}, args...)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return opts, optsErr
+}
+
+func TestOptions(t *testing.T) {
+	opts, err := run(t,
+		"--log-level", "warn",
+		"--otlp-endpoint", "collector:4317",
+		"--metrics-exporter", "prometheus",
+		"--trace-sample-ratio", "0.25",
+	)
+	if err != nil {
+		t.Fatalf("Options() error = %v", err)
+	}
+
+	if opts.ServiceName != "mycli" || opts.ServiceVersion != "1.2.3" {
+		t.Errorf("service = %s@%s, want mycli@1.2.3", opts.ServiceName, opts.ServiceVersion)
+	}
+	if opts.LogsMinSeverity != otellog.SeverityWarn {
+		t.Errorf("LogsMinSeverity = %v, want %v", opts.LogsMinSeverity, otellog.SeverityWarn)
+	}
+	for name, endpoint := range map[string]string{
+		"TracesEndpoint":  opts.TracesEndpoint,
+		"MetricsEndpoint": opts.MetricsEndpoint,
+		"LogsEndpoint":    opts.LogsEndpoint,
+	} {
+		if endpoint != "collector:4317" {
+			t.Errorf("%s = %q, want collector:4317", name, endpoint)
+		}
+	}
+	if opts.MetricsExporter != "prometheus" {
+		t.Errorf("MetricsExporter = %q, want prometheus", opts.MetricsExporter)
+	}
+	if opts.TracesSampler != "parentbased_traceidratio" || opts.TracesSamplerRatio != 0.25 {
+		t.Errorf("sampler = %s(%v), want parentbased_traceidratio(0.25)", opts.TracesSampler, opts.TracesSamplerRatio)
+	}
+}
+
+func TestOptions_Unset(t *testing.T) {
+	opts, err := run(t)
+	if err != nil {
+		t.Fatalf("Options() error = %v", err)
+	}
+	if opts.TracesEndpoint != "" || opts.MetricsExporter != "" || opts.TracesSampler != "" {
+		t.Errorf("Options() = %+v, want only the service set", opts)
+	}
+}
+
+func TestOptions_Invalid(t *testing.T) {
+	if _, err := run(t, "--log-level", "loud"); err == nil {
+		t.Error("Options() with --log-level loud error = nil, want error")
+	}
+	if _, err := run(t, "--trace-sample-ratio", "2"); err == nil {
+		t.Error("Options() with --trace-sample-ratio 2 error = nil, want error")
+	}
+}
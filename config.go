@@ -1,8 +1,19 @@
 package telemetry
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
 )
 
 // Options holds configuration for the telemetry system.
@@ -11,6 +22,41 @@ type Options struct {
 	ServiceName string
 	// ServiceVersion is the version of the service.
 	ServiceVersion string
+	// Namespace is the service.namespace resource attribute, grouping related services (e.g., "shop").
+	Namespace string
+	// Environment is the deployment.environment resource attribute (e.g., "production").
+	Environment string
+	// InstanceID is the service.instance.id resource attribute. When empty
+	// (default), a random UUID generated once per process is used.
+	InstanceID string
+	// EnableBuildInfo registers the build_info gauge and adds the build resource
+	// attributes (Go version, module version, VCS revision) read from
+	// runtime/debug.BuildInfo (default: false).
+	EnableBuildInfo bool
+	// DetectPlatform adds the container.id resource attribute, read from
+	// /proc/self/cgroup or /proc/self/mountinfo when running in a container,
+	// and the cloud.* and faas.* attributes of AWS Lambda, Cloud Run, and
+	// Cloud Functions, read from the environment they set (default: false).
+	DetectPlatform bool
+	// DetectKubernetes adds k8s.* resource attributes read from the downward
+	// API, so telemetry can be filtered by pod without a collector processor:
+	// the pod name, uid, namespace, node, and container from the K8S_POD_NAME
+	// (or POD_NAME), K8S_POD_UID (POD_UID), K8S_NAMESPACE_NAME (POD_NAMESPACE),
+	// K8S_NODE_NAME (NODE_NAME), and K8S_CONTAINER_NAME (CONTAINER_NAME)
+	// environment variables, and the name, uid, namespace, and labels files of
+	// a downward API volume mounted at KubernetesPodInfoDir. Labels become
+	// k8s.pod.label.<key> attributes. ResourceAttributes take precedence.
+	DetectKubernetes bool
+	// KubernetesPodInfoDir is where the downward API volume is mounted
+	// (default: "/etc/podinfo").
+	KubernetesPodInfoDir string
+	// UptimeMetrics registers the process.start_time gauge and the
+	// process.uptime counter, both in seconds (default: false).
+	UptimeMetrics bool
+
+	// Strict makes Validate reject a missing ServiceName (empty or the "unknown"
+	// default), so a service can't report telemetry under a placeholder name.
+	Strict bool
 
 	// BatchExport controls whether telemetry data is exported in batches or immediately.
 	// When true, uses batch processors/exporters for better performance (higher latency).
@@ -19,7 +65,13 @@ type Options struct {
 	// Simple mode is recommended for development and debugging.
 	BatchExport bool
 
-	// MetricsExporter specifies which metrics exporter to use: "otlp", "prometheus", or "none".
+	// TracesBatchExport and LogsBatchExport enable batch export for one
+	// signal, like BatchExport.
+	TracesBatchExport bool
+	LogsBatchExport   bool
+
+	// MetricsExporter specifies which metrics exporter to use: "otlp", "prometheus", "manual", or "none".
+	// "manual" collects metrics only when CollectMetrics is called, for use in tests.
 	// When empty, defaults to "otlp" if OTel is enabled via environment variables.
 	// Can be overridden by OTEL_METRICS_EXPORTER environment variable.
 	MetricsExporter string
@@ -38,6 +90,277 @@ type Options struct {
 	// When false (default), use PrometheusHandler() to get the handler and register it
 	// with your own HTTP server. Only used when MetricsExporter is "prometheus".
 	PrometheusServer bool
+
+	// PrometheusOpenMetrics serves the OpenMetrics format to scrapers that
+	// request application/openmetrics-text, which is required for exemplars.
+	// Other scrapers still get the classic text format.
+	PrometheusOpenMetrics bool
+	// PrometheusTimeout bounds how long a scrape may take before it fails with
+	// 503 Service Unavailable (default: no timeout).
+	PrometheusTimeout time.Duration
+	// PrometheusMaxRequestsInFlight limits concurrent scrapes; additional scrapes
+	// get 503 Service Unavailable (default: no limit).
+	PrometheusMaxRequestsInFlight int
+	// PrometheusExemplars attaches the trace and span ID of the active sampled
+	// span as an exemplar to histogram and counter measurements served by the
+	// Prometheus handler, so Grafana can jump from a metric to the trace.
+	// Implies PrometheusOpenMetrics, since exemplars need the OpenMetrics format.
+	PrometheusExemplars bool
+
+	// MetricCardinalityLimit caps the number of distinct attribute sets each
+	// instrument keeps. Measurements beyond the limit are aggregated into a single
+	// overflow series with the attribute otel.metric.overflow=true, so a buggy
+	// label can't blow up the Prometheus endpoint or the collector.
+	// When zero (default), the SDK default applies.
+	MetricCardinalityLimit int
+
+	// HistogramBucketPresets applies a bucket preset (BucketsHTTPLatencyMs,
+	// BucketsDBLatencyMs, or BucketsPayloadBytes) to the histograms whose name
+	// matches each key, e.g. {"http.client.duration": "http_latency_ms"}. A key
+	// may use "*" and "?" wildcards. This replaces the SDK default boundaries,
+	// which are sized for milliseconds up to 10s, without changing
+	// instrumentation code.
+	HistogramBucketPresets map[string]string
+
+	// LogsExporter specifies which logs exporter to use: "otlp", "fluentforward", or "none".
+	// Multiple exporters can be combined with a comma-separated list (e.g., "otlp,fluentforward").
+	// When empty, defaults to "otlp" if OTel is enabled via environment variables.
+	// Can be overridden by OTEL_LOGS_EXPORTER environment variable.
+	LogsExporter string
+
+	// GRPCConn, when set, is shared by the OTLP log, trace, and metric exporters
+	// instead of each dialing its own connection to the collector. The caller
+	// owns the connection and must close it after Shutdown; its target and
+	// credentials replace the endpoint and TLS settings from environment variables.
+	GRPCConn *grpc.ClientConn
+
+	// TracesEndpoint, MetricsEndpoint, and LogsEndpoint set the OTLP gRPC
+	// endpoint per signal (e.g., "https://traces.example.com:4317" or
+	// "collector:4317"), so signals can go to different backends without
+	// environment variables. Setting an endpoint enables its signal.
+	// A comma-separated list (e.g., "collector:4317,https://otlp.vendor.com")
+	// exports the signal to every endpoint, each with its own exporter, e.g.
+	// to an on-prem collector and a SaaS vendor during a migration.
+	// Each is overridden by its OTEL_EXPORTER_OTLP_<SIGNAL>_ENDPOINT environment
+	// variable, which may hold a list too, and is not used with GRPCConn or
+	// KafkaProducer.
+	TracesEndpoint  string
+	MetricsEndpoint string
+	LogsEndpoint    string
+
+	// ExporterInsecure disables TLS for every OTLP gRPC exporter, whether the
+	// endpoint comes from Options or environment variables, so "host:port"
+	// endpoints connect in plaintext without an "http://" scheme. An "http://"
+	// endpoint is always insecure. Not used with GRPCConn or KafkaProducer.
+	// Can be overridden by OTEL_EXPORTER_OTLP_INSECURE environment variable.
+	ExporterInsecure bool
+
+	// TracesInsecure, MetricsInsecure, and LogsInsecure disable TLS for one
+	// signal's exporter, like ExporterInsecure.
+	// Can be overridden by the OTEL_EXPORTER_OTLP_<SIGNAL>_INSECURE environment variables.
+	TracesInsecure  bool
+	MetricsInsecure bool
+	LogsInsecure    bool
+
+	// TracesSampler is the trace sampler: "always_on", "always_off",
+	// "traceidratio", "parentbased_always_on", "parentbased_always_off", or
+	// "parentbased_traceidratio". When empty (default), the SDK default
+	// ("parentbased_always_on") applies.
+	// Overridden by the OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG environment variables.
+	TracesSampler string
+
+	// TracesSamplerRatio is the fraction of traces sampled by the ratio samplers (0 to 1).
+	TracesSamplerRatio float64
+
+	// TracesSamplingRules set the sampling ratio per span name or HTTP route, so
+	// noisy endpoints don't drown out valuable traces, e.g.
+	// {Match: "/healthz", Ratio: 0} and {Match: "/checkout", Ratio: 1}. The first
+	// matching rule decides for a root span; spans no rule matches use
+	// TracesSampler, and child spans follow their parent.
+	// Ignored when the OTEL_TRACES_SAMPLER environment variable is set.
+	TracesSamplingRules []SamplingRule
+
+	// SpanNameRewrites rewrite span names when spans start, in order, e.g.
+	// {Pattern: `/users/\d+`, Replacement: "/users/{id}"}, so IDs in span names
+	// don't create one series per request in the backends.
+	SpanNameRewrites []SpanNameRewrite
+
+	// MaxSpanNames caps the number of distinct span names (after
+	// SpanNameRewrites); spans with new names beyond the limit are named "other".
+	// When zero (default), span names are not limited.
+	MaxSpanNames int
+
+	// SpanProcessors and LogProcessors, when set, return additional processors
+	// (enrichment, filtering, fan-out) for the tracer and logger providers. They
+	// run before the exporting processors, so they can modify spans when they
+	// start and log records before they are exported. They are called each time
+	// the providers are built, by New and Reconfigure, and must return new
+	// processors each time since the providers shut down their processors.
+	// Processors are only added when the signal is enabled.
+	SpanProcessors func() []sdktrace.SpanProcessor
+	LogProcessors  func() []sdklog.Processor
+
+	// CustomSpanExporter, CustomLogExporter, and CustomMetricReader export to
+	// backends this package doesn't support, alongside the configured exporters.
+	// Each enables its signal unless OTEL_SDK_DISABLED is set, and gets the same
+	// resource, processors (BatchExport, AsyncLogs, LogsMinSeverity), logger
+	// wiring, and shutdown as the built-in exporters. They are reported as
+	// "custom" in ExporterEndpoints. The providers shut them down, so pass new
+	// instances to Reconfigure.
+	CustomSpanExporter sdktrace.SpanExporter
+	CustomLogExporter  sdklog.Exporter
+	CustomMetricReader sdkmetric.Reader
+
+	// ResourceAttributes are added to the resource alongside the service name,
+	// service version, and host name.
+	ResourceAttributes []attribute.KeyValue
+
+	// KafkaProducer, when set, publishes the OTLP exports to Kafka instead of
+	// sending them to a collector: each export request is published as an OTLP
+	// protobuf payload to the topic of its signal, ready for the Collector Kafka
+	// receiver. Signals are enabled and exported with "otlp" as usual.
+	KafkaProducer KafkaProducer
+
+	// KafkaTracesTopic, KafkaMetricsTopic, and KafkaLogsTopic are the topics used
+	// with KafkaProducer (default: "otlp_spans", "otlp_metrics", "otlp_logs").
+	KafkaTracesTopic  string
+	KafkaMetricsTopic string
+	KafkaLogsTopic    string
+
+	// LogsMinSeverity is the lowest severity forwarded to the logs exporters
+	// (e.g., otellog.SeverityInfo to keep debug logs on the console only).
+	// All hooks consult the OTel logger before building a record, so the
+	// threshold applies consistently to every logger integration.
+	// When zero (default), all severities are forwarded.
+	// Can be overridden by the LOGS_MIN_SEVERITY environment variable.
+	LogsMinSeverity otellog.Severity
+
+	// ComponentLevels overrides LogsMinSeverity per component (instrumentation
+	// scope name, as passed to LoggerFor), e.g. {"database": otellog.SeverityDebug}
+	// exports debug logs from the database component only. Overrides can be
+	// changed at runtime with SetComponentLevel.
+	ComponentLevels map[string]otellog.Severity
+
+	// ExceptionStackTraces captures the stack at the error site as the
+	// exception.stacktrace attribute, on error log records that carry an error
+	// and on errors recorded with RecordError (default: false, since capturing
+	// a stack is expensive). The exception.type and exception.message
+	// attributes are always set.
+	ExceptionStackTraces bool
+
+	// LogsAsSpanEvents records logs emitted within a recording span as events on
+	// that span instead of exporting them, for backends with good trace views
+	// but poor or no log support. The log context must carry the span (e.g.,
+	// logrus WithContext or zaphook.Context); logs outside a span are exported
+	// as usual. LogsMinSeverity and ComponentLevels apply to the span events.
+	LogsAsSpanEvents bool
+
+	// UseContribBridges registers the managed logger provider as the OTel
+	// global logger provider, so the upstream contrib bridges (otelslog,
+	// otelzap, otellogrus, ...) can be used instead of the hooks in this
+	// repository without passing a provider. Loggers they create follow
+	// Reconfigure and stop exporting after Shutdown.
+	UseContribBridges bool
+
+	// CorrelationID adds the correlation ID carried by the context (see
+	// ContextWithCorrelationID) to every log record and span as the
+	// correlation.id attribute. The HTTP middleware reads it from the
+	// CorrelationIDHeader request header, generates one if it is missing, and
+	// echoes it in the response header.
+	CorrelationID bool
+
+	// CorrelationIDHeader is the HTTP header that carries the correlation ID
+	// (default: "X-Request-ID"). Only used when CorrelationID is true.
+	CorrelationIDHeader string
+
+	// RecoverPanics makes the HTTP middleware respond with 500 after capturing a
+	// panic from a handler (see Telemetry.CapturePanic). When false (default),
+	// the panic is captured and then re-raised, so an outer recovery handler or
+	// net/http still sees it.
+	RecoverPanics bool
+
+	// FluentForwardAddress is the Fluentd/Fluent Bit forward input address (default: "localhost:24224").
+	// Use "unix:///path/to/socket" to connect over a unix socket.
+	// Only used when LogsExporter includes "fluentforward".
+	// Can be overridden by FLUENT_FORWARD_ADDRESS environment variable.
+	FluentForwardAddress string
+
+	// FluentForwardTag is the tag attached to forwarded records (default: the service name).
+	// Only used when LogsExporter includes "fluentforward".
+	// Can be overridden by FLUENT_FORWARD_TAG environment variable.
+	FluentForwardTag string
+
+	// FluentForwardRequireAck asks the forward input to acknowledge each export
+	// (the Forward protocol "chunk" option). An export only succeeds once its
+	// chunk is acknowledged, and is resent on a new connection otherwise.
+	// Only used when LogsExporter includes "fluentforward".
+	// Can be overridden by FLUENT_FORWARD_REQUIRE_ACK environment variable.
+	FluentForwardRequireAck bool
+
+	// AsyncLogs makes log emission non-blocking: records are queued and exported
+	// by a background worker, so a stalled exporter doesn't add latency to the
+	// logging call. Records are dropped when the queue is full and counted in the
+	// telemetry.log.queue.dropped metric.
+	AsyncLogs bool
+
+	// AsyncLogQueueSize is the number of records the async queue holds (default: 2048).
+	// Only used when AsyncLogs is true.
+	AsyncLogQueueSize int
+
+	// ShutdownTimeout bounds how long Shutdown waits for providers to flush and
+	// shut down. When zero (default), only the context passed to Shutdown applies.
+	ShutdownTimeout time.Duration
+
+	// LogDiagnostics writes a one-line summary of the resolved configuration
+	// (see Telemetry.Diagnostics) to stderr when the telemetry starts.
+	LogDiagnostics bool
+
+	// SentryDSN enables the Sentry integration: Error and Fatal log records, and
+	// spans ended with an error status, are also sent to Sentry as error events
+	// with stack traces and trace IDs, alongside the normal export. If logs are
+	// otherwise disabled, a logger provider is created for Sentry alone.
+	// Can be overridden by SENTRY_DSN environment variable.
+	SentryDSN string
+
+	// SentryEnvironment is the environment reported with Sentry events (e.g., "production").
+	// Can be overridden by SENTRY_ENVIRONMENT environment variable.
+	SentryEnvironment string
+
+	// SpoolDir enables a disk-backed buffer for OTLP export: requests that fail
+	// because the collector is unreachable are written to this directory and
+	// replayed, oldest first, once an export succeeds again (and every 30s
+	// until Shutdown). Each signal and endpoint has its own subdirectory, and
+	// its requests are only replayed to that endpoint. Spooled exports are
+	// reported as degraded in ExporterState. Not used with GRPCConn or KafkaProducer.
+	// When empty (default), failed exports are dropped after the exporter's retries.
+	SpoolDir string
+
+	// SpoolMaxBytes caps the size of SpoolDir (default: 64 MiB); the oldest
+	// requests are dropped when it is exceeded.
+	SpoolMaxBytes int64
+
+	// The fields below are set by New and Reconfigure on their own copy of the
+	// Options; the Telemetry built from that copy owns them.
+
+	// health is set by New so the OTLP exporters report into ExporterState
+	health *exportHealth
+	// levels holds the ComponentLevels overrides, changed by SetComponentLevel
+	levels *componentLevels
+	// spool is set by New when SpoolDir is set
+	spool *spool
+	// sentry is set by New when SentryDSN is set
+	sentry *sentryClient
+	// meterProvider is set by New when metrics are enabled, so the telemetry's
+	// own instruments don't depend on the global meter provider
+	meterProvider metric.MeterProvider
+	// kafkaConn is set by New when KafkaProducer is set
+	kafkaConn *grpc.ClientConn
+	// otlpEndpointEntry is set on the copies made by otlpEndpointOptions, whose
+	// endpoint is one entry of a list and takes precedence over the environment
+	otlpEndpointEntry bool
+	// declarative is set when the options come from OTEL_EXPERIMENTAL_CONFIG_FILE,
+	// so other environment variables are ignored
+	declarative bool
 }
 
 // DefaultOptions returns Options with default values.
@@ -48,7 +371,21 @@ func DefaultOptions() *Options {
 		BatchExport:    false, // Default to simple/immediate export
 		PrometheusPort: 9090,
 		PrometheusPath: "/metrics",
+
+		FluentForwardAddress: defaultFluentForwardAddress,
+		AsyncLogQueueSize:    defaultAsyncLogQueueSize,
+	}
+}
+
+// applyOverrides applies the OpenTelemetry declarative configuration file
+// named by OTEL_EXPERIMENTAL_CONFIG_FILE if set, and the environment variable
+// overrides otherwise.
+func (o *Options) applyOverrides() error {
+	if path := os.Getenv("OTEL_EXPERIMENTAL_CONFIG_FILE"); path != "" {
+		return o.applyDeclarativeConfig(path)
 	}
+	o.applyEnvVars()
+	return nil
 }
 
 // applyEnvVars applies environment variable overrides to the options.
@@ -56,8 +393,14 @@ func DefaultOptions() *Options {
 // - OTEL_SERVICE_NAME: service name
 // - OTEL_SERVICE_VERSION: service version (if supported)
 // - OTEL_METRICS_EXPORTER: metrics exporter type (otlp, prometheus, none)
+// - OTEL_EXPORTER_OTLP_INSECURE, OTEL_EXPORTER_OTLP_<SIGNAL>_INSECURE: disable TLS for the OTLP exporters
 // - PROMETHEUS_PORT: Prometheus HTTP port (default: 9090)
 // - PROMETHEUS_PATH: Prometheus HTTP path (default: /metrics)
+// - OTEL_LOGS_EXPORTER: logs exporter type (otlp, fluentforward, none)
+// - FLUENT_FORWARD_ADDRESS: Fluentd/Fluent Bit forward input address
+// - FLUENT_FORWARD_TAG: tag attached to forwarded records
+// - SENTRY_DSN, SENTRY_ENVIRONMENT: Sentry integration settings
+// - LOGS_MIN_SEVERITY: lowest severity forwarded to the logs exporters (trace, debug, info, warn, error, fatal)
 func (o *Options) applyEnvVars() {
 	if v := os.Getenv("OTEL_SERVICE_NAME"); v != "" {
 		o.ServiceName = v
@@ -70,6 +413,18 @@ func (o *Options) applyEnvVars() {
 	if v := os.Getenv("OTEL_METRICS_EXPORTER"); v != "" {
 		o.MetricsExporter = v
 	}
+	if v, err := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_INSECURE")); err == nil {
+		o.ExporterInsecure = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_INSECURE")); err == nil {
+		o.TracesInsecure = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_METRICS_INSECURE")); err == nil {
+		o.MetricsInsecure = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("OTEL_EXPORTER_OTLP_LOGS_INSECURE")); err == nil {
+		o.LogsInsecure = v
+	}
 	if v := os.Getenv("PROMETHEUS_PORT"); v != "" {
 		if port, err := strconv.Atoi(v); err == nil {
 			o.PrometheusPort = port
@@ -78,14 +433,76 @@ func (o *Options) applyEnvVars() {
 	if v := os.Getenv("PROMETHEUS_PATH"); v != "" {
 		o.PrometheusPath = v
 	}
+	if v := os.Getenv("OTEL_LOGS_EXPORTER"); v != "" {
+		o.LogsExporter = v
+	}
+	if v := os.Getenv("FLUENT_FORWARD_ADDRESS"); v != "" {
+		o.FluentForwardAddress = v
+	}
+	if v := os.Getenv("FLUENT_FORWARD_TAG"); v != "" {
+		o.FluentForwardTag = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("FLUENT_FORWARD_REQUIRE_ACK")); err == nil {
+		o.FluentForwardRequireAck = v
+	}
+	if v := os.Getenv("SENTRY_DSN"); v != "" {
+		o.SentryDSN = v
+	}
+	if v := os.Getenv("SENTRY_ENVIRONMENT"); v != "" {
+		o.SentryEnvironment = v
+	}
+	if v := os.Getenv("LOGS_MIN_SEVERITY"); v != "" {
+		if severity, ok := parseSeverity(v); ok {
+			o.LogsMinSeverity = severity
+		}
+	}
+}
+
+// ParseSeverity parses a severity name (trace, debug, info, warn, error,
+// fatal), e.g. from a --log-level flag, for Options.LogsMinSeverity.
+func ParseSeverity(s string) (otellog.Severity, error) {
+	severity, ok := parseSeverity(s)
+	if !ok {
+		return otellog.SeverityUndefined, fmt.Errorf("unsupported severity: %s (supported: trace, debug, info, warn, error, fatal)", s)
+	}
+	return severity, nil
+}
+
+// prometheusPath returns PrometheusPath, or "/metrics" if it is empty.
+func (o *Options) prometheusPath() string {
+	if o.PrometheusPath == "" {
+		return "/metrics"
+	}
+	return o.PrometheusPath
+}
+
+// parseSeverity parses a severity name (trace, debug, info, warn, error, fatal).
+func parseSeverity(s string) (otellog.Severity, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return otellog.SeverityTrace, true
+	case "debug":
+		return otellog.SeverityDebug, true
+	case "info":
+		return otellog.SeverityInfo, true
+	case "warn", "warning":
+		return otellog.SeverityWarn, true
+	case "error":
+		return otellog.SeverityError, true
+	case "fatal":
+		return otellog.SeverityFatal, true
+	default:
+		return otellog.SeverityUndefined, false
+	}
 }
 
 // shouldEnableOTel determines if OpenTelemetry should be enabled based on
 // standard OpenTelemetry environment variables.
 // Returns false (no-op) by default, following OTel spec.
 func shouldEnableOTel() bool {
-	// Check OTEL_SDK_DISABLED first - if true, disable everything
-	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+	// Check OTEL_SDK_DISABLED first - if true, disable everything.
+	// With a declarative config file, only the file enables signals.
+	if sdkDisabled() || os.Getenv("OTEL_EXPERIMENTAL_CONFIG_FILE") != "" {
 		return false
 	}
 
@@ -120,6 +537,12 @@ func shouldEnableOTel() bool {
 	return false
 }
 
+// sdkDisabled reports whether the SDK is disabled via OTEL_SDK_DISABLED.
+func sdkDisabled() bool {
+	disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED"))
+	return disabled
+}
+
 // shouldEnableTraces determines if trace collection should be enabled.
 func shouldEnableTraces() bool {
 	if !shouldEnableOTel() {
@@ -130,6 +553,12 @@ func shouldEnableTraces() bool {
 	return exp != "none"
 }
 
+// otlpTracesEnabled reports whether traces are exported with OTLP, as enabled
+// by environment variables or Options.TracesEndpoint.
+func (o *Options) otlpTracesEnabled() bool {
+	return shouldEnableTraces() || o.enabledByEndpoint("traces")
+}
+
 // shouldEnableMetrics determines if metric collection should be enabled.
 func shouldEnableMetrics() bool {
 	if !shouldEnableOTel() {
@@ -149,3 +578,82 @@ func shouldEnableLogs() bool {
 	// Enable if not explicitly set to "none", default is "otlp"
 	return exp != "none"
 }
+
+// otlpConn returns the gRPC connection shared by the OTLP exporters: the Kafka
+// transport if KafkaProducer is set, otherwise GRPCConn (nil if unset).
+func (o *Options) otlpConn() *grpc.ClientConn {
+	if o.kafkaConn != nil {
+		return o.kafkaConn
+	}
+	return o.GRPCConn
+}
+
+// otlpSignalEndpoint returns the OTLP endpoint set in Options for a signal
+// ("traces", "metrics", or "logs") and whether to connect without TLS.
+// The signal-specific environment variable overrides it: a comma-separated
+// list in the variable is returned to be split like the Options field, and
+// the endpoint is "" for a single endpoint, which the exporter reads itself.
+func (o *Options) otlpSignalEndpoint(signal string) (string, bool) {
+	var endpoint string
+	insecure := o.ExporterInsecure
+	switch signal {
+	case "traces":
+		endpoint, insecure = o.TracesEndpoint, insecure || o.TracesInsecure
+	case "metrics":
+		endpoint, insecure = o.MetricsEndpoint, insecure || o.MetricsInsecure
+	case "logs":
+		endpoint, insecure = o.LogsEndpoint, insecure || o.LogsInsecure
+	}
+
+	if !o.declarative && !o.otlpEndpointEntry {
+		if env := os.Getenv("OTEL_EXPORTER_OTLP_" + strings.ToUpper(signal) + "_ENDPOINT"); env != "" {
+			if strings.Contains(env, ",") {
+				return env, insecure
+			}
+			return "", insecure
+		}
+	}
+	return endpoint, insecure
+}
+
+// otlpEndpointOptions returns one copy of o per entry in the signal's
+// comma-separated OTLP endpoint list, from Options or the environment, each
+// with only that endpoint set, so an exporter is created for every endpoint.
+// Returns o itself if the list has a single entry or a shared connection is used.
+func (o *Options) otlpEndpointOptions(signal string) []*Options {
+	endpoint, _ := o.otlpSignalEndpoint(signal)
+	if !strings.Contains(endpoint, ",") || o.otlpConn() != nil {
+		return []*Options{o}
+	}
+
+	var list []*Options
+	for _, e := range strings.Split(endpoint, ",") {
+		if e = strings.TrimSpace(e); e == "" {
+			continue
+		}
+		c := *o
+		c.otlpEndpointEntry = true
+		switch signal {
+		case "traces":
+			c.TracesEndpoint = e
+		case "metrics":
+			c.MetricsEndpoint = e
+		case "logs":
+			c.LogsEndpoint = e
+		}
+		list = append(list, &c)
+	}
+	return list
+}
+
+// enabledByEndpoint reports whether a signal is enabled because its endpoint
+// is set in Options, unless the SDK or the signal is disabled by environment variables.
+func (o *Options) enabledByEndpoint(signal string) bool {
+	if endpoint, _ := o.otlpSignalEndpoint(signal); endpoint == "" {
+		return false
+	}
+	if o.declarative {
+		return true
+	}
+	return !sdkDisabled() && os.Getenv("OTEL_"+strings.ToUpper(signal)+"_EXPORTER") != "none"
+}
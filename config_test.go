@@ -3,6 +3,8 @@ package telemetry
 import (
 	"os"
 	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
 )
 
 func TestDefaultOptions(t *testing.T) {
@@ -576,20 +578,112 @@ func TestDefaultOptions_Values(t *testing.T) {
 func clearOTelEnvVars() {
 	envVars := []string{
 		"OTEL_SDK_DISABLED",
+		"OTEL_EXPERIMENTAL_CONFIG_FILE",
 		"OTEL_SERVICE_NAME",
 		"OTEL_SERVICE_VERSION",
 		"OTEL_EXPORTER_OTLP_ENDPOINT",
 		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
 		"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
 		"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+		"OTEL_EXPORTER_OTLP_INSECURE",
+		"OTEL_EXPORTER_OTLP_TRACES_INSECURE",
+		"OTEL_EXPORTER_OTLP_METRICS_INSECURE",
+		"OTEL_EXPORTER_OTLP_LOGS_INSECURE",
 		"OTEL_TRACES_EXPORTER",
 		"OTEL_METRICS_EXPORTER",
 		"OTEL_LOGS_EXPORTER",
 		"PROMETHEUS_PORT",
 		"PROMETHEUS_PATH",
+		"FLUENT_FORWARD_ADDRESS",
+		"FLUENT_FORWARD_TAG",
+		"SENTRY_DSN",
+		"SENTRY_ENVIRONMENT",
+		"LOGS_MIN_SEVERITY",
 	}
 
 	for _, v := range envVars {
 		os.Unsetenv(v)
 	}
 }
+
+func TestOptions_applyEnvVars_LogsExporter(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_LOGS_EXPORTER", "otlp,fluentforward")
+	os.Setenv("FLUENT_FORWARD_ADDRESS", "unix:///var/run/fluent.sock")
+	os.Setenv("FLUENT_FORWARD_TAG", "app.logs")
+
+	opts := DefaultOptions()
+	opts.applyEnvVars()
+
+	if opts.LogsExporter != "otlp,fluentforward" {
+		t.Errorf("LogsExporter = %v, want 'otlp,fluentforward'", opts.LogsExporter)
+	}
+	if opts.FluentForwardAddress != "unix:///var/run/fluent.sock" {
+		t.Errorf("FluentForwardAddress = %v, want 'unix:///var/run/fluent.sock'", opts.FluentForwardAddress)
+	}
+	if opts.FluentForwardTag != "app.logs" {
+		t.Errorf("FluentForwardTag = %v, want 'app.logs'", opts.FluentForwardTag)
+	}
+}
+
+func TestOptions_applyEnvVars_LogsMinSeverity(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	tests := []struct {
+		value string
+		want  otellog.Severity
+	}{
+		{"debug", otellog.SeverityDebug},
+		{"INFO", otellog.SeverityInfo},
+		{"warning", otellog.SeverityWarn},
+		{"error", otellog.SeverityError},
+		{"bogus", otellog.SeverityUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			os.Setenv("LOGS_MIN_SEVERITY", tt.value)
+
+			opts := DefaultOptions()
+			opts.applyEnvVars()
+
+			if opts.LogsMinSeverity != tt.want {
+				t.Errorf("LogsMinSeverity = %v, want %v", opts.LogsMinSeverity, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if got, err := ParseSeverity("Warn"); err != nil || got != otellog.SeverityWarn {
+		t.Errorf("ParseSeverity(Warn) = %v, %v, want %v", got, err, otellog.SeverityWarn)
+	}
+	if _, err := ParseSeverity("loud"); err == nil {
+		t.Error("ParseSeverity(loud) error = nil, want error")
+	}
+}
+
+func TestOptions_applyEnvVars_Insecure(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+	os.Setenv("OTEL_EXPORTER_OTLP_LOGS_INSECURE", "false")
+	os.Setenv("OTEL_EXPORTER_OTLP_METRICS_INSECURE", "bogus")
+
+	opts := &Options{LogsInsecure: true, MetricsInsecure: true}
+	opts.applyEnvVars()
+
+	if !opts.ExporterInsecure {
+		t.Error("ExporterInsecure = false, want true from OTEL_EXPORTER_OTLP_INSECURE")
+	}
+	if opts.LogsInsecure {
+		t.Error("LogsInsecure = true, want false from OTEL_EXPORTER_OTLP_LOGS_INSECURE")
+	}
+	if !opts.MetricsInsecure {
+		t.Error("MetricsInsecure = false, want the option kept for an invalid value")
+	}
+}
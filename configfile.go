@@ -0,0 +1,214 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the schema of a configuration file loaded by LoadOptions.
+//
+//	service:
+//	  name: checkout
+//	  version: ${VERSION:-dev}
+//	  namespace: shop
+//	  environment: ${ENVIRONMENT:-development}
+//	batch_export: true
+//	shutdown_timeout: 10s
+//	traces:
+//	  endpoint: https://traces.example.com:4317
+//	  sampler: parentbased_traceidratio
+//	  sample_ratio: 0.1
+//	  rules:
+//	    - {match: /healthz, ratio: 0}
+//	metrics:
+//	  exporter: prometheus
+//	  prometheus:
+//	    port: 9090
+//	    path: /metrics
+//	    server: true
+//	logs:
+//	  exporter: otlp
+//	  endpoint: logs.internal:4317
+//	  insecure: true
+//	  level: info
+type fileConfig struct {
+	Service struct {
+		Name        string `yaml:"name" json:"name"`
+		Version     string `yaml:"version" json:"version"`
+		Namespace   string `yaml:"namespace" json:"namespace"`
+		Environment string `yaml:"environment" json:"environment"`
+		InstanceID  string `yaml:"instance_id" json:"instance_id"`
+	} `yaml:"service" json:"service"`
+
+	BatchExport     *bool  `yaml:"batch_export" json:"batch_export"`
+	ShutdownTimeout string `yaml:"shutdown_timeout" json:"shutdown_timeout"`
+
+	Traces struct {
+		Endpoint    string   `yaml:"endpoint" json:"endpoint"`
+		Insecure    bool     `yaml:"insecure" json:"insecure"`
+		Sampler     string   `yaml:"sampler" json:"sampler"`
+		SampleRatio *float64 `yaml:"sample_ratio" json:"sample_ratio"`
+		Rules       []struct {
+			Match string  `yaml:"match" json:"match"`
+			Ratio float64 `yaml:"ratio" json:"ratio"`
+		} `yaml:"rules" json:"rules"`
+	} `yaml:"traces" json:"traces"`
+
+	Metrics struct {
+		Exporter         string            `yaml:"exporter" json:"exporter"`
+		Endpoint         string            `yaml:"endpoint" json:"endpoint"`
+		Insecure         bool              `yaml:"insecure" json:"insecure"`
+		CardinalityLimit int               `yaml:"cardinality_limit" json:"cardinality_limit"`
+		HistogramBuckets map[string]string `yaml:"histogram_buckets" json:"histogram_buckets"`
+		Prometheus       struct {
+			Port   int    `yaml:"port" json:"port"`
+			Path   string `yaml:"path" json:"path"`
+			Server bool   `yaml:"server" json:"server"`
+		} `yaml:"prometheus" json:"prometheus"`
+	} `yaml:"metrics" json:"metrics"`
+
+	Logs struct {
+		Exporter  string `yaml:"exporter" json:"exporter"`
+		Endpoint  string `yaml:"endpoint" json:"endpoint"`
+		Insecure  bool   `yaml:"insecure" json:"insecure"`
+		Level     string `yaml:"level" json:"level"`
+		Async     bool   `yaml:"async" json:"async"`
+		QueueSize int    `yaml:"queue_size" json:"queue_size"`
+	} `yaml:"logs" json:"logs"`
+}
+
+// NewFromConfigFile creates a Telemetry instance from a YAML or JSON
+// configuration file; see LoadOptions. Environment variables override the
+// file, as they override Options passed to New.
+func NewFromConfigFile(ctx context.Context, path string) (*Telemetry, error) {
+	opts, err := LoadOptions(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(ctx, opts)
+}
+
+// LoadOptions loads Options from a YAML or JSON configuration file, starting
+// from DefaultOptions. Files ending in ".json" are parsed as JSON, anything
+// else as YAML. References to environment variables, written $VAR, ${VAR},
+// or ${VAR:-default}, are expanded before parsing, so one templated file can
+// serve a whole fleet. Unknown keys are rejected to catch typos.
+func LoadOptions(path string) (*Options, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telemetry config: %w", err)
+	}
+	data = []byte(expandEnv(string(data)))
+
+	var cfg fileConfig
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(&cfg); errors.Is(err, io.EOF) {
+			// An empty file leaves the defaults in place
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse telemetry config %s: %w", path, err)
+	}
+
+	opts := DefaultOptions()
+	if err := cfg.apply(opts); err != nil {
+		return nil, fmt.Errorf("invalid telemetry config %s: %w", path, err)
+	}
+	return opts, nil
+}
+
+// apply copies the values set in the file onto opts.
+func (c *fileConfig) apply(opts *Options) error {
+	if c.Service.Name != "" {
+		opts.ServiceName = c.Service.Name
+	}
+	if c.Service.Version != "" {
+		opts.ServiceVersion = c.Service.Version
+	}
+	opts.Namespace = c.Service.Namespace
+	opts.Environment = c.Service.Environment
+	opts.InstanceID = c.Service.InstanceID
+	if c.BatchExport != nil {
+		opts.BatchExport = *c.BatchExport
+	}
+	if c.ShutdownTimeout != "" {
+		timeout, err := time.ParseDuration(c.ShutdownTimeout)
+		if err != nil {
+			return fmt.Errorf("shutdown_timeout: %w", err)
+		}
+		opts.ShutdownTimeout = timeout
+	}
+
+	opts.TracesEndpoint = c.Traces.Endpoint
+	opts.TracesInsecure = c.Traces.Insecure
+	opts.TracesSampler = c.Traces.Sampler
+	if c.Traces.SampleRatio != nil {
+		opts.TracesSamplerRatio = *c.Traces.SampleRatio
+	}
+	for _, rule := range c.Traces.Rules {
+		opts.TracesSamplingRules = append(opts.TracesSamplingRules, SamplingRule{Match: rule.Match, Ratio: rule.Ratio})
+	}
+
+	if c.Metrics.Exporter != "" {
+		opts.MetricsExporter = c.Metrics.Exporter
+	}
+	opts.MetricsEndpoint = c.Metrics.Endpoint
+	opts.MetricsInsecure = c.Metrics.Insecure
+	opts.MetricCardinalityLimit = c.Metrics.CardinalityLimit
+	opts.HistogramBucketPresets = c.Metrics.HistogramBuckets
+	if c.Metrics.Prometheus.Port != 0 {
+		opts.PrometheusPort = c.Metrics.Prometheus.Port
+	}
+	if c.Metrics.Prometheus.Path != "" {
+		opts.PrometheusPath = c.Metrics.Prometheus.Path
+	}
+	opts.PrometheusServer = c.Metrics.Prometheus.Server
+
+	if c.Logs.Exporter != "" {
+		opts.LogsExporter = c.Logs.Exporter
+	}
+	opts.LogsEndpoint = c.Logs.Endpoint
+	opts.LogsInsecure = c.Logs.Insecure
+	if c.Logs.Level != "" {
+		severity, ok := parseSeverity(c.Logs.Level)
+		if !ok {
+			return fmt.Errorf("logs.level: unknown level %q", c.Logs.Level)
+		}
+		opts.LogsMinSeverity = severity
+	}
+	opts.AsyncLogs = c.Logs.Async
+	if c.Logs.QueueSize != 0 {
+		opts.AsyncLogQueueSize = c.Logs.QueueSize
+	}
+
+	return nil
+}
+
+// expandEnv replaces $VAR, ${VAR}, and ${VAR:-default} with environment
+// variable values. The default is used when VAR is unset or empty.
+func expandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		name, fallback, hasDefault := strings.Cut(key, ":-")
+		if v := os.Getenv(name); v != "" || !hasDefault {
+			return v
+		}
+		return fallback
+	})
+}
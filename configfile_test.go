@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadOptions_YAML(t *testing.T) {
+	t.Setenv("TEST_SERVICE_VERSION", "2.0.0")
+
+	path := writeConfigFile(t, "telemetry.yaml", `
+service:
+  name: checkout
+  version: ${TEST_SERVICE_VERSION}
+batch_export: true
+shutdown_timeout: 5s
+traces:
+  endpoint: https://traces.example.com:4317
+  sampler: parentbased_traceidratio
+  sample_ratio: 0.1
+metrics:
+  exporter: prometheus
+  prometheus:
+    port: 9464
+    server: true
+logs:
+  exporter: otlp
+  endpoint: ${TEST_LOGS_ENDPOINT:-logs.internal:4317}
+  insecure: true
+  level: warn
+`)
+
+	opts, err := LoadOptions(path)
+	if err != nil {
+		t.Fatalf("LoadOptions() error = %v", err)
+	}
+
+	if opts.ServiceName != "checkout" || opts.ServiceVersion != "2.0.0" {
+		t.Errorf("service = %s@%s, want checkout@2.0.0", opts.ServiceName, opts.ServiceVersion)
+	}
+	if !opts.BatchExport || opts.ShutdownTimeout != 5*time.Second {
+		t.Errorf("BatchExport = %v, ShutdownTimeout = %v, want true, 5s", opts.BatchExport, opts.ShutdownTimeout)
+	}
+	if opts.TracesEndpoint != "https://traces.example.com:4317" || opts.TracesSampler != "parentbased_traceidratio" || opts.TracesSamplerRatio != 0.1 {
+		t.Errorf("traces = %s %s %v, want endpoint, sampler, and ratio from file", opts.TracesEndpoint, opts.TracesSampler, opts.TracesSamplerRatio)
+	}
+	if opts.MetricsExporter != "prometheus" || opts.PrometheusPort != 9464 || opts.PrometheusPath != "/metrics" || !opts.PrometheusServer {
+		t.Errorf("metrics = %s :%d%s server=%v, want prometheus :9464/metrics server=true",
+			opts.MetricsExporter, opts.PrometheusPort, opts.PrometheusPath, opts.PrometheusServer)
+	}
+	if opts.LogsEndpoint != "logs.internal:4317" || !opts.LogsInsecure {
+		t.Errorf("LogsEndpoint = %s (insecure %v), want the ${VAR:-default} fallback", opts.LogsEndpoint, opts.LogsInsecure)
+	}
+	if opts.LogsMinSeverity != otellog.SeverityWarn {
+		t.Errorf("LogsMinSeverity = %v, want %v", opts.LogsMinSeverity, otellog.SeverityWarn)
+	}
+}
+
+func TestLoadOptions_JSON(t *testing.T) {
+	path := writeConfigFile(t, "telemetry.json", `{"service": {"name": "api"}, "logs": {"exporter": "none"}}`)
+
+	opts, err := LoadOptions(path)
+	if err != nil {
+		t.Fatalf("LoadOptions() error = %v", err)
+	}
+	if opts.ServiceName != "api" || opts.LogsExporter != "none" {
+		t.Errorf("ServiceName = %s, LogsExporter = %s, want api, none", opts.ServiceName, opts.LogsExporter)
+	}
+	if opts.PrometheusPort != 9090 {
+		t.Errorf("PrometheusPort = %d, want default 9090", opts.PrometheusPort)
+	}
+}
+
+func TestLoadOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{name: "unknown key", file: "c.yaml", content: "servce:\n  name: typo\n"},
+		{name: "unknown JSON key", file: "c.json", content: `{"servce": {}}`},
+		{name: "bad level", file: "c.yaml", content: "logs:\n  level: loud\n"},
+		{name: "bad duration", file: "c.yaml", content: "shutdown_timeout: soon\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadOptions(writeConfigFile(t, tt.file, tt.content)); err == nil {
+				t.Error("LoadOptions() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := LoadOptions(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadOptions() error = nil for a missing file, want error")
+	}
+}
@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// cgroupFile and mountInfoFile are read to detect the container ID.
+var (
+	cgroupFile    = "/proc/self/cgroup"
+	mountInfoFile = "/proc/self/mountinfo"
+)
+
+var (
+	// containerIDPattern matches a container ID: 64 hex characters.
+	containerIDPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	// mountInfoIDPattern matches the container ID in the paths of the files
+	// Docker and containerd mount into a container (hostname, resolv.conf).
+	mountInfoIDPattern = regexp.MustCompile(`/(?:containers|sandboxes)/([0-9a-f]{64})/`)
+)
+
+// detectContainerID returns the ID of the container the process runs in, or
+// "" outside a container. It is read once from the cgroup file (cgroup v1,
+// or v2 without a cgroup namespace) and falls back to the mount info (cgroup
+// v2, where the cgroup path is "/" inside the container).
+var detectContainerID = sync.OnceValue(func() string {
+	if data, err := os.ReadFile(cgroupFile); err == nil {
+		if id := parseCgroupContainerID(data); id != "" {
+			return id
+		}
+	}
+	if data, err := os.ReadFile(mountInfoFile); err == nil {
+		return parseMountInfoContainerID(data)
+	}
+	return ""
+})
+
+// parseCgroupContainerID returns the container ID in the last path segment of
+// a /proc/self/cgroup line, e.g. "/docker/<id>",
+// "/kubepods/burstable/pod<uid>/<id>", or
+// "/system.slice/cri-containerd-<id>.scope".
+func parseCgroupContainerID(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		segment := parts[2][strings.LastIndex(parts[2], "/")+1:]
+		segment = strings.TrimSuffix(segment, ".scope")
+		if i := strings.LastIndexAny(segment, "-:"); i >= 0 {
+			segment = segment[i+1:]
+		}
+		if containerIDPattern.MatchString(segment) {
+			return segment
+		}
+	}
+	return ""
+}
+
+// parseMountInfoContainerID returns the container ID found in the source of
+// the /etc/hostname, /etc/hosts, or /etc/resolv.conf mount listed in
+// /proc/self/mountinfo. Other mounts are ignored, since a host running
+// containers also lists theirs.
+func parseMountInfoContainerID(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// mount-ID parent-ID major:minor root mount-point ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		switch fields[4] {
+		case "/etc/hostname", "/etc/hosts", "/etc/resolv.conf":
+		default:
+			continue
+		}
+		if m := mountInfoIDPattern.FindStringSubmatch(fields[3]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCgroupContainerID(t *testing.T) {
+	id := strings.Repeat("0123456789abcdef", 4)
+
+	tests := []struct {
+		name   string
+		cgroup string
+		want   string
+	}{
+		{"docker", "12:memory:/docker/" + id + "\n", id},
+		{"kubernetes", "11:cpu,cpuacct:/kubepods/burstable/pod6f6a1d2c-4b8e-4c1a-9f0e-1b2c3d4e5f60/" + id + "\n", id},
+		{"systemd", "1:name=systemd:/system.slice/docker-" + id + ".scope\n", id},
+		{"containerd", "0::/kubepods.slice/cri-containerd-" + id + ".scope\n", id},
+		{"cgroup v2 namespace", "0::/\n", ""},
+		{"host", "12:memory:/user.slice\n0::/user.slice/user-1000.slice\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCgroupContainerID([]byte(tt.cgroup)); got != tt.want {
+				t.Errorf("parseCgroupContainerID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMountInfoContainerID(t *testing.T) {
+	id := strings.Repeat("fedcba9876543210", 4)
+	other := strings.Repeat("0123456789abcdef", 4)
+
+	mountInfo := strings.Join([]string{
+		"1270 1269 0:61 / /dev/shm rw - tmpfs shm rw",
+		"1280 1269 0:62 /var/lib/docker/containers/" + other + "/mounts/shm /other/shm rw - tmpfs shm rw",
+		"1281 1269 259:1 /var/lib/docker/containers/" + id + "/hostname /etc/hostname rw - ext4 /dev/root rw",
+	}, "\n")
+
+	if got := parseMountInfoContainerID([]byte(mountInfo)); got != id {
+		t.Errorf("parseMountInfoContainerID() = %q, want %q", got, id)
+	}
+
+	host := "1280 1269 0:62 /var/lib/docker/containers/" + other + "/mounts/shm /var/lib/docker/containers/" + other + "/mounts/shm rw - tmpfs shm rw"
+	if got := parseMountInfoContainerID([]byte(host)); got != "" {
+		t.Errorf("parseMountInfoContainerID() on a host = %q, want empty", got)
+	}
+}
@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"context"
+
+	otellog "go.opentelemetry.io/otel/log"
+	logembedded "go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// installContribBridges registers t as the global logger provider when
+// Options.UseContribBridges is set.
+func installContribBridges(t *Telemetry, opts *Options) {
+	if opts.UseContribBridges {
+		global.SetLoggerProvider(&reconfigurableLoggerProvider{t: t})
+	}
+}
+
+// reconfigurableLoggerProvider is an OTel logger provider that hands out
+// loggers forwarding to the logger provider of the current configuration, so
+// loggers created by the contrib bridges stay valid across Reconfigure.
+type reconfigurableLoggerProvider struct {
+	logembedded.LoggerProvider
+	t *Telemetry
+}
+
+func (p *reconfigurableLoggerProvider) Logger(name string, opts ...otellog.LoggerOption) otellog.Logger {
+	return &reconfigurableScopedLogger{t: p.t, name: name, opts: opts}
+}
+
+// reconfigurableScopedLogger forwards to the logger with its instrumentation
+// scope from the current logger provider, and drops records while logs are
+// disabled.
+type reconfigurableScopedLogger struct {
+	logembedded.Logger
+	t    *Telemetry
+	name string
+	opts []otellog.LoggerOption
+}
+
+// current returns the logger of the current configuration, or nil if logs
+// are disabled. The SDK caches loggers per scope, so this is a map lookup.
+func (l *reconfigurableScopedLogger) current() otellog.Logger {
+	lp := l.t.LoggerProvider()
+	if lp == nil {
+		return nil
+	}
+	return lp.Logger(l.name, l.opts...)
+}
+
+func (l *reconfigurableScopedLogger) Emit(ctx context.Context, record otellog.Record) {
+	if logger := l.current(); logger != nil {
+		logger.Emit(ctx, record)
+	}
+}
+
+func (l *reconfigurableScopedLogger) Enabled(ctx context.Context, param otellog.EnabledParameters) bool {
+	logger := l.current()
+	return logger != nil && logger.Enabled(ctx, param)
+}
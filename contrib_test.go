@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestNew_UseContribBridges(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+	defer global.SetLoggerProvider(lognoop.NewLoggerProvider())
+
+	ctx := context.Background()
+	first := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:       "test-service",
+		CustomLogExporter: first,
+		UseContribBridges: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	// A contrib bridge created without a provider uses the global one
+	logger := global.GetLoggerProvider().Logger("otelslog", otellog.WithInstrumentationVersion("1.2.3"))
+
+	emit := func(msg string) {
+		var record otellog.Record
+		record.SetBody(otellog.StringValue(msg))
+		record.SetSeverity(otellog.SeverityInfo)
+		logger.Emit(ctx, record)
+	}
+
+	emit("before reconfigure")
+	records := first.Records()
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+	if records[0].Scope != "otelslog" || records[0].ScopeVersion != "1.2.3" {
+		t.Errorf("scope = %s %s, want otelslog 1.2.3", records[0].Scope, records[0].ScopeVersion)
+	}
+
+	second := &telemetrytest.LogRecorder{}
+	if err := tel.Reconfigure(ctx, &Options{
+		ServiceName:       "test-service",
+		CustomLogExporter: second,
+		UseContribBridges: true,
+	}); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	emit("after reconfigure")
+	second.AssertLogged(t, telemetrytest.WithMessage("after reconfigure"), telemetrytest.WithScope("otelslog"))
+	first.AssertNotLogged(t, telemetrytest.WithMessage("after reconfigure"))
+}
+
+func TestNew_UseContribBridgesDisabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+	defer global.SetLoggerProvider(lognoop.NewLoggerProvider())
+
+	ctx := context.Background()
+	global.SetLoggerProvider(lognoop.NewLoggerProvider())
+
+	tel, err := New(ctx, &Options{
+		ServiceName:       "test-service",
+		CustomLogExporter: &telemetrytest.LogRecorder{},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if _, ok := global.GetLoggerProvider().(*reconfigurableLoggerProvider); ok {
+		t.Error("global logger provider was replaced without UseContribBridges")
+	}
+}
@@ -0,0 +1,158 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DefaultCorrelationIDHeader is the HTTP header that carries the correlation
+// ID when Options.CorrelationIDHeader is not set.
+const DefaultCorrelationIDHeader = "X-Request-ID"
+
+// correlationIDKey is the log attribute, span attribute, and baggage member
+// that carry the correlation ID.
+const correlationIDKey = "correlation.id"
+
+// maxCorrelationIDLength bounds incoming correlation IDs, so a client can't
+// inject arbitrarily long values into every log record.
+const maxCorrelationIDLength = 128
+
+// correlationIDContextKey is the context key for the correlation ID.
+type correlationIDContextKey struct{}
+
+// NewCorrelationID returns a new random correlation ID (32 hex characters).
+func NewCorrelationID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// ContextWithCorrelationID returns a copy of ctx that carries the correlation
+// ID id. It is also added to the baggage, so it reaches downstream services
+// when the baggage propagator is configured. With Options.CorrelationID set,
+// logs and spans emitted in the returned context carry it as the
+// correlation.id attribute.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, correlationIDContextKey{}, id)
+	if member, err := baggage.NewMemberRaw(correlationIDKey, id); err == nil {
+		if bag, err := baggage.FromContext(ctx).SetMember(member); err == nil {
+			ctx = baggage.ContextWithBaggage(ctx, bag)
+		}
+	}
+	return ctx
+}
+
+// CorrelationIDFromContext returns the correlation ID carried by ctx, set by
+// ContextWithCorrelationID or received in the baggage, or "" if there is none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(correlationIDContextKey{}).(string); ok {
+		return id
+	}
+	return baggage.FromContext(ctx).Member(correlationIDKey).Value()
+}
+
+// CorrelationIDHeader returns the HTTP header that carries the correlation ID,
+// or "" if Options.CorrelationID is not set.
+func (t *Telemetry) CorrelationIDHeader() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.cfg == nil || !t.cfg.CorrelationID {
+		return ""
+	}
+	if t.cfg.CorrelationIDHeader != "" {
+		return t.cfg.CorrelationIDHeader
+	}
+	return DefaultCorrelationIDHeader
+}
+
+// ExtractCorrelationID returns a copy of ctx carrying the correlation ID read
+// from the CorrelationIDHeader in carrier (usually the request headers), along
+// with the ID. If the header is missing or invalid, the ID already in ctx
+// (e.g., from the propagated baggage) is used, or a new one is generated. The
+// ID should be echoed in the response header. It returns ctx and "" if
+// Options.CorrelationID is not set.
+func (t *Telemetry) ExtractCorrelationID(ctx context.Context, carrier propagation.TextMapCarrier) (context.Context, string) {
+	header := t.CorrelationIDHeader()
+	if header == "" {
+		return ctx, ""
+	}
+	id := carrier.Get(header)
+	if !validCorrelationID(id) {
+		id = CorrelationIDFromContext(ctx)
+	}
+	if !validCorrelationID(id) {
+		id = NewCorrelationID()
+	}
+	return ContextWithCorrelationID(ctx, id), id
+}
+
+// validCorrelationID reports whether id is a non-empty correlation ID of
+// printable ASCII characters within maxCorrelationIDLength.
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// correlationLogProcessor is a log processor that adds the correlation.id
+// attribute to records emitted in a context carrying a correlation ID. It
+// must be registered ahead of the exporting processors.
+type correlationLogProcessor struct{}
+
+var _ sdklog.Processor = (*correlationLogProcessor)(nil)
+
+// OnEmit adds the correlation ID from ctx to the record.
+func (p *correlationLogProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		record.AddAttributes(otellog.String(correlationIDKey, id))
+	}
+	return nil
+}
+
+// Enabled returns false, so the processor never enables a record on its own.
+func (p *correlationLogProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool {
+	return false
+}
+
+// Shutdown does nothing.
+func (p *correlationLogProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *correlationLogProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// correlationSpanProcessor is a span processor that adds the correlation.id
+// attribute to spans started in a context carrying a correlation ID.
+type correlationSpanProcessor struct{}
+
+func (p *correlationSpanProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		s.SetAttributes(attribute.String(correlationIDKey, id))
+	}
+}
+
+func (p *correlationSpanProcessor) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (p *correlationSpanProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *correlationSpanProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_CorrelationID(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+		CorrelationID:      true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if got := tel.CorrelationIDHeader(); got != DefaultCorrelationIDHeader {
+		t.Errorf("CorrelationIDHeader() = %q, want %q", got, DefaultCorrelationIDHeader)
+	}
+
+	header := http.Header{}
+	header.Set(DefaultCorrelationIDHeader, "req-123")
+	reqCtx, id := tel.ExtractCorrelationID(ctx, propagation.HeaderCarrier(header))
+	if id != "req-123" || CorrelationIDFromContext(reqCtx) != "req-123" {
+		t.Fatalf("ExtractCorrelationID() = %q (context %q), want req-123", id, CorrelationIDFromContext(reqCtx))
+	}
+
+	spanCtx, span := tel.StartSpan(reqCtx, "handler")
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("handled"))
+	tel.Logger().Emit(spanCtx, record)
+	span.End()
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	spanAttrs := attribute.NewSet(got[0].Attributes...)
+	if v, _ := spanAttrs.Value(correlationIDKey); v.AsString() != "req-123" {
+		t.Errorf("span %s = %q, want req-123", correlationIDKey, v.AsString())
+	}
+	records := logs.Records()
+	if len(records) != 1 || records[0].Attributes[correlationIDKey].AsString() != "req-123" {
+		t.Errorf("log records = %v, want one with %s=req-123", records, correlationIDKey)
+	}
+}
+
+func TestTelemetry_ExtractCorrelationID(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	disabled, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer disabled.Shutdown(ctx)
+
+	if _, id := disabled.ExtractCorrelationID(ctx, propagation.MapCarrier{}); id != "" {
+		t.Errorf("ExtractCorrelationID() without CorrelationID = %q, want empty", id)
+	}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:         "test-service",
+		CorrelationID:       true,
+		CorrelationIDHeader: "X-Correlation-ID",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "missing", header: ""},
+		{name: "too long", header: strings.Repeat("a", maxCorrelationIDLength+1)},
+		{name: "control characters", header: "id\nforged=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{"X-Correlation-ID": tt.header}
+			_, id := tel.ExtractCorrelationID(ctx, carrier)
+			if id == tt.header || len(id) != 32 {
+				t.Errorf("ExtractCorrelationID() = %q, want a new 32-character ID", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package telemetry
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultDeclarativeOTLPEndpoint is the OTLP gRPC endpoint used by the
+// declarative configuration when an exporter has no endpoint.
+const defaultDeclarativeOTLPEndpoint = "http://localhost:4317"
+
+// declarativeConfig is the subset of the OpenTelemetry declarative (file)
+// configuration schema that maps onto Options. Keys outside this subset, such
+// as propagators or limits, are ignored.
+type declarativeConfig struct {
+	FileFormat string `yaml:"file_format"`
+	Disabled   bool   `yaml:"disabled"`
+
+	Resource struct {
+		Attributes []struct {
+			Name  string `yaml:"name"`
+			Value any    `yaml:"value"`
+		} `yaml:"attributes"`
+	} `yaml:"resource"`
+
+	TracerProvider *struct {
+		Processors []declarativeProcessor `yaml:"processors"`
+		Sampler    *declarativeSampler    `yaml:"sampler"`
+	} `yaml:"tracer_provider"`
+
+	MeterProvider *struct {
+		Readers []struct {
+			Periodic *struct {
+				Exporter declarativeExporter `yaml:"exporter"`
+			} `yaml:"periodic"`
+			Pull *struct {
+				Exporter struct {
+					Prometheus *struct {
+						Port int `yaml:"port"`
+					} `yaml:"prometheus"`
+				} `yaml:"exporter"`
+			} `yaml:"pull"`
+		} `yaml:"readers"`
+	} `yaml:"meter_provider"`
+
+	LoggerProvider *struct {
+		Processors []declarativeProcessor `yaml:"processors"`
+	} `yaml:"logger_provider"`
+}
+
+// declarativeProcessor is a span or log record processor.
+type declarativeProcessor struct {
+	Batch *struct {
+		Exporter declarativeExporter `yaml:"exporter"`
+	} `yaml:"batch"`
+	Simple *struct {
+		Exporter declarativeExporter `yaml:"exporter"`
+	} `yaml:"simple"`
+}
+
+// exporter returns the processor's exporter and whether it batches.
+func (p declarativeProcessor) exporter() (declarativeExporter, bool) {
+	if p.Batch != nil {
+		return p.Batch.Exporter, true
+	}
+	if p.Simple != nil {
+		return p.Simple.Exporter, false
+	}
+	return declarativeExporter{}, false
+}
+
+// declarativeExporter is a push exporter. "otlp" is the name used by older
+// schema versions, "otlp_grpc" by newer ones.
+type declarativeExporter struct {
+	OTLP     *declarativeOTLP `yaml:"otlp"`
+	OTLPGRPC *declarativeOTLP `yaml:"otlp_grpc"`
+}
+
+// declarativeOTLP configures an OTLP exporter.
+type declarativeOTLP struct {
+	Protocol string `yaml:"protocol"`
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+// otlp returns the OTLP gRPC endpoint of the exporter and whether it is insecure.
+func (e declarativeExporter) otlp() (string, bool, error) {
+	otlp := e.OTLPGRPC
+	if otlp == nil {
+		otlp = e.OTLP
+	}
+	if otlp == nil {
+		return "", false, errors.New("unsupported exporter (supported: otlp, otlp_grpc)")
+	}
+	if otlp.Protocol != "" && otlp.Protocol != "grpc" {
+		return "", false, fmt.Errorf("unsupported OTLP protocol: %s (supported: grpc)", otlp.Protocol)
+	}
+
+	endpoint := otlp.Endpoint
+	if endpoint == "" {
+		endpoint = defaultDeclarativeOTLPEndpoint
+	}
+	return endpoint, otlp.Insecure, nil
+}
+
+// declarativeSampler is a sampler in the declarative configuration.
+type declarativeSampler struct {
+	AlwaysOn          *struct{} `yaml:"always_on"`
+	AlwaysOff         *struct{} `yaml:"always_off"`
+	TraceIDRatioBased *struct {
+		Ratio float64 `yaml:"ratio"`
+	} `yaml:"trace_id_ratio_based"`
+	ParentBased *struct {
+		Root *declarativeSampler `yaml:"root"`
+	} `yaml:"parent_based"`
+}
+
+// options returns the TracesSampler and TracesSamplerRatio for the sampler.
+func (s *declarativeSampler) options() (string, float64, error) {
+	switch {
+	case s.AlwaysOn != nil:
+		return "always_on", 0, nil
+	case s.AlwaysOff != nil:
+		return "always_off", 0, nil
+	case s.TraceIDRatioBased != nil:
+		return "traceidratio", s.TraceIDRatioBased.Ratio, nil
+	case s.ParentBased != nil:
+		if s.ParentBased.Root == nil {
+			return "parentbased_always_on", 0, nil
+		}
+		root, ratio, err := s.ParentBased.Root.options()
+		if err != nil {
+			return "", 0, err
+		}
+		if strings.HasPrefix(root, "parentbased_") {
+			return "", 0, errors.New("unsupported sampler: nested parent_based")
+		}
+		return "parentbased_" + root, ratio, nil
+	default:
+		return "", 0, errors.New("unsupported sampler (supported: always_on, always_off, trace_id_ratio_based, parent_based)")
+	}
+}
+
+// applyDeclarativeConfig configures opts from an OpenTelemetry declarative
+// configuration file, as named by OTEL_EXPERIMENTAL_CONFIG_FILE. ${VAR} and
+// ${VAR:-default} references are expanded. As the specification requires, a
+// signal is exported only if the file configures it, and other environment
+// variables don't override the file.
+func (o *Options) applyDeclarativeConfig(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenTelemetry config file: %w", err)
+	}
+
+	var cfg declarativeConfig
+	if err := yaml.NewDecoder(bytes.NewReader([]byte(expandEnv(string(data))))).Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse OpenTelemetry config file %s: %w", path, err)
+	}
+
+	if err := cfg.apply(o); err != nil {
+		return fmt.Errorf("invalid OpenTelemetry config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// apply maps the configuration onto opts.
+func (c *declarativeConfig) apply(opts *Options) error {
+	opts.declarative = true
+	opts.LogsExporter = "none"
+	opts.MetricsExporter = "none"
+	opts.TracesEndpoint = ""
+	opts.TracesSampler = "parentbased_always_on"
+	// The processors set batching per signal
+	opts.BatchExport = false
+
+	for _, attr := range c.Resource.Attributes {
+		switch attr.Name {
+		case "service.name":
+			opts.ServiceName = fmt.Sprint(attr.Value)
+		case "service.version":
+			opts.ServiceVersion = fmt.Sprint(attr.Value)
+		default:
+			opts.ResourceAttributes = append(opts.ResourceAttributes, declarativeAttribute(attr.Name, attr.Value))
+		}
+	}
+
+	if c.Disabled {
+		return nil
+	}
+
+	if tp := c.TracerProvider; tp != nil {
+		if len(tp.Processors) > 1 {
+			return errors.New("tracer_provider: only one processor is supported")
+		}
+		for _, processor := range tp.Processors {
+			exporter, batch := processor.exporter()
+			endpoint, insecure, err := exporter.otlp()
+			if err != nil {
+				return fmt.Errorf("tracer_provider: %w", err)
+			}
+			opts.TracesEndpoint, opts.TracesInsecure, opts.TracesBatchExport = endpoint, insecure, batch
+		}
+		if tp.Sampler != nil {
+			sampler, ratio, err := tp.Sampler.options()
+			if err != nil {
+				return fmt.Errorf("tracer_provider: %w", err)
+			}
+			opts.TracesSampler, opts.TracesSamplerRatio = sampler, ratio
+		}
+	}
+
+	if mp := c.MeterProvider; mp != nil {
+		var exporters []string
+		for _, reader := range mp.Readers {
+			switch {
+			case reader.Periodic != nil:
+				endpoint, insecure, err := reader.Periodic.Exporter.otlp()
+				if err != nil {
+					return fmt.Errorf("meter_provider: %w", err)
+				}
+				opts.MetricsEndpoint, opts.MetricsInsecure = endpoint, insecure
+				exporters = append(exporters, "otlp")
+			case reader.Pull != nil && reader.Pull.Exporter.Prometheus != nil:
+				opts.PrometheusServer = true
+				if port := reader.Pull.Exporter.Prometheus.Port; port != 0 {
+					opts.PrometheusPort = port
+				}
+				exporters = append(exporters, "prometheus")
+			default:
+				return errors.New("meter_provider: unsupported reader (supported: periodic, pull with prometheus)")
+			}
+		}
+		if len(exporters) > 0 {
+			opts.MetricsExporter = strings.Join(exporters, ",")
+		}
+	}
+
+	if lp := c.LoggerProvider; lp != nil {
+		if len(lp.Processors) > 1 {
+			return errors.New("logger_provider: only one processor is supported")
+		}
+		for _, processor := range lp.Processors {
+			exporter, batch := processor.exporter()
+			endpoint, insecure, err := exporter.otlp()
+			if err != nil {
+				return fmt.Errorf("logger_provider: %w", err)
+			}
+			opts.LogsEndpoint, opts.LogsInsecure, opts.LogsBatchExport = endpoint, insecure, batch
+			opts.LogsExporter = "otlp"
+		}
+	}
+
+	return nil
+}
+
+// declarativeAttribute converts a resource attribute value parsed from YAML.
+func declarativeAttribute(name string, value any) attribute.KeyValue {
+	switch v := value.(type) {
+	case bool:
+		return attribute.Bool(name, v)
+	case int:
+		return attribute.Int(name, v)
+	case float64:
+		return attribute.Float64(name, v)
+	case string:
+		return attribute.String(name, v)
+	default:
+		return attribute.String(name, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,241 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestOptions_applyDeclarativeConfig(t *testing.T) {
+	t.Setenv("TEST_COLLECTOR", "collector.internal:4317")
+
+	path := writeConfigFile(t, "otel.yaml", `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: checkout
+    - name: deployment.environment
+      value: production
+    - name: shard
+      value: 3
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: grpc
+            endpoint: http://${TEST_COLLECTOR}
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based:
+          ratio: 0.25
+meter_provider:
+  readers:
+    - periodic:
+        exporter:
+          otlp_grpc:
+            endpoint: ${TEST_METRICS_ENDPOINT:-http://metrics:4317}
+    - pull:
+        exporter:
+          prometheus:
+            port: 9464
+logger_provider:
+  processors:
+    - simple:
+        exporter:
+          otlp:
+            endpoint: http://logs:4317
+propagator:
+  composite: [tracecontext, baggage]
+`)
+
+	opts := DefaultOptions()
+	if err := opts.applyDeclarativeConfig(path); err != nil {
+		t.Fatalf("applyDeclarativeConfig() error = %v", err)
+	}
+
+	if opts.ServiceName != "checkout" {
+		t.Errorf("ServiceName = %s, want checkout", opts.ServiceName)
+	}
+	want := []attribute.KeyValue{attribute.String("deployment.environment", "production"), attribute.Int("shard", 3)}
+	if len(opts.ResourceAttributes) != len(want) || opts.ResourceAttributes[0] != want[0] || opts.ResourceAttributes[1] != want[1] {
+		t.Errorf("ResourceAttributes = %v, want %v", opts.ResourceAttributes, want)
+	}
+	if opts.TracesEndpoint != "http://collector.internal:4317" || !opts.TracesBatchExport {
+		t.Errorf("TracesEndpoint = %s, TracesBatchExport = %v, want http://collector.internal:4317, true", opts.TracesEndpoint, opts.TracesBatchExport)
+	}
+	if opts.BatchExport {
+		t.Error("BatchExport = true, want batching set per signal")
+	}
+	if opts.TracesSampler != "parentbased_traceidratio" || opts.TracesSamplerRatio != 0.25 {
+		t.Errorf("sampler = %s(%v), want parentbased_traceidratio(0.25)", opts.TracesSampler, opts.TracesSamplerRatio)
+	}
+	if opts.MetricsExporter != "otlp,prometheus" || opts.MetricsEndpoint != "http://metrics:4317" || !opts.PrometheusServer || opts.PrometheusPort != 9464 {
+		t.Errorf("metrics = %s %s %v %d, want otlp,prometheus from file", opts.MetricsExporter, opts.MetricsEndpoint, opts.PrometheusServer, opts.PrometheusPort)
+	}
+	if opts.LogsExporter != "otlp" || opts.LogsEndpoint != "http://logs:4317" || opts.LogsBatchExport {
+		t.Errorf("logs = %s %s batch=%v, want otlp http://logs:4317 with a simple processor", opts.LogsExporter, opts.LogsEndpoint, opts.LogsBatchExport)
+	}
+}
+
+func TestOptions_applyDeclarativeConfig_Disabled(t *testing.T) {
+	path := writeConfigFile(t, "otel.yaml", `
+file_format: "0.3"
+disabled: true
+tracer_provider:
+  processors:
+    - simple:
+        exporter:
+          otlp: {}
+`)
+
+	opts := DefaultOptions()
+	if err := opts.applyDeclarativeConfig(path); err != nil {
+		t.Fatalf("applyDeclarativeConfig() error = %v", err)
+	}
+	if opts.TracesEndpoint != "" || opts.MetricsExporter != "none" || opts.LogsExporter != "none" {
+		t.Errorf("options = %s %s %s, want every signal disabled", opts.TracesEndpoint, opts.MetricsExporter, opts.LogsExporter)
+	}
+}
+
+func TestOptions_applyDeclarativeConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "unsupported exporter",
+			content: `
+tracer_provider:
+  processors:
+    - simple:
+        exporter:
+          console: {}
+`,
+			wantErr: "unsupported exporter",
+		},
+		{
+			name: "unsupported protocol",
+			content: `
+logger_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+`,
+			wantErr: "unsupported OTLP protocol",
+		},
+		{
+			name: "unsupported sampler",
+			content: `
+tracer_provider:
+  sampler:
+    jaeger_remote: {}
+`,
+			wantErr: "unsupported sampler",
+		},
+		{
+			name: "multiple span processors",
+			content: `
+tracer_provider:
+  processors:
+    - batch:
+        exporter:
+          otlp: {}
+    - simple:
+        exporter:
+          otlp:
+            endpoint: http://other:4317
+`,
+			wantErr: "tracer_provider: only one processor is supported",
+		},
+		{
+			name: "multiple log processors",
+			content: `
+logger_provider:
+  processors:
+    - simple:
+        exporter:
+          otlp: {}
+    - simple:
+        exporter:
+          otlp: {}
+`,
+			wantErr: "logger_provider: only one processor is supported",
+		},
+		{
+			name:    "invalid YAML",
+			content: "tracer_provider: [",
+			wantErr: "failed to parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, "otel.yaml", tt.content)
+			err := DefaultOptions().applyDeclarativeConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("applyDeclarativeConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew_DeclarativeConfigFile(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	path := writeConfigFile(t, "otel.yaml", `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: declared
+logger_provider:
+  processors:
+    - simple:
+        exporter:
+          otlp:
+            endpoint: http://localhost:4317
+`)
+	t.Setenv("OTEL_EXPERIMENTAL_CONFIG_FILE", path)
+	// Ignored in favor of the file
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+
+	tel, err := New(context.Background(), &Options{ServiceName: "from-options"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	if tel.ServiceName() != "declared" {
+		t.Errorf("ServiceName() = %s, want declared", tel.ServiceName())
+	}
+	if tel.LoggerProvider() == nil {
+		t.Error("LoggerProvider() = nil, want logs enabled by the file")
+	}
+	if tel.TracerProvider() != nil {
+		t.Error("TracerProvider() != nil, want traces disabled when the file omits them")
+	}
+	if tel.MeterProvider() != nil {
+		t.Error("MeterProvider() != nil, want metrics disabled when the file omits them")
+	}
+}
+
+func TestNew_DeclarativeConfigFile_Invalid(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	t.Setenv("OTEL_EXPERIMENTAL_CONFIG_FILE", "/nonexistent/otel.yaml")
+
+	if _, err := New(context.Background(), nil); err == nil {
+		t.Error("New() error = nil, want error for missing config file")
+	}
+}
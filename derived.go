@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// WithAttributes returns a Telemetry derived from t whose logs, spans, and
+// metrics carry attrs as instrumentation scope attributes, e.g. a tenant ID in
+// a multi-tenant service. It shares t's providers and exporters instead of
+// building new ones, so deriving one per request or tenant is cheap.
+//
+// Logger, LoggerFor, Tracer, TracerFor, StartSpan, and the Counter, Histogram,
+// and related shortcuts of the derived Telemetry use the attributes; the
+// providers returned by LoggerProvider, MeterProvider, and TracerProvider are
+// t's and don't. t keeps owning the providers: Shutdown on the derived
+// Telemetry does nothing and Reconfigure returns an error. It uses the
+// configuration t had when it was derived, so derive it again after
+// reconfiguring t.
+func (t *Telemetry) WithAttributes(attrs ...attribute.KeyValue) *Telemetry {
+	return t.derive(nil, attrs)
+}
+
+// CloneWith returns a Telemetry for another logical service hosted in the same
+// process, such as a plugin or an embedded job. It shares t's providers and
+// exporters like WithAttributes. Its logger, tracer, and meter are named after
+// opts.ServiceName and versioned with opts.ServiceVersion, and they carry both
+// as the service.name and service.version scope attributes, so backends can
+// tell the services apart. Empty fields keep t's values and the other fields
+// of opts are ignored. The resource still describes the process.
+func (t *Telemetry) CloneWith(opts *Options) *Telemetry {
+	t.mu.RLock()
+	var cfg Options
+	if t.cfg != nil {
+		cfg = *t.cfg
+	}
+	t.mu.RUnlock()
+
+	if opts != nil && opts.ServiceName != "" {
+		cfg.ServiceName = opts.ServiceName
+	}
+	if opts != nil && opts.ServiceVersion != "" {
+		cfg.ServiceVersion = opts.ServiceVersion
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(cfg.ServiceName)}
+	if cfg.ServiceVersion != "" {
+		attrs = append(attrs, semconv.ServiceVersion(cfg.ServiceVersion))
+	}
+	return t.derive(&cfg, attrs)
+}
+
+// derive returns a Telemetry sharing t's providers, with cfg (t's
+// configuration if nil) and attrs added to t's scope attributes.
+func (t *Telemetry) derive(cfg *Options, attrs []attribute.KeyValue) *Telemetry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	owner := t
+	if t.owner != nil {
+		owner = t.owner
+	}
+	scopeAttrs := append(slices.Clip(t.scopeAttrs), attrs...)
+
+	if cfg == nil {
+		cfg = t.cfg
+	}
+	serviceName, serviceVersion := "", ""
+	if cfg != nil {
+		serviceName, serviceVersion = cfg.ServiceName, cfg.ServiceVersion
+	}
+
+	var logger otellog.Logger
+	if t.lp != nil {
+		logger = t.lp.Logger(serviceName,
+			otellog.WithInstrumentationVersion(serviceVersion),
+			otellog.WithInstrumentationAttributes(scopeAttrs...),
+		)
+	} else {
+		logger = lognoop.NewLoggerProvider().Logger(serviceName)
+	}
+
+	var tracer trace.Tracer
+	if t.tp != nil {
+		tracer = t.tp.Tracer(serviceName,
+			trace.WithInstrumentationVersion(serviceVersion),
+			trace.WithInstrumentationAttributes(scopeAttrs...),
+		)
+	} else {
+		tracer = tracenoop.NewTracerProvider().Tracer(serviceName)
+	}
+
+	child := &Telemetry{
+		cfg:         cfg,
+		lp:          t.lp,
+		mp:          t.mp,
+		tp:          t.tp,
+		logger:      logger,
+		tracer:      tracer,
+		propagator:  t.propagator,
+		promHandler: t.promHandler,
+
+		manualReader: t.manualReader,
+		health:       t.health,
+		levels:       t.levels,
+		endpoints:    t.endpoints,
+		instruments:  newInstrumentCache(t.mp, serviceName, serviceVersion, scopeAttrs...),
+
+		owner:      owner,
+		scopeAttrs: scopeAttrs,
+	}
+	child.loggerHandle = &reconfigurableLogger{t: child}
+	child.tracerHandle = &reconfigurableTracer{t: child}
+	child.lpHandle = t.lpHandle
+	child.promHandle = t.promHandle
+	return child
+}
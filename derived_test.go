@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry_WithAttributes(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	tenant := tel.WithAttributes(attribute.String("tenant.id", "acme"))
+	_, span := tenant.StartSpan(ctx, "tenant-request")
+	span.End()
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	if v, ok := got[0].InstrumentationScope.Attributes.Value("tenant.id"); !ok || v.AsString() != "acme" {
+		t.Errorf("scope tenant.id = %q (present %v), want acme", v.AsString(), ok)
+	}
+
+	if err := tenant.Shutdown(ctx); err != nil {
+		t.Fatalf("derived Shutdown() error = %v", err)
+	}
+	if err := tenant.Reconfigure(ctx, &Options{ServiceName: "other"}); err == nil {
+		t.Error("derived Reconfigure() error = nil, want error")
+	}
+
+	// The parent's providers survive the derived Telemetry's Shutdown
+	_, span = tel.StartSpan(ctx, "parent-request")
+	span.End()
+	if got := spans.GetSpans(); len(got) != 2 {
+		t.Errorf("exported %d spans after derived Shutdown(), want 2", len(got))
+	}
+}
+
+func TestTelemetry_CloneWith(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "host",
+		ServiceVersion:     "1.0.0",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	plugin := tel.CloneWith(&Options{ServiceName: "plugin", ServiceVersion: "0.3.0"})
+	if got := plugin.ServiceName(); got != "plugin" {
+		t.Errorf("ServiceName() = %q, want plugin", got)
+	}
+	if got := tel.ServiceName(); got != "host" {
+		t.Errorf("parent ServiceName() = %q, want host", got)
+	}
+
+	_, span := plugin.StartSpan(ctx, "plugin-work")
+	span.End()
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	scope := got[0].InstrumentationScope
+	if scope.Name != "plugin" || scope.Version != "0.3.0" {
+		t.Errorf("scope = %s@%s, want plugin@0.3.0", scope.Name, scope.Version)
+	}
+	if v, ok := scope.Attributes.Value("service.name"); !ok || v.AsString() != "plugin" {
+		t.Errorf("scope service.name = %q (present %v), want plugin", v.AsString(), ok)
+	}
+
+	if err := plugin.Shutdown(ctx); err != nil {
+		t.Fatalf("cloned Shutdown() error = %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package telemetry
+
+import (
+	"os"
+	"strings"
+)
+
+// diagnosticEnvVars are the environment variables that affect the
+// configuration, reported by Diagnostics when set.
+var diagnosticEnvVars = []string{
+	"OTEL_SDK_DISABLED",
+	"OTEL_EXPERIMENTAL_CONFIG_FILE",
+	"OTEL_SERVICE_NAME",
+	"OTEL_SERVICE_VERSION",
+	"OTEL_RESOURCE_ATTRIBUTES",
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_INSECURE",
+	"OTEL_EXPORTER_OTLP_TRACES_INSECURE",
+	"OTEL_EXPORTER_OTLP_METRICS_INSECURE",
+	"OTEL_EXPORTER_OTLP_LOGS_INSECURE",
+	"OTEL_EXPORTER_OTLP_TIMEOUT",
+	"OTEL_EXPORTER_OTLP_HEADERS",
+	"OTEL_TRACES_EXPORTER",
+	"OTEL_METRICS_EXPORTER",
+	"OTEL_LOGS_EXPORTER",
+	"OTEL_TRACES_SAMPLER",
+	"OTEL_TRACES_SAMPLER_ARG",
+	"PROMETHEUS_PORT",
+	"PROMETHEUS_PATH",
+	"FLUENT_FORWARD_ADDRESS",
+	"FLUENT_FORWARD_TAG",
+	"SENTRY_DSN",
+	"SENTRY_ENVIRONMENT",
+	"LOGS_MIN_SEVERITY",
+}
+
+// redactedEnvVars may carry credentials, so Diagnostics reports them as set without their value.
+var redactedEnvVars = map[string]bool{
+	"OTEL_EXPORTER_OTLP_HEADERS": true,
+	"SENTRY_DSN":                 true,
+}
+
+// Diagnostics is a report of the resolved telemetry configuration.
+type Diagnostics struct {
+	// Options are the resolved options, after environment variable overrides.
+	Options Options
+	// Environment holds the configuration environment variables that are set.
+	// Values that may carry credentials are redacted.
+	Environment map[string]string
+
+	// TracesEnabled, MetricsEnabled, and LogsEnabled report which signals are exported.
+	TracesEnabled  bool
+	MetricsEnabled bool
+	LogsEnabled    bool
+
+	// Endpoints lists the active exporters and where they send telemetry.
+	Endpoints []ExporterEndpoint
+	// Sampler is the trace sampler, as configured by OTEL_TRACES_SAMPLER or
+	// Options.TracesSampler (default: "parentbased_always_on").
+	Sampler string
+	// ExporterState is the current state of the OTLP exporters.
+	ExporterState ExporterState
+}
+
+// Diagnostics returns a structured report of the resolved configuration, for
+// debug endpoints or startup logging. See Options.LogDiagnostics for a
+// one-line summary at startup.
+func (t *Telemetry) Diagnostics() Diagnostics {
+	d := Diagnostics{
+		Environment:    map[string]string{},
+		TracesEnabled:  t.TracesEnabled(),
+		MetricsEnabled: t.MetricsEnabled(),
+		LogsEnabled:    t.LogsEnabled(),
+		Endpoints:      t.ExporterEndpoints(),
+		ExporterState:  t.ExporterState(),
+	}
+
+	t.mu.RLock()
+	if t.cfg != nil {
+		d.Options = *t.cfg
+	}
+	t.mu.RUnlock()
+	d.Sampler = samplerDescription(&d.Options)
+
+	for _, name := range diagnosticEnvVars {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if redactedEnvVars[name] {
+			v = "[redacted]"
+		}
+		d.Environment[name] = v
+	}
+
+	return d
+}
+
+// String returns a one-line summary of the report, e.g.:
+//
+//	service=api version=1.2.0 traces=otlp(localhost:4317) metrics=prometheus logs=disabled sampler=parentbased_always_on
+func (d Diagnostics) String() string {
+	signals := map[string][]string{}
+	for _, e := range d.Endpoints {
+		desc := e.Exporter
+		if e.Endpoint != "" {
+			desc += "(" + e.Endpoint + ")"
+		}
+		if e.Err != nil {
+			desc += "[down]"
+		}
+		signals[e.Signal] = append(signals[e.Signal], desc)
+	}
+
+	parts := []string{
+		"service=" + d.Options.ServiceName,
+		"version=" + d.Options.ServiceVersion,
+	}
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		exporters := "disabled"
+		if len(signals[signal]) > 0 {
+			exporters = strings.Join(signals[signal], ",")
+		}
+		parts = append(parts, signal+"="+exporters)
+	}
+	parts = append(parts, "sampler="+d.Sampler)
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,71 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestTelemetry_Diagnostics(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+	defer os.Unsetenv("OTEL_EXPORTER_OTLP_HEADERS")
+	defer os.Unsetenv("OTEL_TRACES_SAMPLER")
+
+	os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+	os.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "authorization=secret")
+	os.Setenv("OTEL_TRACES_SAMPLER", "always_off")
+	os.Setenv("OTEL_METRICS_EXPORTER", "none")
+	os.Setenv("OTEL_LOGS_EXPORTER", "none")
+
+	ctx := context.Background()
+	tel, err := New(ctx, &Options{ServiceName: "diag-service", ServiceVersion: "1.2.3"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	d := tel.Diagnostics()
+
+	if d.Options.ServiceName != "diag-service" {
+		t.Errorf("Diagnostics().Options.ServiceName = %q, want %q", d.Options.ServiceName, "diag-service")
+	}
+	if !d.TracesEnabled || d.MetricsEnabled || d.LogsEnabled {
+		t.Errorf("Diagnostics() signals = traces:%v metrics:%v logs:%v, want traces only",
+			d.TracesEnabled, d.MetricsEnabled, d.LogsEnabled)
+	}
+	if got := d.Environment["OTEL_EXPORTER_OTLP_ENDPOINT"]; got != "http://collector:4317" {
+		t.Errorf("Diagnostics().Environment[OTEL_EXPORTER_OTLP_ENDPOINT] = %q, want %q", got, "http://collector:4317")
+	}
+	if got := d.Environment["OTEL_EXPORTER_OTLP_HEADERS"]; got != "[redacted]" {
+		t.Errorf("Diagnostics().Environment[OTEL_EXPORTER_OTLP_HEADERS] = %q, want redacted", got)
+	}
+	if _, ok := d.Environment["PROMETHEUS_PORT"]; ok {
+		t.Error("Diagnostics().Environment contains unset PROMETHEUS_PORT")
+	}
+	if d.Sampler != "always_off" {
+		t.Errorf("Diagnostics().Sampler = %q, want %q", d.Sampler, "always_off")
+	}
+
+	want := "service=diag-service version=1.2.3 traces=otlp(http://collector:4317) metrics=disabled logs=disabled sampler=always_off"
+	if got := d.String(); got != want {
+		t.Errorf("Diagnostics().String() = %q, want %q", got, want)
+	}
+}
+
+func TestSamplerDescription(t *testing.T) {
+	defer os.Unsetenv("OTEL_TRACES_SAMPLER")
+	defer os.Unsetenv("OTEL_TRACES_SAMPLER_ARG")
+
+	os.Unsetenv("OTEL_TRACES_SAMPLER")
+	os.Unsetenv("OTEL_TRACES_SAMPLER_ARG")
+	if got := samplerDescription(nil); got != "parentbased_always_on" {
+		t.Errorf("samplerDescription(nil) = %q, want default", got)
+	}
+
+	os.Setenv("OTEL_TRACES_SAMPLER", "traceidratio")
+	os.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+	if got := samplerDescription(nil); got != "traceidratio(0.25)" {
+		t.Errorf("samplerDescription(nil) = %q, want %q", got, "traceidratio(0.25)")
+	}
+}
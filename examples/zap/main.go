@@ -95,7 +95,7 @@ func main() {
 	log.Info("Processing request within span",
 		zap.String("request_id", "req-12345"),
 		zap.String("user_id", "user-67890"),
-		zap.Any("context", ctx), // OTel core extracts trace info from this
+		zaphook.Context(ctx), // OTel core extracts trace info from this, console ignores it
 	)
 
 	log.Info("Request completed successfully",
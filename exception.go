@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// exceptionProcessor is a log processor that adds the exception.type,
+// exception.message, and, if enabled, exception.stacktrace attributes to
+// error records that carry an error, so backends render them as exceptions.
+// The error is taken from the error.type and error.message attributes set by
+// the hooks, or from a plain "error" string attribute. It must be registered
+// ahead of the exporting processors.
+type exceptionProcessor struct {
+	stackTraces bool
+}
+
+var _ sdklog.Processor = (*exceptionProcessor)(nil)
+
+// OnEmit adds the exception attributes to error records.
+func (p *exceptionProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	if record.Severity() < otellog.SeverityError {
+		return nil
+	}
+
+	var errType, errMessage, errString string
+	found := false
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		switch kv.Key {
+		case string(semconv.ExceptionTypeKey), string(semconv.ExceptionMessageKey):
+			// Already mapped, e.g. by the application
+			found = true
+			return false
+		case "error.type":
+			errType = kv.Value.AsString()
+		case "error.message":
+			errMessage = kv.Value.AsString()
+		case "error":
+			if kv.Value.Kind() == otellog.KindString {
+				errString = kv.Value.AsString()
+			}
+		}
+		return true
+	})
+	if found {
+		return nil
+	}
+	if errMessage == "" {
+		errMessage = errString
+	}
+	if errType == "" && errMessage == "" {
+		return nil
+	}
+
+	if errType != "" {
+		record.AddAttributes(otellog.String(string(semconv.ExceptionTypeKey), errType))
+	}
+	if errMessage != "" {
+		record.AddAttributes(otellog.String(string(semconv.ExceptionMessageKey), errMessage))
+	}
+	if p.stackTraces {
+		// OnEmit runs synchronously in the logging call, so this is the error site's stack
+		record.AddAttributes(otellog.String(string(semconv.ExceptionStacktraceKey), string(debug.Stack())))
+	}
+	return nil
+}
+
+// Enabled returns false, so the processor never enables a record on its own.
+func (p *exceptionProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool {
+	return false
+}
+
+// Shutdown does nothing.
+func (p *exceptionProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *exceptionProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// RecordError records err on the span in ctx as an exception event, with the
+// exception.type, exception.message, and, with Options.ExceptionStackTraces,
+// exception.stacktrace attributes, and sets the span status to Error.
+// It does nothing if ctx has no recording span or err is nil.
+func (t *Telemetry) RecordError(ctx context.Context, err error, opts ...trace.EventOption) {
+	t.mu.RLock()
+	stackTraces := t.cfg != nil && t.cfg.ExceptionStackTraces
+	t.mu.RUnlock()
+
+	recordError(ctx, err, stackTraces, opts...)
+}
+
+// recordError records err on the span in ctx and sets the span status to Error.
+func recordError(ctx context.Context, err error, stackTraces bool, opts ...trace.EventOption) {
+	span := trace.SpanFromContext(ctx)
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	span.RecordError(err, append([]trace.EventOption{trace.WithStackTrace(stackTraces)}, opts...)...)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestExceptionProcessor(t *testing.T) {
+	ctx := context.Background()
+
+	recorder := &telemetrytest.LogRecorder{}
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(&exceptionProcessor{stackTraces: true}),
+		sdklog.WithProcessor(sdklog.NewSimpleProcessor(recorder)),
+	)
+	defer lp.Shutdown(ctx)
+	logger := lp.Logger("test")
+
+	emit := func(severity otellog.Severity, body string, attrs ...otellog.KeyValue) {
+		var record otellog.Record
+		record.SetSeverity(severity)
+		record.SetBody(otellog.StringValue(body))
+		record.AddAttributes(attrs...)
+		logger.Emit(ctx, record)
+	}
+	emit(otellog.SeverityError, "hook error",
+		otellog.String("error.type", "*net.OpError"),
+		otellog.String("error.message", "dial tcp: connection refused"),
+	)
+	emit(otellog.SeverityError, "string error", otellog.String("error", "boom"))
+	emit(otellog.SeverityWarn, "warning", otellog.String("error", "ignored"))
+	emit(otellog.SeverityError, "no error")
+
+	recorder.AssertLogged(t,
+		telemetrytest.WithMessage("hook error"),
+		telemetrytest.WithAttr("exception.type", otellog.StringValue("*net.OpError")),
+		telemetrytest.WithAttr("exception.message", otellog.StringValue("dial tcp: connection refused")),
+		telemetrytest.WithAttrKey("exception.stacktrace"),
+	)
+	recorder.AssertLogged(t,
+		telemetrytest.WithMessage("string error"),
+		telemetrytest.WithAttr("exception.message", otellog.StringValue("boom")),
+	)
+	recorder.AssertNotLogged(t, telemetrytest.WithMessage("warning"), telemetrytest.WithAttrKey("exception.message"))
+	recorder.AssertNotLogged(t, telemetrytest.WithMessage("no error"), telemetrytest.WithAttrKey("exception.message"))
+}
+
+func TestTelemetry_RecordError(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	exporter := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:          "test-service",
+		CustomSpanExporter:   exporter,
+		ExceptionStackTraces: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	spanCtx, span := tel.StartSpan(ctx, "checkout")
+	tel.RecordError(spanCtx, errors.New("payment declined"))
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(spans))
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("status = %v, want Error", spans[0].Status.Code)
+	}
+	if len(spans[0].Events) != 1 {
+		t.Fatalf("recorded %d events, want 1 exception event", len(spans[0].Events))
+	}
+	attrs := map[string]string{}
+	for _, kv := range spans[0].Events[0].Attributes {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	if attrs["exception.message"] != "payment declined" {
+		t.Errorf("exception.message = %q, want payment declined", attrs["exception.message"])
+	}
+	if attrs["exception.stacktrace"] == "" {
+		t.Error("exception.stacktrace is empty, want a stack trace with ExceptionStackTraces")
+	}
+}
@@ -0,0 +1,286 @@
+// Package elasticsearch provides an OpenTelemetry log exporter that indexes
+// records into Elasticsearch or OpenSearch with the bulk API.
+//
+// Use it as the custom log exporter of go-telemetry:
+//
+//	tel, err := telemetry.New(ctx, &telemetry.Options{
+//		ServiceName:       "my-service",
+//		CustomLogExporter: elasticsearch.New(elasticsearch.WithURL("https://es.example.com:9200")),
+//	})
+//
+// or with any sdklog processor.
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+const (
+	// DefaultURL is the default Elasticsearch/OpenSearch address.
+	DefaultURL = "http://localhost:9200"
+	// DefaultIndex is the default index or data stream.
+	DefaultIndex = "logs-generic-default"
+	// elasticsearchTimeout bounds each bulk request when the context has no deadline.
+	elasticsearchTimeout = 30 * time.Second
+	// elasticsearchMaxRetries bounds how often documents rejected with 429 are resent.
+	elasticsearchMaxRetries = 5
+	// elasticsearchInitialBackoff is the delay before the first retry; it doubles on each retry.
+	elasticsearchInitialBackoff = 100 * time.Millisecond
+)
+
+// Exporter is a log exporter that indexes records into
+// Elasticsearch or OpenSearch with the bulk API. Documents use ECS field
+// names (@timestamp, message, log.level, service.name, trace.id, span.id), so
+// they correlate with APM traces; record attributes are kept under "attributes".
+//
+// When the cluster applies backpressure (HTTP 429, for the whole request or
+// individual documents), the rejected documents are resent with exponential
+// backoff until the context is done.
+type Exporter struct {
+	url      string
+	index    string
+	apiKey   string
+	username string
+	password string
+	client   *http.Client
+}
+
+// New creates a bulk-indexing exporter. Authentication uses the API key if
+// set, otherwise basic auth if a username is set. The ELASTICSEARCH_URL,
+// ELASTICSEARCH_INDEX, ELASTICSEARCH_API_KEY, ELASTICSEARCH_USERNAME, and
+// ELASTICSEARCH_PASSWORD environment variables override the options.
+func New(opts ...Option) *Exporter {
+	c := newConfig(opts)
+	if v := os.Getenv("ELASTICSEARCH_URL"); v != "" {
+		c.url = v
+	}
+	if v := os.Getenv("ELASTICSEARCH_INDEX"); v != "" {
+		c.index = v
+	}
+	if v := os.Getenv("ELASTICSEARCH_API_KEY"); v != "" {
+		c.apiKey = v
+	}
+	if v := os.Getenv("ELASTICSEARCH_USERNAME"); v != "" {
+		c.username = v
+	}
+	if v := os.Getenv("ELASTICSEARCH_PASSWORD"); v != "" {
+		c.password = v
+	}
+
+	return &Exporter{
+		url:      strings.TrimSuffix(c.url, "/") + "/_bulk",
+		index:    c.index,
+		apiKey:   c.apiKey,
+		username: c.username,
+		password: c.password,
+		client:   &http.Client{Timeout: elasticsearchTimeout},
+	}
+}
+
+// bulkResponse is the part of a bulk API response needed to find
+// rejected documents.
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// Export indexes the records, retrying documents rejected with 429.
+func (e *Exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	docs := make([][]byte, 0, len(records))
+	for i := range records {
+		doc, err := json.Marshal(ecsDocument(&records[i]))
+		if err != nil {
+			return fmt.Errorf("failed to encode log record: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	backoff := elasticsearchInitialBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := e.bulk(ctx, docs)
+		if err != nil || len(retry) == 0 {
+			return err
+		}
+		if attempt == elasticsearchMaxRetries {
+			return fmt.Errorf("failed to index %d log records in %s: rejected with 429 after %d retries", len(retry), e.index, attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to index %d log records in %s: %w", len(retry), e.index, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		docs = retry
+	}
+}
+
+// bulk sends one bulk request and returns the documents rejected with 429,
+// which should be retried. Other failures are returned as an error.
+func (e *Exporter) bulk(ctx context.Context, docs [][]byte) ([][]byte, error) {
+	action, _ := json.Marshal(map[string]any{"create": map[string]string{"_index": e.index}})
+
+	var body bytes.Buffer
+	for _, doc := range docs {
+		body.Write(action)
+		body.WriteByte('\n')
+		body.Write(doc)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	switch {
+	case e.apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+e.apiKey)
+	case e.username != "":
+		req.SetBasicAuth(e.username, e.password)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send bulk request to %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return docs, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("failed to send bulk request to %s: %s: %s", e.url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result bulkResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil, nil
+	}
+
+	var retry [][]byte
+	var failed int
+	var firstErr string
+	for i, item := range result.Items {
+		for _, status := range item {
+			switch {
+			case status.Status == http.StatusTooManyRequests && i < len(docs):
+				retry = append(retry, docs[i])
+			case status.Error != nil:
+				failed++
+				if firstErr == "" {
+					firstErr = status.Error.Type + ": " + status.Error.Reason
+				}
+			}
+		}
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("failed to index %d log records in %s: %s", failed, e.index, firstErr)
+	}
+
+	return retry, nil
+}
+
+// Shutdown closes idle connections to the cluster.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// ForceFlush is a no-op; records are indexed synchronously by Export.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// ecsDocument maps a record to an ECS-compatible document.
+func ecsDocument(record *sdklog.Record) map[string]any {
+	timestamp := record.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = record.ObservedTimestamp()
+	}
+
+	doc := map[string]any{
+		"@timestamp": timestamp.UTC().Format(time.RFC3339Nano),
+		"message":    valueToAny(record.Body()),
+		"log.level":  levelName(record),
+	}
+
+	if res := record.Resource(); res != nil {
+		for _, attr := range res.Attributes() {
+			switch attr.Key {
+			case "service.name", "service.version", "service.namespace":
+				doc[string(attr.Key)] = attr.Value.Emit()
+			case "deployment.environment":
+				doc["service.environment"] = attr.Value.Emit()
+			}
+		}
+	}
+
+	if traceID := record.TraceID(); traceID.IsValid() {
+		doc["trace.id"] = traceID.String()
+	}
+	if spanID := record.SpanID(); spanID.IsValid() {
+		doc["span.id"] = spanID.String()
+	}
+
+	if record.AttributesLen() > 0 {
+		attrs := make(map[string]any, record.AttributesLen())
+		record.WalkAttributes(func(kv otellog.KeyValue) bool {
+			attrs[kv.Key] = valueToAny(kv.Value)
+			return true
+		})
+		doc["attributes"] = attrs
+	}
+
+	return doc
+}
+
+// levelName returns the lowercase level name of a record (trace, debug, info,
+// warn, error, or fatal).
+func levelName(record *sdklog.Record) string {
+	switch severity := record.Severity(); {
+	case severity == otellog.SeverityUndefined:
+		if text := record.SeverityText(); text != "" {
+			return strings.ToLower(text)
+		}
+		return "unknown"
+	case severity < otellog.SeverityDebug:
+		return "trace"
+	case severity < otellog.SeverityInfo:
+		return "debug"
+	case severity < otellog.SeverityWarn:
+		return "info"
+	case severity < otellog.SeverityError:
+		return "warn"
+	case severity < otellog.SeverityFatal:
+		return "error"
+	default:
+		return "fatal"
+	}
+}
@@ -0,0 +1,176 @@
+package elasticsearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestECSDocument(t *testing.T) {
+	traceID := trace.TraceID{0x01}
+	spanID := trace.SpanID{0x02}
+
+	record := newSDKRecord(t)
+	record.SetTimestamp(time.Unix(1700000000, 0))
+	record.SetBody(otellog.StringValue("hello"))
+	record.SetSeverity(otellog.SeverityWarn)
+	record.SetTraceID(traceID)
+	record.SetSpanID(spanID)
+	record.AddAttributes(otellog.String("user", "alice"))
+
+	doc := ecsDocument(&record)
+
+	if doc["@timestamp"] != "2023-11-14T22:13:20Z" {
+		t.Errorf("@timestamp = %v, want 2023-11-14T22:13:20Z", doc["@timestamp"])
+	}
+	if doc["message"] != "hello" {
+		t.Errorf("message = %v, want hello", doc["message"])
+	}
+	if doc["log.level"] != "warn" {
+		t.Errorf("log.level = %v, want warn", doc["log.level"])
+	}
+	if doc["trace.id"] != traceID.String() {
+		t.Errorf("trace.id = %v, want %s", doc["trace.id"], traceID)
+	}
+	if doc["span.id"] != spanID.String() {
+		t.Errorf("span.id = %v, want %s", doc["span.id"], spanID)
+	}
+	if attrs, _ := doc["attributes"].(map[string]any); attrs["user"] != "alice" {
+		t.Errorf("attributes = %v, want user=alice", doc["attributes"])
+	}
+}
+
+func TestElasticsearchExporter_RetriesRejectedDocuments(t *testing.T) {
+	var mu sync.Mutex
+	var requests [][]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" {
+			t.Errorf("request path = %s, want /_bulk", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "ApiKey secret" {
+			t.Errorf("Authorization = %q, want %q", got, "ApiKey secret")
+		}
+
+		var lines []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+
+		mu.Lock()
+		requests = append(requests, lines)
+		first := len(requests) == 1
+		mu.Unlock()
+
+		// Reject the second document of the first request with 429
+		docs := len(lines) / 2
+		items := make([]string, docs)
+		for i := range items {
+			status := 201
+			if first && i == 1 {
+				status = 429
+			}
+			items[i] = fmt.Sprintf(`{"create":{"status":%d}}`, status)
+		}
+		fmt.Fprintf(w, `{"errors":%t,"items":[%s]}`, first, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	exporter := New(WithURL(server.URL), WithIndex("logs-test"), WithAPIKey("secret"))
+
+	records := make([]sdklog.Record, 3)
+	for i := range records {
+		records[i].SetBody(otellog.StringValue(fmt.Sprintf("message %d", i)))
+	}
+
+	if err := exporter.Export(context.Background(), records); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("sent %d bulk requests, want 2", len(requests))
+	}
+	if len(requests[0]) != 6 {
+		t.Errorf("first request has %d lines, want 6", len(requests[0]))
+	}
+
+	var action map[string]map[string]string
+	if err := json.Unmarshal([]byte(requests[0][0]), &action); err != nil || action["create"]["_index"] != "logs-test" {
+		t.Errorf("action = %s, want create in logs-test", requests[0][0])
+	}
+
+	if len(requests[1]) != 2 || !strings.Contains(requests[1][1], "message 1") {
+		t.Errorf("retry request = %v, want only message 1", requests[1])
+	}
+}
+
+func TestElasticsearchExporter_DocumentErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors":true,"items":[{"create":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"bad field"}}}]}`)
+	}))
+	defer server.Close()
+
+	exporter := New(WithURL(server.URL))
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	err := exporter.Export(context.Background(), []sdklog.Record{record})
+	if err == nil || !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Errorf("Export() error = %v, want mapper_parsing_exception", err)
+	}
+}
+
+type recordCapture struct {
+	records []sdklog.Record
+}
+
+func (p *recordCapture) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.records = append(p.records, record.Clone())
+	return nil
+}
+
+func (p *recordCapture) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (p *recordCapture) Shutdown(context.Context) error                         { return nil }
+func (p *recordCapture) ForceFlush(context.Context) error                       { return nil }
+
+// newSDKRecord returns an empty record emitted through a logger provider, so
+// unlike a zero sdklog.Record it keeps attribute values untruncated.
+func newSDKRecord(t testing.TB) sdklog.Record {
+	t.Helper()
+
+	capture := &recordCapture{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(capture))
+	defer lp.Shutdown(context.Background())
+
+	lp.Logger("test").Emit(context.Background(), otellog.Record{})
+	if len(capture.records) != 1 {
+		t.Fatalf("captured %d records, want 1", len(capture.records))
+	}
+	return capture.records[0]
+}
+
+func TestNew_Env(t *testing.T) {
+	t.Setenv("ELASTICSEARCH_URL", "http://es:9200/")
+	t.Setenv("ELASTICSEARCH_INDEX", "logs-env")
+
+	exporter := New(WithURL("http://other:9200"), WithIndex("logs-other"))
+	if exporter.url != "http://es:9200/_bulk" {
+		t.Errorf("url = %q, want %q", exporter.url, "http://es:9200/_bulk")
+	}
+	if exporter.index != "logs-env" {
+		t.Errorf("index = %q, want %q", exporter.index, "logs-env")
+	}
+}
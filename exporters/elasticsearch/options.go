@@ -0,0 +1,55 @@
+package elasticsearch
+
+// Option configures the exporter created by New.
+type Option func(*config)
+
+// config holds the settings applied by Options.
+type config struct {
+	url      string
+	index    string
+	apiKey   string
+	username string
+	password string
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) config {
+	c := config{url: DefaultURL, index: DefaultIndex}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithURL sets the Elasticsearch or OpenSearch address (default: DefaultURL).
+func WithURL(url string) Option {
+	return func(c *config) {
+		if url != "" {
+			c.url = url
+		}
+	}
+}
+
+// WithIndex sets the index or data stream records are written to (default: DefaultIndex).
+func WithIndex(index string) Option {
+	return func(c *config) {
+		if index != "" {
+			c.index = index
+		}
+	}
+}
+
+// WithAPIKey authenticates with an encoded API key. It takes precedence over WithBasicAuth.
+func WithAPIKey(apiKey string) Option {
+	return func(c *config) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBasicAuth authenticates with a username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *config) {
+		c.username = username
+		c.password = password
+	}
+}
@@ -0,0 +1,235 @@
+// Package emf provides an OpenTelemetry metric exporter that writes CloudWatch
+// Embedded Metric Format (EMF) documents.
+//
+// Use it through a periodic reader as the custom metric reader of go-telemetry:
+//
+//	tel, err := telemetry.New(ctx, &telemetry.Options{
+//		ServiceName:        "my-service",
+//		CustomMetricReader: sdkmetric.NewPeriodicReader(emf.New(emf.WithNamespace("my-service"))),
+//	})
+package emf
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+const (
+	// DefaultNamespace is the CloudWatch namespace used when none is set.
+	DefaultNamespace = "aws-embedded-metrics"
+	// maxDimensions is the CloudWatch limit on dimensions per metric.
+	maxDimensions = 30
+)
+
+// Exporter is a metric exporter that writes CloudWatch Embedded Metric
+// Format (EMF) documents, one JSON line per data point. On Lambda and Fargate
+// anything written to stdout lands in CloudWatch Logs, which extracts the
+// metrics without a collector or PutMetricData calls.
+//
+// Data point attributes become dimensions. Counters and histograms are
+// exported with delta temporality, since CloudWatch aggregates per period;
+// histograms are written as statistic sets (min, max, sum, count).
+type Exporter struct {
+	namespace string
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// New creates an EMF exporter. The AWS_EMF_NAMESPACE environment variable
+// overrides the namespace set with WithNamespace.
+func New(opts ...Option) *Exporter {
+	c := newConfig(opts)
+	if v := os.Getenv("AWS_EMF_NAMESPACE"); v != "" {
+		c.namespace = v
+	}
+
+	return &Exporter{namespace: c.namespace, w: c.writer}
+}
+
+// Temporality returns delta temporality for counters and histograms, and
+// cumulative temporality for up-down counters and gauges.
+func (e *Exporter) Temporality(kind sdkmetric.InstrumentKind) metricdata.Temporality {
+	switch kind {
+	case sdkmetric.InstrumentKindCounter, sdkmetric.InstrumentKindObservableCounter, sdkmetric.InstrumentKindHistogram:
+		return metricdata.DeltaTemporality
+	default:
+		return metricdata.CumulativeTemporality
+	}
+}
+
+// Aggregation returns the default aggregation for kind.
+func (e *Exporter) Aggregation(kind sdkmetric.InstrumentKind) sdkmetric.Aggregation {
+	return sdkmetric.DefaultAggregationSelector(kind)
+}
+
+// Export writes one EMF document per data point.
+func (e *Exporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	w := bufio.NewWriter(e.w)
+	enc := json.NewEncoder(w)
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			for _, doc := range e.documents(m) {
+				if err := enc.Encode(doc); err != nil {
+					return fmt.Errorf("failed to write EMF document: %w", err)
+				}
+			}
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write EMF documents: %w", err)
+	}
+	return nil
+}
+
+// ForceFlush is a no-op; documents are written synchronously by Export.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// Shutdown is a no-op; the writer is owned by the caller.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// documents returns the EMF documents for the data points of m.
+func (e *Exporter) documents(m metricdata.Metrics) []map[string]any {
+	unit := cloudWatchUnit(m.Unit)
+	var docs []map[string]any
+
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), dp.Value))
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), dp.Value))
+		}
+	case metricdata.Gauge[int64]:
+		for _, dp := range data.DataPoints {
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), dp.Value))
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range data.DataPoints {
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), dp.Value))
+		}
+	case metricdata.Histogram[int64]:
+		for _, dp := range data.DataPoints {
+			if dp.Count == 0 {
+				continue
+			}
+			value := statisticSet(float64(dp.Sum), dp.Count, dp.Min, dp.Max)
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), value))
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range data.DataPoints {
+			if dp.Count == 0 {
+				continue
+			}
+			value := statisticSet(dp.Sum, dp.Count, dp.Min, dp.Max)
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), value))
+		}
+	case metricdata.ExponentialHistogram[int64]:
+		for _, dp := range data.DataPoints {
+			if dp.Count == 0 {
+				continue
+			}
+			value := statisticSet(float64(dp.Sum), dp.Count, dp.Min, dp.Max)
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), value))
+		}
+	case metricdata.ExponentialHistogram[float64]:
+		for _, dp := range data.DataPoints {
+			if dp.Count == 0 {
+				continue
+			}
+			value := statisticSet(dp.Sum, dp.Count, dp.Min, dp.Max)
+			docs = append(docs, e.document(m.Name, unit, dp.Attributes, dp.Time.UnixMilli(), value))
+		}
+	}
+
+	return docs
+}
+
+// document builds an EMF document for one metric value.
+func (e *Exporter) document(name, unit string, attrs attribute.Set, timestamp int64, value any) map[string]any {
+	doc := make(map[string]any, attrs.Len()+2)
+
+	dimensions := make([]string, 0, attrs.Len())
+	iter := attrs.Iter()
+	for iter.Next() {
+		kv := iter.Attribute()
+		key := string(kv.Key)
+		if key == name || key == "_aws" {
+			continue
+		}
+		doc[key] = kv.Value.Emit()
+		if len(dimensions) < maxDimensions {
+			dimensions = append(dimensions, key)
+		}
+	}
+
+	doc[name] = value
+	doc["_aws"] = map[string]any{
+		"Timestamp": timestamp,
+		"CloudWatchMetrics": []map[string]any{{
+			"Namespace":  e.namespace,
+			"Dimensions": [][]string{dimensions},
+			"Metrics":    []map[string]string{{"Name": name, "Unit": unit}},
+		}},
+	}
+
+	return doc
+}
+
+// statisticSet returns a histogram as an EMF statistic set. If the
+// histogram does not record extrema, the mean is used for min and max.
+func statisticSet[N int64 | float64](sum float64, count uint64, minimum, maximum metricdata.Extrema[N]) map[string]any {
+	mean := sum / float64(count)
+	minValue, maxValue := mean, mean
+	if v, ok := minimum.Value(); ok {
+		minValue = float64(v)
+	}
+	if v, ok := maximum.Value(); ok {
+		maxValue = float64(v)
+	}
+	return map[string]any{"Min": minValue, "Max": maxValue, "Sum": sum, "Count": count}
+}
+
+// cloudWatchUnit maps a UCUM unit used by OTel instruments to a CloudWatch unit.
+func cloudWatchUnit(unit string) string {
+	switch unit {
+	case "s":
+		return "Seconds"
+	case "ms":
+		return "Milliseconds"
+	case "us":
+		return "Microseconds"
+	case "By":
+		return "Bytes"
+	case "By/s":
+		return "Bytes/Second"
+	case "%":
+		return "Percent"
+	}
+	// Annotations such as "{request}" count things
+	if strings.HasPrefix(unit, "{") {
+		return "Count"
+	}
+	return "None"
+}
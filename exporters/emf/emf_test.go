@@ -0,0 +1,128 @@
+package emf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestEMFExporter_Export(t *testing.T) {
+	var buf bytes.Buffer
+	exporter := New(WithNamespace("my-service"), WithWriter(&buf))
+
+	now := time.Unix(1700000000, 0)
+	attrs := attribute.NewSet(attribute.String("route", "/users"))
+
+	rm := &metricdata.ResourceMetrics{
+		ScopeMetrics: []metricdata.ScopeMetrics{{
+			Metrics: []metricdata.Metrics{
+				{
+					Name: "requests",
+					Unit: "{request}",
+					Data: metricdata.Sum[int64]{
+						Temporality: metricdata.DeltaTemporality,
+						IsMonotonic: true,
+						DataPoints:  []metricdata.DataPoint[int64]{{Attributes: attrs, Time: now, Value: 5}},
+					},
+				},
+				{
+					Name: "duration",
+					Unit: "s",
+					Data: metricdata.Histogram[float64]{
+						Temporality: metricdata.DeltaTemporality,
+						DataPoints: []metricdata.HistogramDataPoint[float64]{{
+							Attributes: attrs,
+							Time:       now,
+							Count:      2,
+							Sum:        0.5,
+							Min:        metricdata.NewExtrema(0.1),
+							Max:        metricdata.NewExtrema(0.4),
+						}},
+					},
+				},
+			},
+		}},
+	}
+
+	if err := exporter.Export(context.Background(), rm); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("wrote %d EMF documents, want 2", len(lines))
+	}
+
+	var counter struct {
+		AWS struct {
+			Timestamp         int64 `json:"Timestamp"`
+			CloudWatchMetrics []struct {
+				Namespace  string              `json:"Namespace"`
+				Dimensions [][]string          `json:"Dimensions"`
+				Metrics    []map[string]string `json:"Metrics"`
+			} `json:"CloudWatchMetrics"`
+		} `json:"_aws"`
+		Route    string `json:"route"`
+		Requests int64  `json:"requests"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &counter); err != nil {
+		t.Fatalf("failed to decode EMF document: %v", err)
+	}
+
+	if counter.AWS.Timestamp != now.UnixMilli() {
+		t.Errorf("Timestamp = %d, want %d", counter.AWS.Timestamp, now.UnixMilli())
+	}
+	directive := counter.AWS.CloudWatchMetrics[0]
+	if directive.Namespace != "my-service" {
+		t.Errorf("Namespace = %q, want %q", directive.Namespace, "my-service")
+	}
+	if len(directive.Dimensions) != 1 || len(directive.Dimensions[0]) != 1 || directive.Dimensions[0][0] != "route" {
+		t.Errorf("Dimensions = %v, want [[route]]", directive.Dimensions)
+	}
+	if directive.Metrics[0]["Name"] != "requests" || directive.Metrics[0]["Unit"] != "Count" {
+		t.Errorf("Metrics = %v, want requests in Count", directive.Metrics)
+	}
+	if counter.Route != "/users" || counter.Requests != 5 {
+		t.Errorf("document = %s, want route=/users requests=5", lines[0])
+	}
+
+	var histogram struct {
+		Duration map[string]float64 `json:"duration"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &histogram); err != nil {
+		t.Fatalf("failed to decode EMF document: %v", err)
+	}
+	want := map[string]float64{"Min": 0.1, "Max": 0.4, "Sum": 0.5, "Count": 2}
+	for key, value := range want {
+		if histogram.Duration[key] != value {
+			t.Errorf("duration[%s] = %v, want %v", key, histogram.Duration[key], value)
+		}
+	}
+}
+
+func TestEMFExporter_Temporality(t *testing.T) {
+	exporter := New(WithNamespace("ns"), WithWriter(&bytes.Buffer{}))
+
+	if got := exporter.Temporality(sdkmetric.InstrumentKindCounter); got != metricdata.DeltaTemporality {
+		t.Errorf("Temporality(Counter) = %v, want delta", got)
+	}
+	if got := exporter.Temporality(sdkmetric.InstrumentKindUpDownCounter); got != metricdata.CumulativeTemporality {
+		t.Errorf("Temporality(UpDownCounter) = %v, want cumulative", got)
+	}
+}
+
+func TestNew_NamespaceFromEnv(t *testing.T) {
+	t.Setenv("AWS_EMF_NAMESPACE", "from-env")
+
+	exporter := New(WithNamespace("my-service"))
+	if exporter.namespace != "from-env" {
+		t.Errorf("namespace = %q, want %q", exporter.namespace, "from-env")
+	}
+}
@@ -0,0 +1,44 @@
+package emf
+
+import (
+	"io"
+	"os"
+)
+
+// Option configures the exporter created by New.
+type Option func(*config)
+
+// config holds the settings applied by Options.
+type config struct {
+	namespace string
+	writer    io.Writer
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) config {
+	c := config{namespace: DefaultNamespace, writer: os.Stdout}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithNamespace sets the CloudWatch namespace of the metrics, usually the
+// service name (default: DefaultNamespace).
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		if namespace != "" {
+			c.namespace = namespace
+		}
+	}
+}
+
+// WithWriter sets where documents are written (default: os.Stdout, which
+// CloudWatch Logs collects on Lambda and Fargate).
+func WithWriter(w io.Writer) Option {
+	return func(c *config) {
+		if w != nil {
+			c.writer = w
+		}
+	}
+}
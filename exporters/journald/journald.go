@@ -0,0 +1,209 @@
+// Package journald provides an OpenTelemetry log exporter that writes records
+// to the systemd journal using the native journal protocol.
+//
+// Use it as the custom log exporter of go-telemetry:
+//
+//	tel, err := telemetry.New(ctx, &telemetry.Options{
+//		ServiceName:       "my-service",
+//		CustomLogExporter: journald.New(journald.WithIdentifier("my-service")),
+//	})
+//
+// or with any sdklog processor.
+package journald
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// DefaultSocket is the systemd journal native protocol socket.
+const DefaultSocket = "/run/systemd/journal/socket"
+
+// Exporter is a log exporter that writes records to the systemd journal
+// using the native journal protocol, so attributes are stored as structured
+// journal fields (queryable with journalctl FIELD=value) instead of text.
+// Each record is sent as a single datagram.
+type Exporter struct {
+	socket     string
+	identifier string
+
+	mu      sync.Mutex
+	conn    *net.UnixConn
+	stopped bool
+}
+
+// New creates a journald exporter. The JOURNALD_SOCKET environment variable
+// overrides the socket set with WithSocket.
+func New(opts ...Option) *Exporter {
+	c := newConfig(opts)
+	if v := os.Getenv("JOURNALD_SOCKET"); v != "" {
+		c.socket = v
+	}
+
+	return &Exporter{
+		socket:     c.socket,
+		identifier: c.identifier,
+	}
+}
+
+// Export writes the records to the journal socket.
+func (e *Exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped || len(records) == 0 {
+		return nil
+	}
+
+	if e.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: e.socket, Net: "unixgram"})
+		if err != nil {
+			return fmt.Errorf("failed to connect to journald socket %s: %w", e.socket, err)
+		}
+		e.conn = conn
+	}
+
+	var buf bytes.Buffer
+	for i := range records {
+		buf.Reset()
+		e.encode(&buf, &records[i])
+
+		if _, err := e.conn.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to write to journald socket %s: %w", e.socket, err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown closes the journal socket.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stopped = true
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// ForceFlush is a no-op; records are written synchronously by Export.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// encode writes a record as a native journal protocol entry.
+func (e *Exporter) encode(buf *bytes.Buffer, record *sdklog.Record) {
+	journalField(buf, "MESSAGE", journalValue(record.Body()))
+	journalField(buf, "PRIORITY", strconv.Itoa(journalPriority(record.Severity())))
+	if e.identifier != "" {
+		journalField(buf, "SYSLOG_IDENTIFIER", e.identifier)
+	}
+	if text := record.SeverityText(); text != "" {
+		journalField(buf, "SEVERITY", text)
+	}
+	if traceID := record.TraceID(); traceID.IsValid() {
+		journalField(buf, "TRACE_ID", traceID.String())
+	}
+	if spanID := record.SpanID(); spanID.IsValid() {
+		journalField(buf, "SPAN_ID", spanID.String())
+	}
+
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if name := journalFieldName(kv.Key); name != "" {
+			journalField(buf, name, journalValue(kv.Value))
+		}
+		return true
+	})
+}
+
+// journalField writes a single field. Values containing newlines use the
+// binary-safe form: NAME\n<64-bit little-endian length><value>\n.
+func journalField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	if strings.ContainsRune(value, '\n') {
+		buf.WriteByte('\n')
+		_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	} else {
+		buf.WriteByte('=')
+	}
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journalFieldName converts an attribute key into a valid journal field name:
+// uppercase letters, digits, and underscores, not starting with an underscore
+// or digit (leading underscores are reserved for trusted fields), at most 64 characters.
+// Returns "" if nothing usable remains.
+func journalFieldName(key string) string {
+	name := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			name = append(name, c-'a'+'A')
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+			name = append(name, c)
+		default:
+			name = append(name, '_')
+		}
+	}
+
+	trimmed := strings.TrimLeft(string(name), "_0123456789")
+	if len(trimmed) > 64 {
+		trimmed = trimmed[:64]
+	}
+	return trimmed
+}
+
+// journalValue formats a log value as a journal field value. Slices and maps
+// are encoded as JSON.
+func journalValue(v otellog.Value) string {
+	switch v.Kind() {
+	case otellog.KindString:
+		return v.AsString()
+	case otellog.KindBytes:
+		return string(v.AsBytes())
+	case otellog.KindSlice, otellog.KindMap:
+		data, err := json.Marshal(valueToAny(v))
+		if err != nil {
+			return v.String()
+		}
+		return string(data)
+	case otellog.KindEmpty:
+		return ""
+	default:
+		return v.String()
+	}
+}
+
+// journalPriority maps an OTel severity to a syslog priority.
+func journalPriority(severity otellog.Severity) int {
+	switch {
+	case severity >= otellog.SeverityFatal:
+		return 2 // crit
+	case severity >= otellog.SeverityError:
+		return 3 // err
+	case severity >= otellog.SeverityWarn:
+		return 4 // warning
+	case severity >= otellog.SeverityInfo, severity == otellog.SeverityUndefined:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
@@ -0,0 +1,116 @@
+package journald
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestJournalFieldName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "user_id", want: "USER_ID"},
+		{key: "http.method", want: "HTTP_METHOD"},
+		{key: "_private", want: "PRIVATE"},
+		{key: "1st", want: "ST"},
+		{key: "...", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := journalFieldName(tt.key); got != tt.want {
+				t.Errorf("journalFieldName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournalPriority(t *testing.T) {
+	tests := []struct {
+		severity otellog.Severity
+		want     int
+	}{
+		{severity: otellog.SeverityTrace, want: 7},
+		{severity: otellog.SeverityDebug, want: 7},
+		{severity: otellog.SeverityInfo, want: 6},
+		{severity: otellog.SeverityUndefined, want: 6},
+		{severity: otellog.SeverityWarn, want: 4},
+		{severity: otellog.SeverityError, want: 3},
+		{severity: otellog.SeverityFatal, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity.String(), func(t *testing.T) {
+			if got := journalPriority(tt.severity); got != tt.want {
+				t.Errorf("journalPriority(%v) = %d, want %d", tt.severity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJournalField_Multiline(t *testing.T) {
+	var buf bytes.Buffer
+	journalField(&buf, "MESSAGE", "line1\nline2")
+
+	var want bytes.Buffer
+	want.WriteString("MESSAGE\n")
+	_ = binary.Write(&want, binary.LittleEndian, uint64(11))
+	want.WriteString("line1\nline2\n")
+
+	if !bytes.Equal(buf.Bytes(), want.Bytes()) {
+		t.Errorf("journalField() = %q, want %q", buf.Bytes(), want.Bytes())
+	}
+}
+
+func TestJournaldExporter_Export(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets not supported: %v", err)
+	}
+	defer listener.Close()
+
+	exporter := New(WithSocket(socket), WithIdentifier("test-service"))
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+	record.SetSeverity(otellog.SeverityWarn)
+	record.SetSeverityText("WARN")
+	record.AddAttributes(otellog.Int("retry.count", 3))
+
+	ctx := context.Background()
+	if err := exporter.Export(ctx, []sdklog.Record{record}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	defer exporter.Shutdown(ctx)
+
+	_ = listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	data := make([]byte, 4096)
+	n, err := listener.Read(data)
+	if err != nil {
+		t.Fatalf("failed to read journal entry: %v", err)
+	}
+
+	want := "MESSAGE=hello\nPRIORITY=4\nSYSLOG_IDENTIFIER=test-service\nSEVERITY=WARN\nRETRY_COUNT=3\n"
+	if got := string(data[:n]); got != want {
+		t.Errorf("journal entry = %q, want %q", got, want)
+	}
+}
+
+func TestNew_SocketFromEnv(t *testing.T) {
+	t.Setenv("JOURNALD_SOCKET", "/tmp/journal.sock")
+
+	exporter := New(WithSocket("/run/other.sock"))
+	if exporter.socket != "/tmp/journal.sock" {
+		t.Errorf("socket = %q, want %q", exporter.socket, "/tmp/journal.sock")
+	}
+}
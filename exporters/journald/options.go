@@ -0,0 +1,36 @@
+package journald
+
+// Option configures the exporter created by New.
+type Option func(*config)
+
+// config holds the settings applied by Options.
+type config struct {
+	socket     string
+	identifier string
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) config {
+	c := config{socket: DefaultSocket}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithSocket sets the journal socket (default: DefaultSocket).
+func WithSocket(socket string) Option {
+	return func(c *config) {
+		if socket != "" {
+			c.socket = socket
+		}
+	}
+}
+
+// WithIdentifier tags records with the identifier as SYSLOG_IDENTIFIER,
+// usually the service name.
+func WithIdentifier(identifier string) Option {
+	return func(c *config) {
+		c.identifier = identifier
+	}
+}
@@ -0,0 +1,37 @@
+package journald
+
+import "go.opentelemetry.io/otel/log"
+
+// valueToAny converts an OTel log value into a plain Go value
+// (string, int64, float64, bool, []byte, []any, map[string]any, or nil)
+// so slices and maps can be encoded as JSON.
+func valueToAny(v log.Value) any {
+	switch v.Kind() {
+	case log.KindString:
+		return v.AsString()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindFloat64:
+		return v.AsFloat64()
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindBytes:
+		return v.AsBytes()
+	case log.KindSlice:
+		items := v.AsSlice()
+		values := make([]any, 0, len(items))
+		for _, item := range items {
+			values = append(values, valueToAny(item))
+		}
+		return values
+	case log.KindMap:
+		kvs := v.AsMap()
+		values := make(map[string]any, len(kvs))
+		for _, kv := range kvs {
+			values[kv.Key] = valueToAny(kv.Value)
+		}
+		return values
+	default:
+		return nil
+	}
+}
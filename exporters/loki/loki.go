@@ -0,0 +1,324 @@
+// Package loki provides an OpenTelemetry log exporter that pushes records to
+// the Loki push API, for setups that run Grafana and Loki without an OTel collector.
+//
+// Use it as the custom log exporter of go-telemetry:
+//
+//	exporter, err := loki.New(loki.WithURL("http://loki:3100"))
+//	if err != nil {
+//		return err
+//	}
+//	tel, err := telemetry.New(ctx, &telemetry.Options{
+//		ServiceName:       "my-service",
+//		CustomLogExporter: exporter,
+//	})
+//
+// or with any sdklog processor.
+package loki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+const (
+	// DefaultURL is the default Loki base URL.
+	DefaultURL = "http://localhost:3100"
+	// pushPath is the Loki push API path.
+	pushPath = "/loki/api/v1/push"
+	// lokiTimeout bounds each push when the context has no deadline.
+	lokiTimeout = 10 * time.Second
+)
+
+// streamLabels maps resource attributes to the Loki stream labels derived from
+// them. Labels are kept to low-cardinality service attributes; everything else
+// goes into the log line.
+var streamLabels = map[string]string{
+	"service.name":           "service_name",
+	"service.namespace":      "service_namespace",
+	"service.version":        "service_version",
+	"deployment.environment": "deployment_environment",
+}
+
+// Exporter is a log exporter that pushes records to the Loki push API.
+// Records are grouped into streams by service labels and level; attributes
+// and trace correlation fields are written into the line as logfmt or JSON.
+type Exporter struct {
+	url      string
+	tenantID string
+	format   string
+	client   *http.Client
+}
+
+// stream is a Loki stream in the push API JSON format.
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// New creates a Loki exporter. The LOKI_URL, LOKI_TENANT_ID, and LOKI_FORMAT
+// environment variables override the options. It fails if the format is not
+// "logfmt" or "json".
+func New(opts ...Option) (*Exporter, error) {
+	c := newConfig(opts)
+	if v := os.Getenv("LOKI_URL"); v != "" {
+		c.url = v
+	}
+	if v := os.Getenv("LOKI_TENANT_ID"); v != "" {
+		c.tenantID = v
+	}
+	if v := os.Getenv("LOKI_FORMAT"); v != "" {
+		c.format = v
+	}
+
+	switch c.format {
+	case "logfmt", "json":
+	default:
+		return nil, fmt.Errorf("unsupported Loki format: %s (supported: logfmt, json)", c.format)
+	}
+
+	return &Exporter{
+		url:      strings.TrimSuffix(c.url, "/") + pushPath,
+		tenantID: c.tenantID,
+		format:   c.format,
+		client:   &http.Client{Timeout: lokiTimeout},
+	}, nil
+}
+
+// Export pushes the records to Loki in a single request.
+func (e *Exporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(map[string][]stream{"streams": e.streams(records)})
+	if err != nil {
+		return fmt.Errorf("failed to encode Loki push request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create Loki push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", e.tenantID)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push to Loki %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("failed to push to Loki %s: %s: %s", e.url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// Shutdown closes idle connections to Loki.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// ForceFlush is a no-op; records are pushed synchronously by Export.
+func (e *Exporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// streams groups the records into Loki streams by label set, preserving
+// record order within each stream.
+func (e *Exporter) streams(records []sdklog.Record) []stream {
+	var streams []stream
+	index := map[string]int{}
+
+	for i := range records {
+		record := &records[i]
+		labels := recordLabels(record)
+		key := labelsKey(labels)
+
+		n, ok := index[key]
+		if !ok {
+			n = len(streams)
+			index[key] = n
+			streams = append(streams, stream{Stream: labels})
+		}
+
+		timestamp := record.Timestamp()
+		if timestamp.IsZero() {
+			timestamp = record.ObservedTimestamp()
+		}
+
+		streams[n].Values = append(streams[n].Values, [2]string{
+			strconv.FormatInt(timestamp.UnixNano(), 10),
+			e.line(record),
+		})
+	}
+
+	return streams
+}
+
+// recordLabels returns the stream labels for a record: the service
+// resource attributes and the level.
+func recordLabels(record *sdklog.Record) map[string]string {
+	labels := map[string]string{"level": levelName(record)}
+	if res := record.Resource(); res != nil {
+		for _, attr := range res.Attributes() {
+			if label, ok := streamLabels[string(attr.Key)]; ok {
+				labels[label] = attr.Value.Emit()
+			}
+		}
+	}
+	return labels
+}
+
+// labelsKey returns a canonical key for a label set.
+func labelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[key]))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// levelName returns the lowercase level name of a record (trace, debug, info,
+// warn, error, or fatal).
+func levelName(record *sdklog.Record) string {
+	switch severity := record.Severity(); {
+	case severity == otellog.SeverityUndefined:
+		if text := record.SeverityText(); text != "" {
+			return strings.ToLower(text)
+		}
+		return "unknown"
+	case severity < otellog.SeverityDebug:
+		return "trace"
+	case severity < otellog.SeverityInfo:
+		return "debug"
+	case severity < otellog.SeverityWarn:
+		return "info"
+	case severity < otellog.SeverityError:
+		return "warn"
+	case severity < otellog.SeverityFatal:
+		return "error"
+	default:
+		return "fatal"
+	}
+}
+
+// lineFields returns the message, attributes, and trace correlation
+// fields of a record, which make up the log line.
+func lineFields(record *sdklog.Record) map[string]any {
+	fields := make(map[string]any, record.AttributesLen()+3)
+
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		fields[kv.Key] = valueToAny(kv.Value)
+		return true
+	})
+
+	fields["msg"] = valueToAny(record.Body())
+	if traceID := record.TraceID(); traceID.IsValid() {
+		fields["trace_id"] = traceID.String()
+	}
+	if spanID := record.SpanID(); spanID.IsValid() {
+		fields["span_id"] = spanID.String()
+	}
+
+	return fields
+}
+
+// line formats a record as a log line in the configured format.
+func (e *Exporter) line(record *sdklog.Record) string {
+	fields := lineFields(record)
+
+	if e.format == "json" {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Sprintf("%v", fields["msg"])
+		}
+		return string(data)
+	}
+
+	return logfmt(fields)
+}
+
+// logfmt formats fields as logfmt with msg first and the remaining keys sorted.
+func logfmt(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if key != "msg" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := fields["msg"]; ok {
+		keys = append([]string{"msg"}, keys...)
+	}
+
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(logfmtKey(key))
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(fields[key]))
+	}
+	return b.String()
+}
+
+// logfmtKey replaces characters that aren't valid in a logfmt key.
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtValue formats a value, quoting it if needed. Slices and maps are
+// written as JSON.
+func logfmtValue(v any) string {
+	var s string
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		s = val
+	case []any, map[string]any, []byte:
+		data, _ := json.Marshal(val)
+		s = string(data)
+	default:
+		s = fmt.Sprintf("%v", val)
+	}
+
+	if s == "" || strings.ContainsAny(s, " =\"\t\n\r") {
+		return strconv.Quote(s)
+	}
+	return s
+}
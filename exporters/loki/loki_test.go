@@ -0,0 +1,163 @@
+package loki
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestLogfmt(t *testing.T) {
+	fields := map[string]any{
+		"msg":   "user logged in",
+		"user":  "alice",
+		"count": int64(3),
+		"empty": "",
+		"tags":  []any{"a", "b"},
+	}
+
+	want := `msg="user logged in" count=3 empty="" tags="[\"a\",\"b\"]" user=alice`
+	if got := logfmt(fields); got != want {
+		t.Errorf("logfmt() = %s, want %s", got, want)
+	}
+}
+
+func TestLokiExporter_Export(t *testing.T) {
+	var push struct {
+		Streams []stream `json:"streams"`
+	}
+	var tenant string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != pushPath {
+			t.Errorf("request path = %s, want %s", r.URL.Path, pushPath)
+		}
+		tenant = r.Header.Get("X-Scope-OrgID")
+		if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+			t.Errorf("failed to decode push request: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	exporter, err := New(WithURL(server.URL), WithTenantID("tenant-a"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	newRecord := func(severity otellog.Severity, msg string) sdklog.Record {
+		record := newSDKRecord(t)
+		record.SetTimestamp(time.Unix(1700000000, 0))
+		record.SetBody(otellog.StringValue(msg))
+		record.SetSeverity(severity)
+		record.AddAttributes(otellog.String("user", "alice"))
+		return record
+	}
+
+	records := []sdklog.Record{
+		newRecord(otellog.SeverityInfo, "first"),
+		newRecord(otellog.SeverityError, "failed"),
+		newRecord(otellog.SeverityInfo, "second"),
+	}
+
+	if err := exporter.Export(context.Background(), records); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if tenant != "tenant-a" {
+		t.Errorf("X-Scope-OrgID = %q, want %q", tenant, "tenant-a")
+	}
+	if len(push.Streams) != 2 {
+		t.Fatalf("pushed %d streams, want 2 (info and error)", len(push.Streams))
+	}
+
+	info := push.Streams[0]
+	if info.Stream["level"] != "info" {
+		t.Errorf("first stream level = %q, want %q", info.Stream["level"], "info")
+	}
+	if len(info.Values) != 2 {
+		t.Fatalf("info stream has %d values, want 2", len(info.Values))
+	}
+	if info.Values[0][0] != "1700000000000000000" {
+		t.Errorf("timestamp = %s, want 1700000000000000000", info.Values[0][0])
+	}
+	if info.Values[0][1] != "msg=first user=alice" {
+		t.Errorf("line = %q, want %q", info.Values[0][1], "msg=first user=alice")
+	}
+}
+
+func TestLokiExporter_ExportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "entry too far behind", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	exporter, err := New(WithURL(server.URL), WithFormat("json"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	if err := exporter.Export(context.Background(), []sdklog.Record{record}); err == nil {
+		t.Error("Export() error = nil, want error for 400 response")
+	}
+}
+
+func TestNew_UnsupportedFormat(t *testing.T) {
+	if _, err := New(WithFormat("xml")); err == nil {
+		t.Error("New() error = nil, want error for unsupported format")
+	}
+}
+
+func TestNew_Env(t *testing.T) {
+	t.Setenv("LOKI_URL", "http://loki:3100/")
+	t.Setenv("LOKI_FORMAT", "json")
+
+	exporter, err := New(WithURL("http://other:3100"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if exporter.url != "http://loki:3100"+pushPath {
+		t.Errorf("url = %q, want %q", exporter.url, "http://loki:3100"+pushPath)
+	}
+	if exporter.format != "json" {
+		t.Errorf("format = %q, want %q", exporter.format, "json")
+	}
+}
+
+// recordCapture is a log processor that keeps a copy of each emitted record.
+type recordCapture struct {
+	records []sdklog.Record
+}
+
+func (p *recordCapture) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.records = append(p.records, record.Clone())
+	return nil
+}
+
+func (p *recordCapture) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (p *recordCapture) Shutdown(context.Context) error                         { return nil }
+func (p *recordCapture) ForceFlush(context.Context) error                       { return nil }
+
+// newSDKRecord returns an empty record emitted through a logger provider, so
+// unlike a zero sdklog.Record it keeps attribute values untruncated.
+func newSDKRecord(t testing.TB) sdklog.Record {
+	t.Helper()
+
+	capture := &recordCapture{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(capture))
+	defer lp.Shutdown(context.Background())
+
+	lp.Logger("test").Emit(context.Background(), otellog.Record{})
+	if len(capture.records) != 1 {
+		t.Fatalf("captured %d records, want 1", len(capture.records))
+	}
+	return capture.records[0]
+}
@@ -0,0 +1,46 @@
+package loki
+
+// Option configures the exporter created by New.
+type Option func(*config)
+
+// config holds the settings applied by Options.
+type config struct {
+	url      string
+	tenantID string
+	format   string
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) config {
+	c := config{url: DefaultURL, format: "logfmt"}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithURL sets the base URL of the Loki push API (default: DefaultURL).
+func WithURL(url string) Option {
+	return func(c *config) {
+		if url != "" {
+			c.url = url
+		}
+	}
+}
+
+// WithTenantID sends the tenant ID as the X-Scope-OrgID header for multi-tenant Loki.
+func WithTenantID(tenantID string) Option {
+	return func(c *config) {
+		c.tenantID = tenantID
+	}
+}
+
+// WithFormat sets the format of the log line: "logfmt" (default) or "json".
+// Stream labels are derived from the service resource attributes and the level.
+func WithFormat(format string) Option {
+	return func(c *config) {
+		if format != "" {
+			c.format = format
+		}
+	}
+}
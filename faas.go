@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Export intervals used on serverless platforms, which throttle or freeze the
+// CPU once a request completes, so the SDK defaults (5s for batches, 60s for
+// metrics) would leave telemetry unexported for long stretches.
+const (
+	serverlessBatchInterval   = time.Second
+	serverlessMetricsInterval = 10 * time.Second
+)
+
+// faasAttributes returns the cloud.* and faas.* resource attributes of the
+// serverless platform the process runs on, read from the environment the
+// platform sets, or nil elsewhere.
+func faasAttributes() []attribute.KeyValue {
+	if name := os.Getenv("AWS_LAMBDA_FUNCTION_NAME"); name != "" {
+		return lambdaAttributes(name)
+	}
+	if name := os.Getenv("K_SERVICE"); name != "" {
+		return cloudRunAttributes(name)
+	}
+	return nil
+}
+
+// onServerless reports whether the process runs on AWS Lambda, Cloud Run, or
+// Cloud Functions.
+func onServerless() bool {
+	return os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" || os.Getenv("K_SERVICE") != ""
+}
+
+// batchSpanOptions returns the batch span processor options: a short export
+// interval on serverless platforms unless OTEL_BSP_SCHEDULE_DELAY is set.
+func batchSpanOptions() []sdktrace.BatchSpanProcessorOption {
+	if !onServerless() || os.Getenv("OTEL_BSP_SCHEDULE_DELAY") != "" {
+		return nil
+	}
+	return []sdktrace.BatchSpanProcessorOption{sdktrace.WithBatchTimeout(serverlessBatchInterval)}
+}
+
+// batchLogOptions returns the batch log processor options: a short export
+// interval on serverless platforms unless OTEL_BLRP_SCHEDULE_DELAY is set.
+func batchLogOptions() []sdklog.BatchProcessorOption {
+	if !onServerless() || os.Getenv("OTEL_BLRP_SCHEDULE_DELAY") != "" {
+		return nil
+	}
+	return []sdklog.BatchProcessorOption{sdklog.WithExportInterval(serverlessBatchInterval)}
+}
+
+// periodicReaderOptions returns the periodic metric reader options: a short
+// export interval on serverless platforms unless OTEL_METRIC_EXPORT_INTERVAL
+// is set.
+func periodicReaderOptions() []sdkmetric.PeriodicReaderOption {
+	if !onServerless() || os.Getenv("OTEL_METRIC_EXPORT_INTERVAL") != "" {
+		return nil
+	}
+	return []sdkmetric.PeriodicReaderOption{sdkmetric.WithInterval(serverlessMetricsInterval)}
+}
+
+// lambdaAttributes returns the resource attributes of the AWS Lambda function
+// name.
+func lambdaAttributes(name string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+		semconv.FaaSName(name),
+	}
+	if v := os.Getenv("AWS_REGION"); v != "" {
+		attrs = append(attrs, semconv.CloudRegion(v))
+	}
+	if v := os.Getenv("AWS_LAMBDA_FUNCTION_VERSION"); v != "" {
+		attrs = append(attrs, semconv.FaaSVersion(v))
+	}
+	if v := os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME"); v != "" {
+		attrs = append(attrs, semconv.FaaSInstance(v))
+	}
+	if mb, err := strconv.Atoi(os.Getenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE")); err == nil {
+		attrs = append(attrs, semconv.FaaSMaxMemory(mb*1024*1024))
+	}
+	return attrs
+}
+
+// cloudRunAttributes returns the resource attributes of the Cloud Run service,
+// or the Cloud Functions function when FUNCTION_TARGET is set, name.
+func cloudRunAttributes(name string) []attribute.KeyValue {
+	platform := semconv.CloudPlatformGCPCloudRun
+	if os.Getenv("FUNCTION_TARGET") != "" {
+		platform = semconv.CloudPlatformGCPCloudFunctions
+	}
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderGCP,
+		platform,
+		semconv.FaaSName(name),
+	}
+	if v := os.Getenv("K_REVISION"); v != "" {
+		attrs = append(attrs, semconv.FaaSVersion(v))
+	}
+	return attrs
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestFaaSAttributes_Lambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "orders")
+	t.Setenv("AWS_LAMBDA_FUNCTION_VERSION", "$LATEST")
+	t.Setenv("AWS_LAMBDA_FUNCTION_MEMORY_SIZE", "128")
+	t.Setenv("AWS_LAMBDA_LOG_STREAM_NAME", "2026/10/16/[$LATEST]abc")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	set := attribute.NewSet(faasAttributes()...)
+
+	want := map[attribute.Key]attribute.Value{
+		"cloud.provider":  attribute.StringValue("aws"),
+		"cloud.platform":  attribute.StringValue("aws_lambda"),
+		"cloud.region":    attribute.StringValue("us-east-1"),
+		"faas.name":       attribute.StringValue("orders"),
+		"faas.version":    attribute.StringValue("$LATEST"),
+		"faas.instance":   attribute.StringValue("2026/10/16/[$LATEST]abc"),
+		"faas.max_memory": attribute.Int64Value(128 * 1024 * 1024),
+	}
+	for key, value := range want {
+		if got, ok := set.Value(key); !ok || got != value {
+			t.Errorf("%s = %v (present %v), want %v", key, got.Emit(), ok, value.Emit())
+		}
+	}
+}
+
+func TestFaaSAttributes_CloudRun(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	t.Setenv("K_SERVICE", "checkout")
+	t.Setenv("K_REVISION", "checkout-00042-abc")
+
+	tests := []struct {
+		name           string
+		functionTarget string
+		want           string
+	}{
+		{"cloud run", "", "gcp_cloud_run"},
+		{"cloud functions", "HandleCheckout", "gcp_cloud_functions"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FUNCTION_TARGET", tt.functionTarget)
+
+			set := attribute.NewSet(faasAttributes()...)
+			if got, _ := set.Value("cloud.platform"); got.AsString() != tt.want {
+				t.Errorf("cloud.platform = %q, want %q", got.AsString(), tt.want)
+			}
+			if got, _ := set.Value("faas.name"); got.AsString() != "checkout" {
+				t.Errorf("faas.name = %q, want checkout", got.AsString())
+			}
+			if got, _ := set.Value("faas.version"); got.AsString() != "checkout-00042-abc" {
+				t.Errorf("faas.version = %q, want checkout-00042-abc", got.AsString())
+			}
+		})
+	}
+}
+
+func TestPeriodicReaderOptions(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	t.Setenv("K_SERVICE", "")
+	t.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "")
+	if got := periodicReaderOptions(); got != nil {
+		t.Errorf("periodicReaderOptions() off serverless = %v, want nil", got)
+	}
+
+	t.Setenv("K_SERVICE", "checkout")
+	if got := periodicReaderOptions(); len(got) != 1 {
+		t.Errorf("periodicReaderOptions() on Cloud Run = %v, want the short interval", got)
+	}
+
+	t.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "30000")
+	if got := periodicReaderOptions(); got != nil {
+		t.Errorf("periodicReaderOptions() with OTEL_METRIC_EXPORT_INTERVAL = %v, want nil", got)
+	}
+}
+
+func TestFaaSAttributes_None(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "")
+	t.Setenv("K_SERVICE", "")
+
+	if attrs := faasAttributes(); attrs != nil {
+		t.Errorf("faasAttributes() = %v, want nil", attrs)
+	}
+}
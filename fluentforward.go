@@ -0,0 +1,506 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+const (
+	// defaultFluentForwardAddress is the default Fluentd/Fluent Bit forward input address.
+	defaultFluentForwardAddress = "localhost:24224"
+	// fluentForwardTimeout bounds dialing, writing and waiting for an ack when the context has no deadline.
+	fluentForwardTimeout = 5 * time.Second
+	// fluentForwardAttempts is how many times an export is sent before giving up.
+	fluentForwardAttempts = 3
+	// fluentForwardMinBackoff and fluentForwardMaxBackoff bound the wait between attempts.
+	fluentForwardMinBackoff = 100 * time.Millisecond
+	fluentForwardMaxBackoff = 2 * time.Second
+)
+
+// fluentForwardExporter is a log exporter that ships records to Fluentd or
+// Fluent Bit using the Forward protocol (msgpack over TCP or a unix socket).
+// Each export is sent as a single Forward mode message: [tag, [[time, record], ...]].
+// With requireAck, the message carries a chunk option and the export only
+// succeeds once the forward input acknowledges that chunk. A failed send drops
+// the connection and is retried on a new one with exponential backoff.
+type fluentForwardExporter struct {
+	network    string
+	address    string
+	tag        string
+	requireAck bool
+
+	mu      sync.Mutex
+	conn    net.Conn
+	stopped bool
+}
+
+// newFluentForwardExporter creates a Forward protocol exporter.
+// The address is "host:port" for TCP or "unix:///path/to/socket" for a unix socket.
+func newFluentForwardExporter(address, tag string, requireAck bool) *fluentForwardExporter {
+	if address == "" {
+		address = defaultFluentForwardAddress
+	}
+
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		network = "unix"
+		address = path
+	}
+
+	return &fluentForwardExporter{
+		network:    network,
+		address:    address,
+		tag:        tag,
+		requireAck: requireAck,
+	}
+}
+
+// Export sends the records to the forward input, reconnecting and retrying
+// with backoff if the connection fails or the chunk is not acknowledged.
+func (e *fluentForwardExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var chunk string
+	if e.requireAck {
+		var err error
+		if chunk, err = newFluentChunkID(); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	e.encode(&buf, records, chunk)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	backoff := fluentForwardMinBackoff
+	for attempt := 1; ; attempt++ {
+		if e.stopped {
+			return nil
+		}
+
+		err := e.send(ctx, buf.Bytes(), chunk)
+		if err == nil || attempt == fluentForwardAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, fluentForwardMaxBackoff)
+	}
+}
+
+// send writes one message, connecting first if needed, and waits for the ack
+// when chunk is set. Any failure drops the connection so the next attempt reconnects.
+func (e *fluentForwardExporter) send(ctx context.Context, message []byte, chunk string) error {
+	if e.conn == nil {
+		dialer := net.Dialer{Timeout: fluentForwardTimeout}
+		conn, err := dialer.DialContext(ctx, e.network, e.address)
+		if err != nil {
+			return fmt.Errorf("failed to connect to fluent forward endpoint %s: %w", e.address, err)
+		}
+		e.conn = conn
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(fluentForwardTimeout)
+	}
+	_ = e.conn.SetDeadline(deadline)
+
+	if _, err := e.conn.Write(message); err != nil {
+		e.closeConn()
+		return fmt.Errorf("failed to write to fluent forward endpoint %s: %w", e.address, err)
+	}
+
+	if chunk == "" {
+		return nil
+	}
+
+	ack, err := readFluentAck(e.conn)
+	if err != nil {
+		e.closeConn()
+		return fmt.Errorf("failed to read ack from fluent forward endpoint %s: %w", e.address, err)
+	}
+	if ack != chunk {
+		e.closeConn()
+		return fmt.Errorf("fluent forward endpoint %s acknowledged chunk %q, want %q", e.address, ack, chunk)
+	}
+
+	return nil
+}
+
+// closeConn closes and forgets the current connection.
+func (e *fluentForwardExporter) closeConn() {
+	_ = e.conn.Close()
+	e.conn = nil
+}
+
+// Shutdown closes the connection to the forward input.
+func (e *fluentForwardExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stopped = true
+	if e.conn == nil {
+		return nil
+	}
+
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+// ForceFlush is a no-op; records are written synchronously by Export.
+func (e *fluentForwardExporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// encode writes the records as a Forward mode message, adding the chunk
+// option when chunk is set.
+func (e *fluentForwardExporter) encode(buf *bytes.Buffer, records []sdklog.Record, chunk string) {
+	if chunk != "" {
+		msgpackArrayHeader(buf, 3)
+	} else {
+		msgpackArrayHeader(buf, 2)
+	}
+	msgpackEncode(buf, e.tag)
+
+	msgpackArrayHeader(buf, len(records))
+	for i := range records {
+		record := &records[i]
+
+		timestamp := record.Timestamp()
+		if timestamp.IsZero() {
+			timestamp = record.ObservedTimestamp()
+		}
+
+		msgpackArrayHeader(buf, 2)
+		msgpackEventTime(buf, timestamp)
+		msgpackEncode(buf, fluentRecord(record))
+	}
+
+	if chunk != "" {
+		msgpackMapHeader(buf, 1)
+		msgpackString(buf, "chunk")
+		msgpackString(buf, chunk)
+	}
+}
+
+// newFluentChunkID returns a random base64-encoded 128-bit chunk ID.
+func newFluentChunkID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", fmt.Errorf("failed to generate fluent forward chunk id: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(id[:]), nil
+}
+
+// readFluentAck reads a Forward protocol ack response ({"ack": chunk}) and
+// returns the acknowledged chunk ID.
+func readFluentAck(r io.Reader) (string, error) {
+	n, err := msgpackReadMapHeader(r)
+	if err != nil {
+		return "", err
+	}
+
+	var ack string
+	for range n {
+		key, err := msgpackReadString(r)
+		if err != nil {
+			return "", err
+		}
+		value, err := msgpackReadString(r)
+		if err != nil {
+			return "", err
+		}
+		if key == "ack" {
+			ack = value
+		}
+	}
+	if ack == "" {
+		return "", errors.New("ack response has no ack field")
+	}
+
+	return ack, nil
+}
+
+// fluentRecord flattens an OTel log record into the map sent to Fluentd.
+// Resource and log attributes are added as top-level keys, followed by the
+// message, level, and trace correlation fields.
+func fluentRecord(record *sdklog.Record) map[string]any {
+	fields := make(map[string]any, record.AttributesLen()+6)
+
+	if res := record.Resource(); res != nil {
+		for _, attr := range res.Attributes() {
+			fields[string(attr.Key)] = attr.Value.AsInterface()
+		}
+	}
+
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		fields[kv.Key] = logValueToAny(kv.Value)
+		return true
+	})
+
+	fields["message"] = logValueToAny(record.Body())
+	fields["severity_number"] = int64(record.Severity())
+	if text := record.SeverityText(); text != "" {
+		fields["level"] = text
+	} else {
+		fields["level"] = record.Severity().String()
+	}
+	if traceID := record.TraceID(); traceID.IsValid() {
+		fields["trace_id"] = traceID.String()
+	}
+	if spanID := record.SpanID(); spanID.IsValid() {
+		fields["span_id"] = spanID.String()
+	}
+
+	return fields
+}
+
+// msgpackEncode writes v in msgpack format. It supports the value types
+// produced by logValueToAny.
+func msgpackEncode(buf *bytes.Buffer, v any) {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if val {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case int:
+		msgpackInt(buf, int64(val))
+	case int64:
+		msgpackInt(buf, val)
+	case float64:
+		buf.WriteByte(0xcb)
+		_ = binary.Write(buf, binary.BigEndian, math.Float64bits(val))
+	case string:
+		msgpackString(buf, val)
+	case []byte:
+		msgpackBinary(buf, val)
+	case []any:
+		msgpackArrayHeader(buf, len(val))
+		for _, item := range val {
+			msgpackEncode(buf, item)
+		}
+	case map[string]any:
+		msgpackMapHeader(buf, len(val))
+		// Sort keys so the encoding is deterministic
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			msgpackString(buf, key)
+			msgpackEncode(buf, val[key])
+		}
+	case []string:
+		msgpackArrayHeader(buf, len(val))
+		for _, item := range val {
+			msgpackString(buf, item)
+		}
+	case []int64:
+		msgpackArrayHeader(buf, len(val))
+		for _, item := range val {
+			msgpackInt(buf, item)
+		}
+	case []float64:
+		msgpackArrayHeader(buf, len(val))
+		for _, item := range val {
+			msgpackEncode(buf, item)
+		}
+	case []bool:
+		msgpackArrayHeader(buf, len(val))
+		for _, item := range val {
+			msgpackEncode(buf, item)
+		}
+	default:
+		msgpackString(buf, fmt.Sprintf("%v", val))
+	}
+}
+
+// msgpackInt writes a signed integer using the smallest msgpack int format.
+func msgpackInt(buf *bytes.Buffer, v int64) {
+	switch {
+	case v >= 0 && v <= 0x7f:
+		buf.WriteByte(byte(v))
+	case v < 0 && v >= -32:
+		buf.WriteByte(byte(v))
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(v)))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		buf.WriteByte(0xd1)
+		_ = binary.Write(buf, binary.BigEndian, int16(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		buf.WriteByte(0xd2)
+		_ = binary.Write(buf, binary.BigEndian, int32(v))
+	default:
+		buf.WriteByte(0xd3)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+// msgpackString writes a UTF-8 string.
+func msgpackString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n <= 31:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+// msgpackBinary writes a byte slice using the bin format family.
+func msgpackBinary(buf *bytes.Buffer, b []byte) {
+	n := len(b)
+	switch {
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xc4)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xc5)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xc6)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.Write(b)
+}
+
+// msgpackArrayHeader writes the header for an array of n elements.
+func msgpackArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n <= 15:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xdc)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// msgpackMapHeader writes the header for a map of n entries.
+func msgpackMapHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n <= 15:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xde)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// msgpackReadMapHeader reads a map header and returns the number of entries.
+func msgpackReadMapHeader(r io.Reader) (int, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case b[0]&0xf0 == 0x80:
+		return int(b[0] & 0x0f), nil
+	case b[0] == 0xde:
+		var n uint16
+		err := binary.Read(r, binary.BigEndian, &n)
+		return int(n), err
+	case b[0] == 0xdf:
+		var n uint32
+		err := binary.Read(r, binary.BigEndian, &n)
+		return int(n), err
+	default:
+		return 0, fmt.Errorf("unexpected msgpack type 0x%02x, want map", b[0])
+	}
+}
+
+// msgpackReadString reads a str or bin value as a string.
+func msgpackReadString(r io.Reader) (string, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return "", err
+	}
+
+	var n int
+	switch {
+	case b[0]&0xe0 == 0xa0:
+		n = int(b[0] & 0x1f)
+	case b[0] == 0xd9 || b[0] == 0xc4:
+		var l uint8
+		if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+			return "", err
+		}
+		n = int(l)
+	case b[0] == 0xda || b[0] == 0xc5:
+		var l uint16
+		if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+			return "", err
+		}
+		n = int(l)
+	case b[0] == 0xdb || b[0] == 0xc6:
+		var l uint32
+		if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+			return "", err
+		}
+		n = int(l)
+	default:
+		return "", fmt.Errorf("unexpected msgpack type 0x%02x, want string", b[0])
+	}
+
+	s := make([]byte, n)
+	if _, err := io.ReadFull(r, s); err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// msgpackEventTime writes t as a Forward protocol EventTime
+// (ext type 0 with 32-bit seconds and nanoseconds).
+func msgpackEventTime(buf *bytes.Buffer, t time.Time) {
+	buf.WriteByte(0xd7) // fixext 8
+	buf.WriteByte(0x00) // EventTime
+	_ = binary.Write(buf, binary.BigEndian, uint32(t.Unix()))
+	_ = binary.Write(buf, binary.BigEndian, uint32(t.Nanosecond()))
+}
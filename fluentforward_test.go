@@ -0,0 +1,293 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestMsgpackEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []byte
+	}{
+		{name: "nil", value: nil, want: []byte{0xc0}},
+		{name: "true", value: true, want: []byte{0xc3}},
+		{name: "false", value: false, want: []byte{0xc2}},
+		{name: "positive fixint", value: int64(7), want: []byte{0x07}},
+		{name: "negative fixint", value: int64(-1), want: []byte{0xff}},
+		{name: "int16", value: int64(1000), want: []byte{0xd1, 0x03, 0xe8}},
+		{name: "fixstr", value: "hi", want: []byte{0xa2, 'h', 'i'}},
+		{name: "bin", value: []byte{0x01, 0x02}, want: []byte{0xc4, 0x02, 0x01, 0x02}},
+		{name: "array", value: []any{int64(1), "a"}, want: []byte{0x92, 0x01, 0xa1, 'a'}},
+		{name: "map sorted keys", value: map[string]any{"b": int64(2), "a": int64(1)}, want: []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			msgpackEncode(&buf, tt.value)
+
+			if !bytes.Equal(buf.Bytes(), tt.want) {
+				t.Errorf("msgpackEncode(%v) = %x, want %x", tt.value, buf.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFluentForwardExporter_Export(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+
+	exporter := newFluentForwardExporter(listener.Addr().String(), "app.logs", false)
+
+	var record sdklog.Record
+	record.SetTimestamp(time.Unix(1700000000, 0))
+	record.SetBody(otellog.StringValue("hello"))
+	record.SetSeverity(otellog.SeverityInfo)
+	record.SetSeverityText("INFO")
+	record.AddAttributes(otellog.Int64("count", 3))
+
+	ctx := context.Background()
+	if err := exporter.Export(ctx, []sdklog.Record{record}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	var data []byte
+	select {
+	case data = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for forwarded data")
+	}
+
+	// [tag, [[time, record]]]
+	var want bytes.Buffer
+	msgpackArrayHeader(&want, 2)
+	msgpackString(&want, "app.logs")
+	msgpackArrayHeader(&want, 1)
+	msgpackArrayHeader(&want, 2)
+	msgpackEventTime(&want, time.Unix(1700000000, 0))
+	msgpackEncode(&want, map[string]any{
+		"count":           int64(3),
+		"level":           "INFO",
+		"message":         "hello",
+		"severity_number": int64(otellog.SeverityInfo),
+	})
+
+	if !bytes.Equal(data, want.Bytes()) {
+		t.Errorf("forwarded data = %x, want %x", data, want.Bytes())
+	}
+}
+
+func TestFluentForwardExporter_ExportConnectionError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	exporter := newFluentForwardExporter(address, "app.logs", false)
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	if err := exporter.Export(context.Background(), []sdklog.Record{record}); err == nil {
+		t.Error("Export() error = nil, want connection error")
+	}
+}
+
+// fakeFluentServer is a forward input that reads ack-requesting messages and
+// lets the test decide, per connection, whether to acknowledge them.
+type fakeFluentServer struct {
+	listener net.Listener
+	chunks   chan string
+}
+
+// newFakeFluentServer starts a server. For the nth accepted connection
+// (starting at 0), ack(n) reports whether its messages are acknowledged;
+// otherwise the connection is closed after the message is read.
+func newFakeFluentServer(t *testing.T, ack func(conn int) bool) *fakeFluentServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	s := &fakeFluentServer{listener: listener, chunks: make(chan string, 16)}
+	go func() {
+		for n := 0; ; n++ {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn, ack(n))
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeFluentServer) serve(conn net.Conn, ack bool) {
+	defer conn.Close()
+
+	// The chunk option is the last element of the message: {"chunk": <24 byte base64 id>}
+	marker := []byte{0x81, 0xa5, 'c', 'h', 'u', 'n', 'k', 0xb8}
+	var data []byte
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+		data = append(data, buf[:n]...)
+
+		i := bytes.LastIndex(data, marker)
+		if i < 0 || len(data) < i+len(marker)+24 {
+			continue
+		}
+		chunk := string(data[i+len(marker) : i+len(marker)+24])
+		data = data[i+len(marker)+24:]
+		s.chunks <- chunk
+
+		if !ack {
+			return
+		}
+		var resp bytes.Buffer
+		msgpackMapHeader(&resp, 1)
+		msgpackString(&resp, "ack")
+		msgpackString(&resp, chunk)
+		if _, err := conn.Write(resp.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+func TestFluentForwardExporter_Ack(t *testing.T) {
+	server := newFakeFluentServer(t, func(int) bool { return true })
+	exporter := newFluentForwardExporter(server.listener.Addr().String(), "app.logs", true)
+	defer exporter.Shutdown(context.Background())
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	seen := make(map[string]bool)
+	for range 2 {
+		if err := exporter.Export(context.Background(), []sdklog.Record{record}); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		chunk := <-server.chunks
+		if seen[chunk] {
+			t.Errorf("chunk %q was reused across exports", chunk)
+		}
+		seen[chunk] = true
+	}
+}
+
+func TestFluentForwardExporter_ReconnectsUntilAcked(t *testing.T) {
+	// The first connection drops the message without an ack
+	server := newFakeFluentServer(t, func(conn int) bool { return conn > 0 })
+	exporter := newFluentForwardExporter(server.listener.Addr().String(), "app.logs", true)
+	defer exporter.Shutdown(context.Background())
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	if err := exporter.Export(context.Background(), []sdklog.Record{record}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	first, second := <-server.chunks, <-server.chunks
+	if first != second {
+		t.Errorf("resent chunk = %q, want %q", second, first)
+	}
+}
+
+func TestFluentForwardExporter_NoAck(t *testing.T) {
+	server := newFakeFluentServer(t, func(int) bool { return false })
+	exporter := newFluentForwardExporter(server.listener.Addr().String(), "app.logs", true)
+	defer exporter.Shutdown(context.Background())
+
+	var record sdklog.Record
+	record.SetBody(otellog.StringValue("hello"))
+
+	if err := exporter.Export(context.Background(), []sdklog.Record{record}); err == nil {
+		t.Fatal("Export() error = nil, want ack error")
+	}
+	if got := len(server.chunks); got != fluentForwardAttempts {
+		t.Errorf("messages sent = %d, want %d", got, fluentForwardAttempts)
+	}
+}
+
+func TestReadFluentAck(t *testing.T) {
+	var buf bytes.Buffer
+	msgpackMapHeader(&buf, 1)
+	msgpackString(&buf, "ack")
+	msgpackString(&buf, "Y2h1bmstaWQ=")
+
+	ack, err := readFluentAck(&buf)
+	if err != nil {
+		t.Fatalf("readFluentAck() error = %v", err)
+	}
+	if ack != "Y2h1bmstaWQ=" {
+		t.Errorf("readFluentAck() = %q, want %q", ack, "Y2h1bmstaWQ=")
+	}
+
+	buf.Reset()
+	msgpackMapHeader(&buf, 0)
+	if _, err := readFluentAck(&buf); err == nil {
+		t.Error("readFluentAck() error = nil for a response without ack")
+	}
+}
+
+func TestNewLogExporter(t *testing.T) {
+	ctx := context.Background()
+	opts := &Options{ServiceName: "test-service"}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "otlp", wantErr: false},
+		{name: "fluentforward", wantErr: false},
+		{name: "unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, err := newLogExporter(ctx, tt.name, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newLogExporter(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if exporter != nil {
+				_ = exporter.Shutdown(ctx)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package fx provides an fx module that constructs a Telemetry from the
+// *telemetry.Options in the graph and shuts it down when the app stops.
+package fx
+
+import (
+	"context"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// Module provides *telemetry.Telemetry and its otellog.Logger, trace.Tracer,
+// and metric.Meter, constructed from the *telemetry.Options supplied by the app.
+//
+//	fx.New(
+//		fx.Supply(&telemetry.Options{ServiceName: "my-service"}),
+//		telemetryfx.Module,
+//		fx.Invoke(func(tracer trace.Tracer) { ... }),
+//	)
+var Module = fx.Module("telemetry",
+	fx.Provide(
+		New,
+		Logger,
+		Tracer,
+		Meter,
+	),
+)
+
+// New returns a Telemetry built from opts and registers its Shutdown to run
+// when the app stops.
+func New(lc fx.Lifecycle, opts *telemetry.Options) (*telemetry.Telemetry, error) {
+	t, err := telemetry.New(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.Hook{OnStop: t.Shutdown})
+	return t, nil
+}
+
+// Logger returns t's OTel logger.
+func Logger(t *telemetry.Telemetry) otellog.Logger {
+	return t.Logger()
+}
+
+// Tracer returns t's tracer.
+func Tracer(t *telemetry.Telemetry) trace.Tracer {
+	return t.Tracer()
+}
+
+// Meter returns t's meter.
+func Meter(t *telemetry.Telemetry) metric.Meter {
+	return t.Meter()
+}
@@ -0,0 +1,47 @@
+package fx
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+func TestModule(t *testing.T) {
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	var tel *telemetry.Telemetry
+	app := fxtest.New(t,
+		fx.Supply(&telemetry.Options{
+			ServiceName:        "test-service",
+			CustomSpanExporter: spans,
+		}),
+		Module,
+		fx.Populate(&tel),
+		fx.Invoke(func(tracer trace.Tracer) {
+			_, span := tracer.Start(ctx, "invoke")
+			span.End()
+		}),
+	)
+	app.RequireStart()
+
+	if tel == nil {
+		t.Fatal("Module did not provide *telemetry.Telemetry")
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported %d spans, want 1", got)
+	}
+
+	app.RequireStop()
+
+	// Shutdown ran on stop and shut the exporter down, which clears it
+	if got := len(spans.GetSpans()); got != 0 {
+		t.Errorf("%d spans left after stop, want 0", got)
+	}
+}
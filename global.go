@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	lognoop "go.opentelemetry.io/otel/log/noop"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// defaultTelemetry holds the Telemetry set with SetDefault.
+var defaultTelemetry atomic.Pointer[Telemetry]
+
+// SetDefault sets the default Telemetry used by the package-level helpers
+// (StartSpan, Logger, Meter). Passing nil clears the default.
+// This lets libraries that can't receive a *Telemetry still participate.
+func SetDefault(t *Telemetry) {
+	defaultTelemetry.Store(t)
+}
+
+// Default returns the default Telemetry, or nil if none has been set.
+func Default() *Telemetry {
+	return defaultTelemetry.Load()
+}
+
+// StartSpan starts a new span with the given name using the default Telemetry.
+// If no default is set, the span is a noop span.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if t := Default(); t != nil {
+		return t.StartSpan(ctx, name, opts...)
+	}
+	return tracenoop.NewTracerProvider().Tracer("").Start(ctx, name, opts...)
+}
+
+// StartSpanWithAttributes starts a new span with the given name and attributes
+// using the default Telemetry. If no default is set, the span is a noop span.
+func StartSpanWithAttributes(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return StartSpan(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartSpanWithLogger starts a new span and returns a logger scoped to it using
+// the default Telemetry. If no default is set, the span and logger are noops.
+func StartSpanWithLogger(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span, otellog.Logger) {
+	if t := Default(); t != nil {
+		return t.StartSpanWithLogger(ctx, name, opts...)
+	}
+	ctx, span := StartSpan(ctx, name, opts...)
+	logger := newSpanLogger(ctx, Logger(), name)
+	return ContextWithLogger(ctx, logger), span, logger
+}
+
+// Logger returns the OTel logger of the default Telemetry.
+// If no default is set, a noop logger is returned.
+func Logger() otellog.Logger {
+	if t := Default(); t != nil {
+		return t.Logger()
+	}
+	return lognoop.NewLoggerProvider().Logger("")
+}
+
+// Meter returns a meter with the given name from the default Telemetry.
+// If no default is set or OTel metrics are disabled, a noop meter is returned.
+func Meter(name string) metric.Meter {
+	if t := Default(); t != nil {
+		if mp := t.MeterProvider(); mp != nil {
+			return mp.Meter(name)
+		}
+	}
+	return metricnoop.NewMeterProvider().Meter(name)
+}
+
+// RecordError records err on the span in ctx using the default Telemetry instance.
+// If no default is set, the error is recorded without a stack trace.
+func RecordError(ctx context.Context, err error, opts ...trace.EventOption) {
+	if t := Default(); t != nil {
+		t.RecordError(ctx, err, opts...)
+		return
+	}
+	recordError(ctx, err, false, opts...)
+}
+
+// Flush exports pending telemetry data using the default Telemetry instance.
+// It is a no-op if no default is set.
+func Flush(ctx context.Context) error {
+	if t := Default(); t != nil {
+		return t.Flush(ctx)
+	}
+	return nil
+}
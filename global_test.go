@@ -0,0 +1,61 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestGlobal_NoDefault(t *testing.T) {
+	SetDefault(nil)
+
+	ctx := context.Background()
+
+	_, span := StartSpan(ctx, "noop")
+	if span == nil {
+		t.Fatal("StartSpan() returned nil span without default, want noop span")
+	}
+	span.End()
+
+	if Logger() == nil {
+		t.Error("Logger() returned nil without default, want noop logger")
+	}
+
+	counter, err := Meter("test").Int64Counter("requests")
+	if err != nil {
+		t.Fatalf("Meter().Int64Counter() error = %v", err)
+	}
+	counter.Add(ctx, 1)
+}
+
+func TestGlobal_SetDefault(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+	defer SetDefault(nil)
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		LogsExporter:    "fluentforward",
+		MetricsExporter: "prometheus",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	SetDefault(tel)
+	if Default() != tel {
+		t.Fatal("Default() did not return the Telemetry passed to SetDefault")
+	}
+
+	if !Logger().Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityInfo}) {
+		t.Error("Logger().Enabled() = false, want the default's logger")
+	}
+
+	if _, err := Meter("test").Int64Counter("requests"); err != nil {
+		t.Errorf("Meter().Int64Counter() error = %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ExportState describes whether an exporter is delivering telemetry.
+type ExportState int
+
+const (
+	// ExportStateIdle means the exporter has not exported anything yet.
+	ExportStateIdle ExportState = iota
+	// ExportStateReady means the last export succeeded.
+	ExportStateReady
+	// ExportStateDegraded means the last export failed, e.g. because the collector
+	// is down, or was only spooled for replay.
+	ExportStateDegraded
+)
+
+func (s ExportState) String() string {
+	switch s {
+	case ExportStateReady:
+		return "ready"
+	case ExportStateDegraded:
+		return "degraded"
+	default:
+		return "idle"
+	}
+}
+
+// SignalExportState is the export state of the OTLP exporter for one signal.
+type SignalExportState struct {
+	// State is the outcome of the last export.
+	State ExportState
+	// LastError is the error of the last failed export, if any.
+	LastError error
+	// LastSuccess is the time of the last successful export.
+	LastSuccess time.Time
+}
+
+// ExporterState reports the state of the OTLP exporters.
+type ExporterState struct {
+	// State is the worst state across all signals.
+	State ExportState
+	// Signals holds the state per signal ("traces", "metrics", "logs") for
+	// every signal exported over OTLP.
+	Signals map[string]SignalExportState
+}
+
+// ExporterState reports whether the OTLP exporters are delivering telemetry.
+// The exporters connect lazily, so New succeeds while the collector is down;
+// use ExporterState to report "telemetry degraded" instead of failing at boot.
+func (t *Telemetry) ExporterState() ExporterState {
+	t.mu.RLock()
+	health := t.health
+	t.mu.RUnlock()
+
+	state := ExporterState{Signals: map[string]SignalExportState{}}
+	if health == nil {
+		return state
+	}
+
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	for signal, s := range health.signals {
+		state.Signals[signal] = *s
+		if s.State > state.State {
+			state.State = s.State
+		}
+	}
+	return state
+}
+
+// exportHealth records export outcomes per signal for ExporterState.
+type exportHealth struct {
+	mu      sync.Mutex
+	signals map[string]*SignalExportState
+
+	// pendingSpool holds the error of an export the spool reported as
+	// delivered, consumed by the record call for that export
+	pendingSpool map[string]error
+}
+
+func newExportHealth() *exportHealth {
+	return &exportHealth{
+		signals:      map[string]*SignalExportState{},
+		pendingSpool: map[string]error{},
+	}
+}
+
+// register adds a signal in the idle state. It is safe to call on a nil exportHealth.
+func (h *exportHealth) register(signal string) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.signals[signal]; !ok {
+		h.signals[signal] = &SignalExportState{}
+	}
+}
+
+// spooled records that an export of signal failed with err and was spooled
+// for replay, so the export, which reports success, leaves the signal
+// degraded. It is safe to call on a nil exportHealth.
+func (h *exportHealth) spooled(signal string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pendingSpool[signal] = fmt.Errorf("export spooled for replay: %w", err)
+}
+
+// record records the outcome of an export. It is safe to call on a nil exportHealth.
+func (h *exportHealth) record(signal string, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if spoolErr, ok := h.pendingSpool[signal]; ok {
+		delete(h.pendingSpool, signal)
+		if err == nil {
+			err = spoolErr
+		}
+	}
+
+	s, ok := h.signals[signal]
+	if !ok {
+		s = &SignalExportState{}
+		h.signals[signal] = s
+	}
+	if err != nil {
+		s.State = ExportStateDegraded
+		s.LastError = err
+		return
+	}
+	s.State = ExportStateReady
+	s.LastError = nil
+	s.LastSuccess = time.Now()
+}
+
+// healthLogExporter records the outcome of each log export for ExporterState
+// and the pipeline metrics.
+type healthLogExporter struct {
+	sdklog.Exporter
+	health *exportHealth
+}
+
+func (e *healthLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, records)
+	e.health.record("logs", err)
+	getPipelineMetrics().recordExport(ctx, "logs", len(records), start, err)
+	return err
+}
+
+// healthSpanExporter records the outcome of each span export for ExporterState
+// and the pipeline metrics.
+type healthSpanExporter struct {
+	sdktrace.SpanExporter
+	health *exportHealth
+}
+
+func (e *healthSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	start := time.Now()
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.health.record("traces", err)
+	getPipelineMetrics().recordExport(ctx, "traces", len(spans), start, err)
+	return err
+}
+
+// healthMetricExporter records the outcome of each metric export for
+// ExporterState and the pipeline metrics.
+type healthMetricExporter struct {
+	sdkmetric.Exporter
+	health *exportHealth
+}
+
+func (e *healthMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	start := time.Now()
+	err := e.Exporter.Export(ctx, rm)
+	e.health.record("metrics", err)
+	getPipelineMetrics().recordExport(ctx, "metrics", countDataPoints(rm), start, err)
+	return err
+}
@@ -0,0 +1,179 @@
+package gokit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// GokitOTelLogger is a go-kit log.Logger that sends logs to OpenTelemetry.
+// It wraps another go-kit logger and forwards logs to both the wrapped logger
+// and OTel, so go-kit based services and middleware emit through the telemetry
+// pipeline without changing their logging calls.
+//
+// Example usage:
+//
+//	// Create your own go-kit logger with full control
+//	base := kitlog.NewLogfmtLogger(kitlog.NewSyncWriter(os.Stderr))
+//
+//	// Create telemetry for OTel
+//	t, _ := telemetry.New(ctx, &telemetry.Options{
+//	    ServiceName: "my-service",
+//	})
+//
+//	// Wrap logger with OTel logger, then add contextual fields as usual
+//	var logger kitlog.Logger = gokithook.New(base, "my-service", "v1.0.0", t.LoggerProvider())
+//	logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
+//
+//	// Use logger as normal - logs go to both the base logger and OTel
+//	level.Info(logger).Log("msg", "Hello", "key", "value")
+type GokitOTelLogger struct {
+	base           kitlog.Logger
+	logger         log.Logger
+	serviceName    string
+	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[level.Value]log.Severity
+}
+
+// New creates a new OpenTelemetry logger for go-kit.
+// This is the recommended way to add OTel integration to an existing go-kit logger.
+//
+// It wraps the provided base logger and also sends logs to OTel:
+//
+//	logger := New(yourLogger, "my-service", "v1.0.0", loggerProvider)
+//
+// The "msg" value becomes the OTel log body and the go-kit level (from the
+// level package) becomes the severity; records without a level are INFO.
+// To correlate logs with a span, pass the context as a "context" key/value pair.
+// The base logger may be nil, in which case logs are only sent to OTel.
+//
+// Returns nil if loggerProvider is nil.
+func New(base kitlog.Logger, serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *GokitOTelLogger {
+	if loggerProvider == nil {
+		return nil
+	}
+
+	cfg := newConfig(opts)
+
+	return &GokitOTelLogger{
+		base:           base,
+		logger:         loggerProvider.Logger(serviceName),
+		serviceName:    serviceName,
+		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
+	}
+}
+
+// Log implements the go-kit log.Logger interface.
+// It sends the log to both the base logger and OTel.
+func (l *GokitOTelLogger) Log(keyvals ...interface{}) error {
+	if l.base != nil {
+		if err := l.base.Log(keyvals...); err != nil {
+			return err
+		}
+	}
+
+	l.sendToOTel(keyvals)
+
+	return nil
+}
+
+// sendToOTel sends the log to OpenTelemetry.
+func (l *GokitOTelLogger) sendToOTel(keyvals []interface{}) {
+	ctx := context.TODO()
+	severity, severityText := log.SeverityInfo, "INFO"
+	msg := ""
+
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		switch {
+		case keyvals[i] == level.Key():
+			if value, ok := keyvals[i+1].(level.Value); ok {
+				severity, severityText = l.mapLevel(value)
+			}
+		case keyvals[i] == "msg":
+			msg = fmt.Sprintf("%v", keyvals[i+1])
+		case keyvals[i] == "context":
+			if val, ok := keyvals[i+1].(context.Context); ok {
+				ctx = val
+			}
+		}
+	}
+
+	// Skip building the record if the OTel pipeline would drop it
+	if severity < l.minSeverity || !l.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	// Create OTel log record
+	var logRecord log.Record
+	logRecord.SetTimestamp(time.Now())
+	logRecord.SetBody(log.StringValue(msg))
+	logRecord.SetSeverity(severity)
+	logRecord.SetSeverityText(severityText)
+
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(l.attributes...)
+
+	// Add remaining key/value pairs as attributes
+	for i := 0; i < len(keyvals); i += 2 {
+		key := keyvals[i]
+		if key == level.Key() || key == "msg" {
+			continue
+		}
+
+		// A trailing key without a value is exported with go-kit's missing value marker
+		var value interface{} = kitlog.ErrMissingValue
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		if _, ok := value.(context.Context); ok && key == "context" {
+			continue
+		}
+		// Errors are expanded into type, message, and causes; go-kit's
+		// missing value marker is kept as a plain value
+		if err, ok := value.(error); ok && err != kitlog.ErrMissingValue {
+			logRecord.AddAttributes(errorAttrs(fmt.Sprintf("%v", key), err)...)
+			continue
+		}
+
+		logRecord.AddAttributes(log.KeyValue{Key: fmt.Sprintf("%v", key), Value: toLogValue(value)})
+	}
+
+	// Emit the log record
+	l.logger.Emit(ctx, logRecord)
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (l *GokitOTelLogger) mapLevel(value level.Value) (log.Severity, string) {
+	severity, severityText := l.levelToOTel(value)
+	if override, ok := l.severities[value]; ok {
+		severity = override
+	}
+	return severity, severityText
+}
+
+// levelToOTel converts a go-kit level.Value to log.Severity.
+func (l *GokitOTelLogger) levelToOTel(value level.Value) (log.Severity, string) {
+	switch value {
+	case level.DebugValue():
+		return log.SeverityDebug, "DEBUG"
+	case level.InfoValue():
+		return log.SeverityInfo, "INFO"
+	case level.WarnValue():
+		return log.SeverityWarn, "WARN"
+	case level.ErrorValue():
+		return log.SeverityError, "ERROR"
+	default:
+		return log.SeverityInfo, "INFO"
+	}
+}
@@ -0,0 +1,133 @@
+package gokit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	kitlog "github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// newLogger returns the OTel logger wrapping a logfmt logger into buf.
+func newLogger(t *testing.T, buf *bytes.Buffer, opts ...Option) (kitlog.Logger, *recordingExporter) {
+	t.Helper()
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	return New(kitlog.NewLogfmtLogger(buf), "test", "1.0.0", lp, opts...), exporter
+}
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record sdklog.Record) map[string]log.Value {
+	out := map[string]log.Value{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func TestLogger_Log(t *testing.T) {
+	var buf bytes.Buffer
+	logger, exporter := newLogger(t, &buf, WithAttributes(log.String("env", "prod")))
+	logger = kitlog.With(logger, "component", "api")
+
+	_ = level.Warn(logger).Log("msg", "slow request", "latency", 1.5, "err", errors.New("timeout"))
+
+	if !strings.Contains(buf.String(), "msg=\"slow request\"") {
+		t.Errorf("base logger wrote %q, want the message", buf.String())
+	}
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if got := record.Body().AsString(); got != "slow request" {
+		t.Errorf("Body() = %q, want %q", got, "slow request")
+	}
+	if got := record.Severity(); got != log.SeverityWarn {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityWarn)
+	}
+
+	got := attrs(record)
+	for key, want := range map[string]string{
+		"env":         "prod",
+		"component":   "api",
+		"err.message": "timeout",
+	} {
+		if got[key].AsString() != want {
+			t.Errorf("attribute %q = %v, want %q", key, got[key], want)
+		}
+	}
+	if got["latency"].AsFloat64() != 1.5 {
+		t.Errorf("attribute latency = %v, want 1.5", got["latency"])
+	}
+	for _, key := range []string{"level", "msg"} {
+		if _, ok := got[key]; ok {
+			t.Errorf("attribute %q was exported, want it mapped onto the record", key)
+		}
+	}
+}
+
+func TestLogger_MissingValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger, exporter := newLogger(t, &buf)
+
+	_ = logger.Log("msg", "odd", "dangling")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := attrs(exporter.records[0])["dangling"].AsString(); got != kitlog.ErrMissingValue.Error() {
+		t.Errorf("attribute dangling = %q, want %q", got, kitlog.ErrMissingValue.Error())
+	}
+}
+
+func TestLogger_WithMinSeverityAndMapping(t *testing.T) {
+	var buf bytes.Buffer
+	logger, exporter := newLogger(t, &buf,
+		WithMinSeverity(log.SeverityInfo),
+		WithSeverityMapping(map[level.Value]log.Severity{level.WarnValue(): log.SeverityInfo4}),
+	)
+
+	_ = level.Debug(logger).Log("msg", "noise")
+	_ = level.Warn(logger).Log("msg", "kept")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := exporter.records[0].Severity(); got != log.SeverityInfo4 {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityInfo4)
+	}
+	if got := exporter.records[0].SeverityText(); got != "WARN" {
+		t.Errorf("SeverityText() = %q, want WARN", got)
+	}
+	if !strings.Contains(buf.String(), "noise") {
+		t.Error("base logger dropped a record below the OTel minimum severity")
+	}
+}
+
+func TestNew_NilProvider(t *testing.T) {
+	if logger := New(nil, "test", "1.0.0", nil); logger != nil {
+		t.Errorf("New() = %v, want nil", logger)
+	}
+}
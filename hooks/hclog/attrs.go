@@ -0,0 +1,193 @@
+package hclog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// maxValueDepth bounds how deep nested maps, slices, and structs are
+// converted; deeper values, including cyclic ones, are formatted as strings.
+const maxValueDepth = 8
+
+// toLogValue converts a field value into a typed OTel log value, so numbers,
+// booleans, durations, times, slices, maps, and structs keep their structure
+// instead of being flattened to strings.
+func toLogValue(v any) log.Value {
+	return toLogValueDepth(v, 0)
+}
+
+// toLogValueDepth converts v at the given nesting depth.
+func toLogValueDepth(v any, depth int) log.Value {
+	if v == nil {
+		return log.Value{}
+	}
+
+	switch val := v.(type) {
+	case string:
+		return log.StringValue(val)
+	case bool:
+		return log.BoolValue(val)
+	case int:
+		return log.IntValue(val)
+	case int8:
+		return log.Int64Value(int64(val))
+	case int16:
+		return log.Int64Value(int64(val))
+	case int32:
+		return log.Int64Value(int64(val))
+	case int64:
+		return log.Int64Value(val)
+	case uint:
+		return uintValue(uint64(val))
+	case uint8:
+		return log.Int64Value(int64(val))
+	case uint16:
+		return log.Int64Value(int64(val))
+	case uint32:
+		return log.Int64Value(int64(val))
+	case uint64:
+		return uintValue(val)
+	case uintptr:
+		return uintValue(uint64(val))
+	case float32:
+		return log.Float64Value(float64(val))
+	case float64:
+		return log.Float64Value(val)
+	case time.Duration:
+		return log.StringValue(val.String())
+	case time.Time:
+		return log.StringValue(val.Format(time.RFC3339Nano))
+	case json.RawMessage:
+		// Raw JSON is passed through as-is to avoid encoding it a second time
+		return log.StringValue(string(val))
+	case []byte:
+		return log.BytesValue(val)
+	case error:
+		return log.StringValue(val.Error())
+	case fmt.Stringer:
+		return log.StringValue(val.String())
+	}
+
+	if depth >= maxValueDepth {
+		return log.StringValue(fmt.Sprintf("%v", v))
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		// Slices of any element type ([]string, []int, ...)
+		values := make([]log.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, toLogValueDepth(rv.Index(i).Interface(), depth+1))
+		}
+		return log.SliceValue(values...)
+
+	case reflect.Map:
+		// Maps of any key type (map[string]any, map[string]int, ...), sorted by key
+		kvs := make([]log.KeyValue, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			kvs = append(kvs, log.KeyValue{
+				Key:   fmt.Sprintf("%v", iter.Key().Interface()),
+				Value: toLogValueDepth(iter.Value().Interface(), depth+1),
+			})
+		}
+		slices.SortFunc(kvs, func(a, b log.KeyValue) int { return strings.Compare(a.Key, b.Key) })
+		return log.MapValue(kvs...)
+
+	case reflect.Struct:
+		return structValue(rv, depth)
+
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return log.Value{}
+		}
+		return toLogValueDepth(rv.Elem().Interface(), depth+1)
+	}
+
+	return log.StringValue(fmt.Sprintf("%v", v))
+}
+
+// structValue converts a struct into a map value of its exported fields,
+// named after their json tag if present; fields tagged "-" are skipped.
+func structValue(rv reflect.Value, depth int) log.Value {
+	rt := rv.Type()
+	kvs := make([]log.KeyValue, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		kvs = append(kvs, log.KeyValue{Key: name, Value: toLogValueDepth(rv.Field(i).Interface(), depth+1)})
+	}
+	return log.MapValue(kvs...)
+}
+
+// uintValue converts an unsigned integer to an OTel log value.
+// Values that overflow int64 are sent as strings to avoid wrapping negative.
+func uintValue(v uint64) log.Value {
+	if v > math.MaxInt64 {
+		return log.StringValue(fmt.Sprintf("%d", v))
+	}
+	return log.Int64Value(int64(v))
+}
+
+// maxErrorCauses bounds the number of wrapped causes exported for one error.
+const maxErrorCauses = 16
+
+// errorAttrs expands err into separate attributes under key: key.type (the Go
+// type of err), key.message, and key.causes with the messages of the errors
+// it wraps, so backends can filter on the error type and root cause instead
+// of parsing one flattened string.
+func errorAttrs(key string, err error) []log.KeyValue {
+	attrs := []log.KeyValue{
+		log.String(key+".type", fmt.Sprintf("%T", err)),
+		log.String(key+".message", err.Error()),
+	}
+	if causes := errorCauses(err); len(causes) > 0 {
+		attrs = append(attrs, log.Slice(key+".causes", causes...))
+	}
+	return attrs
+}
+
+// errorCauses returns the messages of the errors wrapped by err, outermost
+// first, following both errors.Unwrap and errors.Join chains.
+func errorCauses(err error) []log.Value {
+	var causes []log.Value
+	queue := unwrapError(err)
+	for len(queue) > 0 && len(causes) < maxErrorCauses {
+		cause := queue[0]
+		queue = queue[1:]
+		if cause == nil {
+			continue
+		}
+		causes = append(causes, log.StringValue(cause.Error()))
+		queue = append(queue, unwrapError(cause)...)
+	}
+	return causes
+}
+
+// unwrapError returns the errors directly wrapped by err.
+func unwrapError(err error) []error {
+	if cause := errors.Unwrap(err); cause != nil {
+		return []error{cause}
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return nil
+}
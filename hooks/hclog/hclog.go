@@ -0,0 +1,199 @@
+package hclog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// HclogOTelSink is an hclog.SinkAdapter that sends logs to OpenTelemetry.
+// It is registered on an hclog.InterceptLogger, which keeps writing to its own
+// output while every log line is also forwarded to OTel. This lets HashiCorp
+// libraries (Vault, Consul, raft, go-plugin) that accept an hclog.Logger emit
+// through the telemetry pipeline.
+//
+// Example usage:
+//
+//	// Create your own hclog logger with full control
+//	log := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+//	    Name:            "my-service",
+//	    Level:           hclog.Info,
+//	    IncludeLocation: true,  // Caller info will be accurate
+//	})
+//
+//	// Create telemetry for OTel
+//	t, _ := telemetry.New(ctx, &telemetry.Options{
+//	    ServiceName: "my-service",
+//	})
+//
+//	// Attach OTel sink to existing logger
+//	sink := hcloghook.New("my-service", "v1.0.0", t.LoggerProvider())
+//	log.RegisterSink(sink)
+//
+//	// Use logger as normal - logs go to both the logger output and OTel
+//	log.Info("Hello", "key", "value")
+type HclogOTelSink struct {
+	logger         log.Logger
+	serviceName    string
+	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[hclog.Level]log.Severity
+}
+
+// New creates a new OpenTelemetry sink for hclog.
+// This is the recommended way to add OTel integration to an existing hclog logger.
+//
+// The sink can be attached to any hclog.InterceptLogger using RegisterSink():
+//
+//	sink := New("my-service", "v1.0.0", loggerProvider)
+//	myLogger.RegisterSink(sink)
+//
+// To correlate logs with a span, pass the context as a "context" key/value pair.
+//
+// Returns nil if loggerProvider is nil.
+func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *HclogOTelSink {
+	if loggerProvider == nil {
+		return nil
+	}
+
+	cfg := newConfig(opts)
+
+	return &HclogOTelSink{
+		logger:         loggerProvider.Logger(serviceName),
+		serviceName:    serviceName,
+		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
+	}
+}
+
+// Accept implements the hclog.SinkAdapter interface.
+func (s *HclogOTelSink) Accept(name string, level hclog.Level, msg string, args ...interface{}) {
+	if s == nil || level == hclog.Off {
+		return
+	}
+
+	ctx := context.TODO()
+	for i := 0; i < len(args); i += 2 {
+		if val, ok := contextFromPair(args, i); ok {
+			ctx = val
+		}
+	}
+
+	// Convert hclog level to OTel severity
+	severity, severityText := s.mapLevel(level)
+
+	// Skip building the record if the OTel pipeline would drop it
+	if severity < s.minSeverity || !s.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	// Create OTel log record
+	var logRecord log.Record
+	logRecord.SetTimestamp(time.Now())
+	logRecord.SetBody(log.StringValue(msg))
+	logRecord.SetSeverity(severity)
+	logRecord.SetSeverityText(severityText)
+
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(s.attributes...)
+
+	// Add logger name
+	if name != "" {
+		logRecord.AddAttributes(log.String("logger", name))
+	}
+
+	// Add key/value pairs as attributes
+	for i := 0; i < len(args); i += 2 {
+		if _, ok := contextFromPair(args, i); ok {
+			continue
+		}
+
+		// A trailing value without a key is exported under hclog.MissingKey,
+		// matching hclog's own output
+		if i+1 >= len(args) {
+			logRecord.AddAttributes(log.KeyValue{Key: hclog.MissingKey, Value: argValue(args[i])})
+			break
+		}
+
+		key, ok := args[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", args[i])
+		}
+		// Errors are expanded into type, message, and causes
+		if err, ok := args[i+1].(error); ok {
+			logRecord.AddAttributes(errorAttrs(key, err)...)
+			continue
+		}
+		logRecord.AddAttributes(log.KeyValue{Key: key, Value: argValue(args[i+1])})
+	}
+
+	// Emit the log record
+	s.logger.Emit(ctx, logRecord)
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (s *HclogOTelSink) mapLevel(level hclog.Level) (log.Severity, string) {
+	severity, severityText := s.hclogLevelToOTel(level)
+	if override, ok := s.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
+}
+
+// hclogLevelToOTel converts hclog.Level to log.Severity.
+func (s *HclogOTelSink) hclogLevelToOTel(level hclog.Level) (log.Severity, string) {
+	switch level {
+	case hclog.Trace:
+		return log.SeverityTrace, "TRACE"
+	case hclog.Debug:
+		return log.SeverityDebug, "DEBUG"
+	case hclog.Info:
+		return log.SeverityInfo, "INFO"
+	case hclog.Warn:
+		return log.SeverityWarn, "WARN"
+	case hclog.Error:
+		return log.SeverityError, "ERROR"
+	default:
+		return log.SeverityInfo, "INFO"
+	}
+}
+
+// contextFromPair returns the context carried by a "context" key/value pair
+// starting at index i.
+func contextFromPair(args []interface{}, i int) (context.Context, bool) {
+	if i+1 >= len(args) || args[i] != "context" {
+		return nil, false
+	}
+	ctx, ok := args[i+1].(context.Context)
+	return ctx, ok
+}
+
+// argValue converts an hclog argument into a typed OTel log value, applying
+// hclog's formatting types (Format, Hex, Octal, Binary, Quote) before the
+// generic conversion.
+func argValue(v interface{}) log.Value {
+	switch val := v.(type) {
+	case hclog.Format:
+		if len(val) == 0 {
+			return log.StringValue("")
+		}
+		return log.StringValue(fmt.Sprintf(fmt.Sprintf("%v", val[0]), val[1:]...))
+	case hclog.Hex:
+		return log.StringValue(fmt.Sprintf("0x%x", int(val)))
+	case hclog.Octal:
+		return log.StringValue(fmt.Sprintf("0%o", int(val)))
+	case hclog.Binary:
+		return log.StringValue(fmt.Sprintf("0b%b", int(val)))
+	case hclog.Quote:
+		return log.StringValue(string(val))
+	}
+	return toLogValue(v)
+}
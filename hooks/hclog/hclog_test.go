@@ -0,0 +1,152 @@
+package hclog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// newLogger returns an intercept logger with the OTel sink registered.
+func newLogger(t *testing.T, opts ...Option) (hclog.InterceptLogger, *recordingExporter) {
+	t.Helper()
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() { _ = lp.Shutdown(context.Background()) })
+
+	logger := hclog.NewInterceptLogger(&hclog.LoggerOptions{
+		Name:   "app",
+		Level:  hclog.Trace,
+		Output: io.Discard,
+	})
+	logger.RegisterSink(New("test", "1.0.0", lp, opts...))
+	return logger, exporter
+}
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record sdklog.Record) map[string]log.Value {
+	out := map[string]log.Value{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func TestSink_Accept(t *testing.T) {
+	logger, exporter := newLogger(t, WithAttributes(log.String("env", "prod")))
+
+	logger.Named("raft").Warn("slow append",
+		"peer", "node-2",
+		"latency", 1.5,
+		"mode", hclog.Hex(255),
+		"err", errors.New("timeout"),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if got := record.Body().AsString(); got != "slow append" {
+		t.Errorf("Body() = %q, want %q", got, "slow append")
+	}
+	if got := record.Severity(); got != log.SeverityWarn {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityWarn)
+	}
+
+	got := attrs(record)
+	for key, want := range map[string]string{
+		"env":         "prod",
+		"logger":      "app.raft",
+		"peer":        "node-2",
+		"mode":        "0xff",
+		"err.message": "timeout",
+	} {
+		if got[key].AsString() != want {
+			t.Errorf("attribute %q = %v, want %q", key, got[key], want)
+		}
+	}
+	if got["latency"].AsFloat64() != 1.5 {
+		t.Errorf("attribute latency = %v, want 1.5", got["latency"])
+	}
+}
+
+func TestSink_MissingKey(t *testing.T) {
+	logger, exporter := newLogger(t)
+
+	logger.Info("odd args", "key", "value", "dangling")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := attrs(exporter.records[0])[hclog.MissingKey].AsString(); got != "dangling" {
+		t.Errorf("attribute %q = %q, want dangling", hclog.MissingKey, got)
+	}
+}
+
+func TestSink_WithMinSeverityAndMapping(t *testing.T) {
+	logger, exporter := newLogger(t,
+		WithMinSeverity(log.SeverityInfo),
+		WithSeverityMapping(map[hclog.Level]log.Severity{hclog.Warn: log.SeverityInfo4}),
+	)
+
+	logger.Debug("noise")
+	logger.Warn("kept")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := exporter.records[0].Severity(); got != log.SeverityInfo4 {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityInfo4)
+	}
+	if got := exporter.records[0].SeverityText(); got != "WARN" {
+		t.Errorf("SeverityText() = %q, want WARN", got)
+	}
+}
+
+func TestSink_ContextCorrelation(t *testing.T) {
+	logger, exporter := newLogger(t)
+
+	tp := sdktrace.NewTracerProvider()
+	defer tp.Shutdown(context.Background())
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	logger.Info("in span", "context", ctx)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if got := record.TraceID(); got != span.SpanContext().TraceID() {
+		t.Errorf("TraceID() = %v, want %v", got, span.SpanContext().TraceID())
+	}
+	if _, ok := attrs(record)["context"]; ok {
+		t.Error("context pair was exported as an attribute")
+	}
+}
+
+func TestNew_NilProvider(t *testing.T) {
+	if sink := New("test", "1.0.0", nil); sink != nil {
+		t.Errorf("New() = %v, want nil", sink)
+	}
+}
@@ -0,0 +1,58 @@
+package hclog
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"go.opentelemetry.io/otel/log"
+)
+
+// Option configures the sink created by New.
+type Option func(*config)
+
+// config holds the settings applied by Options.
+type config struct {
+	minSeverity log.Severity
+	attributes  []log.KeyValue
+	severities  map[hclog.Level]log.Severity
+}
+
+// newConfig applies opts to the default settings.
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// WithMinSeverity sets the lowest severity sent to OpenTelemetry. Records
+// below it are not exported, but the logger still writes them to its own
+// output, so debug logs can stay on the console without reaching the collector.
+func WithMinSeverity(severity log.Severity) Option {
+	return func(c *config) {
+		c.minSeverity = severity
+	}
+}
+
+// WithAttributes adds attrs to every record sent to OpenTelemetry, for fixed
+// context such as the environment, region, or pod name. Fields from a log
+// call with the same key take precedence.
+func WithAttributes(attrs ...log.KeyValue) Option {
+	return func(c *config) {
+		c.attributes = append(c.attributes, attrs...)
+	}
+}
+
+// WithSeverityMapping overrides the OTel severity for the given levels,
+// e.g. {hclog.Warn: log.SeverityInfo4}, for backends that alert on severity
+// numbers. Unlisted levels keep the default mapping, and the severity text
+// stays the logger's level name.
+func WithSeverityMapping(mapping map[hclog.Level]log.Severity) Option {
+	return func(c *config) {
+		if c.severities == nil {
+			c.severities = make(map[hclog.Level]log.Severity, len(mapping))
+		}
+		for level, severity := range mapping {
+			c.severities[level] = severity
+		}
+	}
+}
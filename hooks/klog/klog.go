@@ -0,0 +1,52 @@
+package klog
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"k8s.io/klog/v2"
+)
+
+// Install routes klog output into OpenTelemetry, so logs from client-go and
+// other Kubernetes libraries join the application's telemetry log stream.
+//
+// klog is redirected to a logr.Logger backed by sink, which should be an OTel
+// logr sink such as the one created by the logr hook. A sink that also writes
+// locally must not write through klog itself (such as klog.Background()),
+// since klog would then feed its own output back into the sink.
+// klog.Flush also force-flushes loggerProvider, which should be the provider
+// sink emits to.
+//
+// Example usage:
+//
+//	// Create telemetry for OTel
+//	t, _ := telemetry.New(ctx, &telemetry.Options{
+//	    ServiceName: "my-operator",
+//	})
+//
+//	// Keep klog's text output on stderr and also send it to OTel
+//	base := textlogger.NewLogger(textlogger.NewConfig()).GetSink()
+//	sink := logrhook.New(base, "my-operator", "v1.0.0", t.LoggerProvider())
+//	kloghook.Install(sink, t.LoggerProvider())
+//	defer klog.Flush()
+//
+// With the logr hook, klog.V(n) verbosity becomes INFO, DEBUG, or TRACE, and
+// error calls (klog.ErrorS, klog.Error) become ERROR. klog passes warnings to
+// logr as Info, so they are exported as INFO.
+//
+// Returns false and leaves klog untouched if sink or loggerProvider is nil.
+func Install(sink logr.LogSink, loggerProvider *sdklog.LoggerProvider) bool {
+	if sink == nil || loggerProvider == nil {
+		return false
+	}
+
+	klog.SetLoggerWithOptions(logr.New(sink),
+		klog.ContextualLogger(true),
+		klog.FlushLogger(func() {
+			_ = loggerProvider.ForceFlush(context.Background())
+		}),
+	)
+
+	return true
+}
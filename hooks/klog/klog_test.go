@@ -0,0 +1,116 @@
+package klog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"k8s.io/klog/v2"
+)
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// otelSink is a minimal logr sink that emits every call to an OTel logger,
+// standing in for the logr hook.
+type otelSink struct {
+	logger log.Logger
+}
+
+func (s *otelSink) Init(logr.RuntimeInfo)             {}
+func (s *otelSink) Enabled(int) bool                  { return true }
+func (s *otelSink) WithValues(...any) logr.LogSink    { return s }
+func (s *otelSink) WithName(string) logr.LogSink      { return s }
+func (s *otelSink) Info(_ int, msg string, kv ...any) { s.emit(log.SeverityInfo, msg, kv) }
+func (s *otelSink) Error(err error, msg string, kv ...any) {
+	s.emit(log.SeverityError, msg, append(kv, "error.message", err.Error()))
+}
+
+func (s *otelSink) emit(severity log.Severity, msg string, keysAndValues []any) {
+	var record log.Record
+	record.SetBody(log.StringValue(msg))
+	record.SetSeverity(severity)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		record.AddAttributes(log.String(fmt.Sprint(keysAndValues[i]), fmt.Sprint(keysAndValues[i+1])))
+	}
+	s.logger.Emit(context.Background(), record)
+}
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record sdklog.Record) map[string]log.Value {
+	out := map[string]log.Value{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func TestInstall(t *testing.T) {
+	exporter := &recordingExporter{}
+	// A long interval keeps records queued until klog.Flush flushes them
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(
+		sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour)),
+	))
+	defer lp.Shutdown(context.Background())
+
+	if !Install(&otelSink{logger: lp.Logger("test")}, lp) {
+		t.Fatal("Install() = false, want true")
+	}
+	defer klog.ClearLogger()
+
+	klog.InfoS("pod synced", "pod", "default/app")
+	klog.ErrorS(errors.New("conflict"), "update failed", "attempt", 2)
+	klog.Flush()
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("exported %d records after klog.Flush, want 2", len(exporter.records))
+	}
+
+	info := exporter.records[0]
+	if got := info.Body().AsString(); got != "pod synced" {
+		t.Errorf("Body() = %q, want %q", got, "pod synced")
+	}
+	if got := info.Severity(); got != log.SeverityInfo {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityInfo)
+	}
+	if got := attrs(info)["pod"].AsString(); got != "default/app" {
+		t.Errorf("attribute pod = %q, want default/app", got)
+	}
+
+	failed := exporter.records[1]
+	if got := failed.Severity(); got != log.SeverityError {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityError)
+	}
+	if got := attrs(failed)["error.message"].AsString(); got != "conflict" {
+		t.Errorf("attribute error.message = %q, want conflict", got)
+	}
+}
+
+func TestInstall_Nil(t *testing.T) {
+	lp := sdklog.NewLoggerProvider()
+	defer lp.Shutdown(context.Background())
+
+	if Install(nil, lp) {
+		t.Error("Install(nil, lp) = true, want false")
+	}
+	if Install(&otelSink{logger: lp.Logger("test")}, nil) {
+		t.Error("Install(sink, nil) = true, want false")
+	}
+}
@@ -0,0 +1,287 @@
+package logr
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// LogrOTelSink is a logr.LogSink that sends logs to OpenTelemetry.
+// It wraps another sink and forwards logs to both the wrapped sink and OTel,
+// so libraries that accept a logr.Logger (controller-runtime, client-go, ...)
+// emit through the telemetry pipeline.
+//
+// Example usage:
+//
+//	// Create your own logr sink with full control
+//	base := stdr.New(stdlog.New(os.Stderr, "", stdlog.LstdFlags)).GetSink()
+//
+//	// Create telemetry for OTel
+//	t, _ := telemetry.New(ctx, &telemetry.Options{
+//	    ServiceName: "my-service",
+//	})
+//
+//	// Wrap sink with OTel sink
+//	sink := logrhook.New(base, "my-service", "v1.0.0", t.LoggerProvider())
+//	log := logr.New(sink)
+//
+//	// Use logger as normal - logs go to both the base sink and OTel
+//	log.Info("Hello", "key", "value")
+type LogrOTelSink struct {
+	base           logr.LogSink
+	logger         log.Logger
+	serviceName    string
+	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[int]log.Severity
+
+	// name, values, and ctx hold state added via WithName and WithValues
+	name   string
+	values []any
+	ctx    context.Context
+}
+
+// New creates a new OpenTelemetry sink for logr.
+// This is the recommended way to add OTel integration to an existing logr logger.
+//
+// It wraps the provided base sink and also sends logs to OTel:
+//
+//	sink := New(yourLogger.GetSink(), "my-service", "v1.0.0", loggerProvider)
+//	logger := logr.New(sink)
+//
+// The base sink may be nil, in which case logs are only sent to OTel.
+// To correlate logs with a span, pass the context as a "context" key/value pair.
+//
+// Returns nil if loggerProvider is nil.
+func New(base logr.LogSink, serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *LogrOTelSink {
+	if loggerProvider == nil {
+		return nil
+	}
+
+	cfg := newConfig(opts)
+
+	return &LogrOTelSink{
+		base:           base,
+		logger:         loggerProvider.Logger(serviceName),
+		serviceName:    serviceName,
+		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
+	}
+}
+
+// Init receives runtime info about the logr library.
+// The base sink is told about the extra frame added by this wrapper so its
+// caller reporting stays accurate.
+func (s *LogrOTelSink) Init(info logr.RuntimeInfo) {
+	if s.base != nil {
+		info.CallDepth++
+		s.base.Init(info)
+	}
+}
+
+// Enabled reports whether logs at the given verbosity level are handled by
+// either the base sink or OTel, so a quiet base sink does not hide records
+// the OTel pipeline wants.
+func (s *LogrOTelSink) Enabled(level int) bool {
+	if s.base != nil && s.base.Enabled(level) {
+		return true
+	}
+	severity, _ := s.mapLevel(level)
+	ctx := s.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return s.otelEnabled(ctx, severity)
+}
+
+// Info logs a non-error message with the given key/value pairs.
+// It sends the log to both the base sink and OTel.
+func (s *LogrOTelSink) Info(level int, msg string, keysAndValues ...any) {
+	// logr only checks the combined Enabled, so the base sink is asked again
+	if s.base != nil && s.base.Enabled(level) {
+		s.base.Info(level, msg, keysAndValues...)
+	}
+
+	severity, severityText := s.mapLevel(level)
+	s.sendToOTel(severity, severityText, msg, nil, keysAndValues)
+}
+
+// Error logs an error with the given message and key/value pairs.
+// It sends the log to both the base sink and OTel.
+func (s *LogrOTelSink) Error(err error, msg string, keysAndValues ...any) {
+	if s.base != nil {
+		s.base.Error(err, msg, keysAndValues...)
+	}
+
+	s.sendToOTel(log.SeverityError, "ERROR", msg, err, keysAndValues)
+}
+
+// WithValues returns a new sink with additional key/value pairs.
+func (s *LogrOTelSink) WithValues(keysAndValues ...any) logr.LogSink {
+	clone := *s
+	if s.base != nil {
+		clone.base = s.base.WithValues(keysAndValues...)
+	}
+	clone.values = make([]any, 0, len(s.values)+len(keysAndValues))
+	clone.values = append(clone.values, s.values...)
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if ctx, ok := contextFromPair(keysAndValues, i); ok {
+			clone.ctx = ctx
+			continue
+		}
+		clone.values = append(clone.values, keysAndValues[i])
+		if i+1 < len(keysAndValues) {
+			clone.values = append(clone.values, keysAndValues[i+1])
+		}
+	}
+
+	return &clone
+}
+
+// WithName returns a new sink with the specified name appended.
+// Names are joined with "/" and exported as the "logger" attribute.
+func (s *LogrOTelSink) WithName(name string) logr.LogSink {
+	clone := *s
+	if s.base != nil {
+		clone.base = s.base.WithName(name)
+	}
+	if s.name == "" {
+		clone.name = name
+	} else {
+		clone.name = s.name + "/" + name
+	}
+	return &clone
+}
+
+// WithCallDepth returns a sink that offsets the call stack by depth.
+// It implements logr.CallDepthLogSink by forwarding to the base sink.
+func (s *LogrOTelSink) WithCallDepth(depth int) logr.LogSink {
+	clone := *s
+	if withDepth, ok := s.base.(logr.CallDepthLogSink); ok {
+		clone.base = withDepth.WithCallDepth(depth)
+	}
+	return &clone
+}
+
+// sendToOTel sends the log to OpenTelemetry.
+func (s *LogrOTelSink) sendToOTel(severity log.Severity, severityText, msg string, err error, keysAndValues []any) {
+	ctx := s.ctx
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if val, ok := contextFromPair(keysAndValues, i); ok {
+			ctx = val
+		}
+	}
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
+	// Skip building the record if the OTel pipeline would drop it
+	if !s.otelEnabled(ctx, severity) {
+		return
+	}
+
+	// Create OTel log record
+	var logRecord log.Record
+	logRecord.SetTimestamp(time.Now())
+	logRecord.SetBody(log.StringValue(msg))
+	logRecord.SetSeverity(severity)
+	logRecord.SetSeverityText(severityText)
+
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(s.attributes...)
+
+	// Add logger name
+	if s.name != "" {
+		logRecord.AddAttributes(log.String("logger", s.name))
+	}
+
+	if err != nil {
+		logRecord.AddAttributes(errorAttrs("error", err)...)
+	}
+
+	addPairs(&logRecord, s.values)
+	addPairs(&logRecord, keysAndValues)
+
+	// Emit the log record
+	s.logger.Emit(ctx, logRecord)
+}
+
+// otelEnabled reports whether the OTel pipeline would keep a record at severity.
+func (s *LogrOTelSink) otelEnabled(ctx context.Context, severity log.Severity) bool {
+	return severity >= s.minSeverity && s.logger.Enabled(ctx, log.EnabledParameters{Severity: severity})
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (s *LogrOTelSink) mapLevel(level int) (log.Severity, string) {
+	severity, severityText := s.levelToOTel(level)
+	if override, ok := s.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
+}
+
+// levelToOTel converts a logr verbosity level to log.Severity.
+// V(0) is Info, V(1) is Debug, and anything more verbose is Trace.
+func (s *LogrOTelSink) levelToOTel(level int) (log.Severity, string) {
+	switch {
+	case level <= 0:
+		return log.SeverityInfo, "INFO"
+	case level == 1:
+		return log.SeverityDebug, "DEBUG"
+	default:
+		return log.SeverityTrace, "TRACE"
+	}
+}
+
+// addPairs adds logr key/value pairs to the record as attributes.
+func addPairs(logRecord *log.Record, keysAndValues []any) {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if _, ok := contextFromPair(keysAndValues, i); ok {
+			continue
+		}
+
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprintf("%v", keysAndValues[i])
+		}
+
+		var value log.Value
+		if i+1 < len(keysAndValues) {
+			if err, ok := keysAndValues[i+1].(error); ok {
+				logRecord.AddAttributes(errorAttrs(key, err)...)
+				continue
+			}
+			value = pairValue(keysAndValues[i+1])
+		}
+
+		logRecord.AddAttributes(log.KeyValue{Key: key, Value: value})
+	}
+}
+
+// contextFromPair returns the context carried by a "context" key/value pair
+// starting at index i.
+func contextFromPair(keysAndValues []any, i int) (context.Context, bool) {
+	if i+1 >= len(keysAndValues) || keysAndValues[i] != "context" {
+		return nil, false
+	}
+	ctx, ok := keysAndValues[i+1].(context.Context)
+	return ctx, ok
+}
+
+// pairValue converts a logr value into a typed OTel log value, resolving
+// logr.Marshaler values before the generic conversion.
+func pairValue(v any) log.Value {
+	if marshaler, ok := v.(logr.Marshaler); ok {
+		v = marshaler.MarshalLog()
+	}
+	return toLogValue(v)
+}
@@ -0,0 +1,181 @@
+package logr
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// discardExporter drops every record.
+type discardExporter struct{}
+
+func (discardExporter) Export(context.Context, []sdklog.Record) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                { return nil }
+func (discardExporter) ForceFlush(context.Context) error              { return nil }
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// newBase returns a funcr sink at verbosity 0 and the lines it wrote.
+func newBase() (logr.LogSink, *[]string) {
+	var lines []string
+	base := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{}).GetSink()
+	return base, &lines
+}
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record sdklog.Record) map[string]log.Value {
+	out := map[string]log.Value{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func BenchmarkSink_Info(b *testing.B) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	logger := logr.New(New(nil, "bench", "1.0.0", lp)).WithValues("region", "us-east-1")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled",
+			"user", "alice",
+			"attempt", 3,
+			"latency", 1.5,
+			"success", true,
+			"endpoint", "/api/v1/users",
+			"bytes", int64(2048),
+		)
+	}
+}
+
+func TestSink_EnabledWhenOnlyOTelWantsLevel(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	base, lines := newBase()
+	logger := logr.New(New(base, "test", "1.0.0", lp))
+
+	logger.V(1).Info("debug detail")
+
+	if len(*lines) != 0 {
+		t.Errorf("base sink wrote %v, want nothing above its verbosity", *lines)
+	}
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := exporter.records[0].Severity(); got != log.SeverityDebug {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityDebug)
+	}
+}
+
+func TestSink_EnabledWhenOnlyBaseWantsLevel(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	base, lines := newBase()
+	sink := New(base, "test", "1.0.0", lp, WithMinSeverity(log.SeverityWarn))
+	logger := logr.New(sink)
+
+	if !sink.Enabled(0) {
+		t.Error("Enabled(0) = false, want true while the base sink handles it")
+	}
+	logger.Info("hello")
+
+	if len(*lines) != 1 {
+		t.Errorf("base sink wrote %d lines, want 1", len(*lines))
+	}
+	if len(exporter.records) != 0 {
+		t.Errorf("exported %d records, want 0 below the minimum severity", len(exporter.records))
+	}
+}
+
+func TestSink_DisabledWhenNeitherWantsLevel(t *testing.T) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	base, _ := newBase()
+	sink := New(base, "test", "1.0.0", lp, WithMinSeverity(log.SeverityInfo))
+
+	if sink.Enabled(1) {
+		t.Error("Enabled(1) = true, want false")
+	}
+}
+
+func TestSink_ValuesNameAndError(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := logr.New(New(nil, "test", "1.0.0", lp, WithAttributes(log.String("env", "prod")))).
+		WithName("controller").
+		WithName("reconciler").
+		WithValues("request", "default/app")
+
+	logger.Error(errors.New("boom"), "reconcile failed", "attempt", 3)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if got := record.Severity(); got != log.SeverityError {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityError)
+	}
+	got := attrs(record)
+	for key, want := range map[string]string{
+		"env":           "prod",
+		"logger":        "controller/reconciler",
+		"request":       "default/app",
+		"error.message": "boom",
+	} {
+		if got[key].AsString() != want {
+			t.Errorf("attribute %q = %v, want %q", key, got[key], want)
+		}
+	}
+	if got["attempt"].AsInt64() != 3 {
+		t.Errorf("attribute attempt = %v, want 3", got["attempt"])
+	}
+}
+
+func TestSink_WithSeverityMapping(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := logr.New(New(nil, "test", "1.0.0", lp, WithSeverityMapping(map[int]log.Severity{2: log.SeverityDebug2})))
+	logger.V(2).Info("verbose")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	if got := exporter.records[0].Severity(); got != log.SeverityDebug2 {
+		t.Errorf("Severity() = %v, want %v", got, log.SeverityDebug2)
+	}
+	if got := exporter.records[0].SeverityText(); got != "TRACE" {
+		t.Errorf("SeverityText() = %q, want TRACE", got)
+	}
+}
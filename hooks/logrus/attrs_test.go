@@ -0,0 +1,126 @@
+package logrus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestToLogValue_Nested(t *testing.T) {
+	type address struct {
+		City    string `json:"city"`
+		Zip     string `json:"-"`
+		Country string
+		secret  string
+	}
+	value := toLogValue(map[string]any{
+		"user": map[string]any{
+			"name":    "alice",
+			"roles":   []string{"admin", "dev"},
+			"address": &address{City: "Berlin", Zip: "10115", Country: "DE", secret: "x"},
+		},
+	})
+
+	if value.Kind() != log.KindMap {
+		t.Fatalf("toLogValue() kind = %v, want map", value.Kind())
+	}
+	user := value.AsMap()[0]
+	if user.Key != "user" || user.Value.Kind() != log.KindMap {
+		t.Fatalf("user = %v, want a map", user)
+	}
+
+	fields := map[string]log.Value{}
+	for _, kv := range user.Value.AsMap() {
+		fields[kv.Key] = kv.Value
+	}
+	if fields["name"].AsString() != "alice" {
+		t.Errorf("name = %v, want alice", fields["name"])
+	}
+	if roles := fields["roles"].AsSlice(); len(roles) != 2 || roles[1].AsString() != "dev" {
+		t.Errorf("roles = %v, want [admin dev]", fields["roles"])
+	}
+
+	addr := map[string]string{}
+	for _, kv := range fields["address"].AsMap() {
+		addr[kv.Key] = kv.Value.AsString()
+	}
+	if len(addr) != 2 || addr["city"] != "Berlin" || addr["Country"] != "DE" {
+		t.Errorf("address = %v, want city and Country only", addr)
+	}
+}
+
+func TestToLogValue_Cyclic(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+
+	if value := toLogValue(n); value.Kind() != log.KindMap {
+		t.Errorf("toLogValue() kind = %v, want map", value.Kind())
+	}
+}
+
+func TestUintValue(t *testing.T) {
+	if got := uintValue(42); got.Kind() != log.KindInt64 || got.AsInt64() != 42 {
+		t.Errorf("uintValue(42) = %v, want int64 42", got)
+	}
+	if got := uintValue(math.MaxUint64); got.Kind() != log.KindString || got.AsString() != "18446744073709551615" {
+		t.Errorf("uintValue(MaxUint64) = %v, want string", got)
+	}
+}
+
+func TestErrorAttrs(t *testing.T) {
+	root := errors.New("connection refused")
+	err := fmt.Errorf("query users: %w", errors.Join(root, io.EOF))
+
+	attrs := map[string]string{}
+	var causes []string
+	for _, kv := range errorAttrs("error", err) {
+		if kv.Key == "error.causes" {
+			for _, cause := range kv.Value.AsSlice() {
+				causes = append(causes, cause.AsString())
+			}
+			continue
+		}
+		attrs[kv.Key] = kv.Value.AsString()
+	}
+
+	if attrs["error.type"] != "*fmt.wrapError" {
+		t.Errorf("error.type = %q, want *fmt.wrapError", attrs["error.type"])
+	}
+	if attrs["error.message"] != err.Error() {
+		t.Errorf("error.message = %q, want %q", attrs["error.message"], err.Error())
+	}
+	want := []string{"connection refused\nEOF", "connection refused", "EOF"}
+	if fmt.Sprint(causes) != fmt.Sprint(want) {
+		t.Errorf("error.causes = %q, want %q", causes, want)
+	}
+}
+
+func TestErrorAttrs_NoCauses(t *testing.T) {
+	for _, kv := range errorAttrs("err", errors.New("boom")) {
+		if kv.Key == "err.causes" {
+			t.Errorf("errorAttrs() has %s for an error without causes", kv.Key)
+		}
+	}
+}
+
+func TestGetAttrs(t *testing.T) {
+	attrs := getAttrs(32)
+	if len(*attrs) != 0 || cap(*attrs) < 32 {
+		t.Errorf("getAttrs(32) len = %d cap = %d, want empty with room for 32", len(*attrs), cap(*attrs))
+	}
+	*attrs = append(*attrs, log.String("key", "value"))
+	putAttrs(attrs)
+
+	attrs = getAttrs(1)
+	defer putAttrs(attrs)
+	if len(*attrs) != 0 {
+		t.Errorf("getAttrs() after putAttrs len = %d, want 0", len(*attrs))
+	}
+}
@@ -2,6 +2,7 @@ package logrus
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/log"
@@ -32,8 +33,12 @@ import (
 //	log.WithFields(logrus.Fields{"key": "value"}).Info("Hello")
 type LogrusOTelHook struct {
 	logger         log.Logger
+	provider       *sdklog.LoggerProvider
 	serviceName    string
 	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[logrus.Level]log.Severity
 }
 
 // New creates a new OpenTelemetry hook for logrus.
@@ -45,15 +50,21 @@ type LogrusOTelHook struct {
 //	myLogger.AddHook(hook)
 //
 // Returns nil if loggerProvider is nil.
-func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider) *LogrusOTelHook {
+func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *LogrusOTelHook {
 	if loggerProvider == nil {
 		return nil
 	}
 
+	cfg := newConfig(opts)
+
 	return &LogrusOTelHook{
 		logger:         loggerProvider.Logger(serviceName),
+		provider:       loggerProvider,
 		serviceName:    serviceName,
 		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
 	}
 }
 
@@ -68,8 +79,19 @@ func (h *LogrusOTelHook) Fire(entry *logrus.Entry) error {
 		return nil
 	}
 
+	// Use entry's context if available, otherwise background
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+
 	// Convert logrus level to OTel severity
-	severity, severityText := h.logrusLevelToOTel(entry.Level)
+	severity, severityText := h.mapLevel(entry.Level)
+
+	// Skip building the record if the OTel pipeline would drop it
+	if severity < h.minSeverity || !h.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return nil
+	}
 
 	// Create OTel log record
 	var logRecord log.Record
@@ -78,28 +100,61 @@ func (h *LogrusOTelHook) Fire(entry *logrus.Entry) error {
 	logRecord.SetSeverity(severity)
 	logRecord.SetSeverityText(severityText)
 
-	// Add fields as attributes
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(h.attributes...)
+
+	// Add fields as attributes, collected first so the record grows only once
+	attrs := getAttrs(len(entry.Data))
+	defer putAttrs(attrs)
 	for key, value := range entry.Data {
 		// Skip trace fields as they're already set on the record
 		if key == "trace_id" || key == "span_id" {
 			continue
 		}
 
+		// Errors, e.g. from WithError, are expanded into type, message, and causes
+		if err, ok := value.(error); ok {
+			*attrs = append(*attrs, errorAttrs(key, err)...)
+			continue
+		}
+
 		// Convert value to OTel attribute
-		logRecord.AddAttributes(log.String(key, formatValue(value)))
+		*attrs = append(*attrs, log.KeyValue{Key: key, Value: toLogValue(value)})
 	}
+	logRecord.AddAttributes(*attrs...)
 
 	// Emit the log record
-	// Use entry's context if available, otherwise background
-	ctx := entry.Context
-	if ctx == nil {
-		ctx = context.TODO()
-	}
 	h.logger.Emit(ctx, logRecord)
 
+	// logrus exits or panics right after the hooks run, so flush now
+	if entry.Level <= logrus.FatalLevel {
+		h.flush()
+	}
+
 	return nil
 }
 
+// flushTimeout bounds the flush performed before a Fatal exit or Panic.
+const flushTimeout = 5 * time.Second
+
+// flush force-flushes the logger provider so batched records are exported
+// before the process exits or unwinds.
+func (h *LogrusOTelHook) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	_ = h.provider.ForceFlush(ctx)
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (h *LogrusOTelHook) mapLevel(level logrus.Level) (log.Severity, string) {
+	severity, severityText := h.logrusLevelToOTel(level)
+	if override, ok := h.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
+}
+
 // logrusLevelToOTel converts logrus.Level to log.Severity.
 func (h *LogrusOTelHook) logrusLevelToOTel(level logrus.Level) (log.Severity, string) {
 	switch level {
@@ -121,19 +176,3 @@ func (h *LogrusOTelHook) logrusLevelToOTel(level logrus.Level) (log.Severity, st
 		return log.SeverityInfo, "INFO"
 	}
 }
-
-// formatValue converts any value to a string for OTel attributes.
-func formatValue(v interface{}) string {
-	if v == nil {
-		return ""
-	}
-
-	switch val := v.(type) {
-	case string:
-		return val
-	case error:
-		return val.Error()
-	default:
-		return ""
-	}
-}
@@ -0,0 +1,245 @@
+package logrus
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// discardExporter drops every record.
+type discardExporter struct{}
+
+func (discardExporter) Export(context.Context, []sdklog.Record) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                { return nil }
+func (discardExporter) ForceFlush(context.Context) error              { return nil }
+
+// countingExporter counts exported records.
+type countingExporter struct {
+	discardExporter
+	exported int
+}
+
+func (e *countingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.exported += len(records)
+	return nil
+}
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// attrs returns the attributes of record keyed by name.
+func attrs(record sdklog.Record) map[string]log.Value {
+	out := map[string]log.Value{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		out[kv.Key] = kv.Value
+		return true
+	})
+	return out
+}
+
+func BenchmarkHook_Fire(b *testing.B) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(New("bench", "1.0.0", lp))
+
+	entry := logger.WithFields(logrus.Fields{
+		"user":     "alice",
+		"attempt":  3,
+		"latency":  1.5,
+		"success":  true,
+		"endpoint": "/api/v1/users",
+		"region":   "us-east-1",
+		"bytes":    int64(2048),
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		entry.Info("request handled")
+	}
+}
+
+func TestHook_FlushOnPanic(t *testing.T) {
+	exporter := &countingExporter{}
+	// A long interval keeps the record queued unless the hook flushes it
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(
+		sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour)),
+	))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(New("test", "1.0.0", lp))
+
+	func() {
+		defer func() { _ = recover() }()
+		logger.Panic("unrecoverable")
+	}()
+
+	if exporter.exported != 1 {
+		t.Errorf("exported %d records before the panic unwound, want 1", exporter.exported)
+	}
+}
+
+func TestHook_WithMinSeverity(t *testing.T) {
+	exporter := &countingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.AddHook(New("test", "1.0.0", lp, WithMinSeverity(log.SeverityWarn)))
+
+	logger.Debug("noise")
+	logger.Info("noise")
+	logger.Warn("kept")
+	logger.Error("kept")
+
+	if exporter.exported != 2 {
+		t.Errorf("exported %d records, want 2 (warn and error)", exporter.exported)
+	}
+}
+
+func TestHook_RawJSON(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(New("test", "1.0.0", lp))
+
+	raw := `{"id":42,"tags":["a","b"]}`
+	logger.WithField("payload", json.RawMessage(raw)).Info("received")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	payload := attrs(exporter.records[0])["payload"]
+	if payload.Kind() != log.KindString || payload.AsString() != raw {
+		t.Errorf("payload = %v (%v), want the raw JSON string %s", payload, payload.Kind(), raw)
+	}
+}
+
+func TestHook_TypedValues(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(New("test", "1.0.0", lp))
+
+	logger.WithFields(logrus.Fields{
+		"attempt": 3,
+		"latency": 1.5,
+		"success": true,
+		"elapsed": 2 * time.Second,
+		"big":     uint64(math.MaxUint64),
+	}).Info("request handled")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	got := map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		got[kv.Key] = kv.Value
+		return true
+	})
+
+	want := map[string]log.Kind{
+		"attempt": log.KindInt64,
+		"latency": log.KindFloat64,
+		"success": log.KindBool,
+		"elapsed": log.KindString,
+		"big":     log.KindString,
+	}
+	for key, kind := range want {
+		if k := got[key].Kind(); k != kind {
+			t.Errorf("%s kind = %v, want %v", key, k, kind)
+		}
+	}
+	if got["attempt"].AsInt64() != 3 || got["latency"].AsFloat64() != 1.5 || !got["success"].AsBool() {
+		t.Errorf("attempt/latency/success = %v/%v/%v, want 3/1.5/true", got["attempt"], got["latency"], got["success"])
+	}
+	if got["elapsed"].AsString() != "2s" {
+		t.Errorf("elapsed = %v, want 2s", got["elapsed"])
+	}
+	// Values above MaxInt64 are exported as strings instead of wrapping negative
+	if got["big"].AsString() != "18446744073709551615" {
+		t.Errorf("big = %v, want 18446744073709551615", got["big"])
+	}
+}
+
+// warnProcessor is a processor that is only enabled at WARN and above and
+// counts the records emitted to it, so tests can tell whether a hook built
+// a record the pipeline would drop.
+type warnProcessor struct {
+	emitted int
+}
+
+func (p *warnProcessor) OnEmit(context.Context, *sdklog.Record) error { p.emitted++; return nil }
+func (p *warnProcessor) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= log.SeverityWarn
+}
+func (p *warnProcessor) Shutdown(context.Context) error   { return nil }
+func (p *warnProcessor) ForceFlush(context.Context) error { return nil }
+
+// nopFormatter leaves fields unformatted, so only the hook reads them.
+type nopFormatter struct{}
+
+func (nopFormatter) Format(*logrus.Entry) ([]byte, error) { return nil, nil }
+
+// stringer counts how often it is formatted.
+type stringer struct {
+	calls *int
+}
+
+func (s stringer) String() string {
+	*s.calls++
+	return "formatted"
+}
+
+func TestHook_SkipsRecordsThePipelineDrops(t *testing.T) {
+	processor := &warnProcessor{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	defer lp.Shutdown(context.Background())
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetFormatter(nopFormatter{})
+	logger.AddHook(New("test", "1.0.0", lp))
+
+	calls := 0
+	logger.WithField("value", stringer{calls: &calls}).Info("dropped")
+	if processor.emitted != 0 || calls != 0 {
+		t.Errorf("INFO emitted %d records and formatted fields %d times, want 0 and 0", processor.emitted, calls)
+	}
+
+	logger.WithField("value", stringer{calls: &calls}).Warn("kept")
+	if processor.emitted != 1 || calls != 1 {
+		t.Errorf("WARN emitted %d records and formatted fields %d times, want 1 and 1", processor.emitted, calls)
+	}
+}
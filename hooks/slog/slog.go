@@ -3,6 +3,9 @@ package slog
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel/log"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
@@ -35,6 +38,29 @@ type SlogOTelHandler struct {
 	logger         log.Logger
 	serviceName    string
 	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[slog.Level]log.Severity
+	level          *otelLevel
+
+	// frames holds the attributes added with WithAttrs, one frame per group
+	// opened with WithGroup after the root frame; nil until either is called
+	frames []attrFrame
+}
+
+// attrFrame holds the converted attributes added with WithAttrs inside one
+// group. The root frame has an empty group.
+type attrFrame struct {
+	group string
+	attrs []log.KeyValue
+}
+
+// otelLevel holds the runtime-adjustable minimum level for records sent to OTel.
+// It is shared by all handlers derived through WithAttrs and WithGroup so that
+// a single SetLevel call applies to the whole logger tree.
+type otelLevel struct {
+	set   atomic.Bool
+	level slog.LevelVar
 }
 
 // New creates a new OpenTelemetry handler for slog.
@@ -49,66 +75,164 @@ type SlogOTelHandler struct {
 // handler wrapper, not your actual code. For accurate caller info, use zap or zerolog.
 //
 // Returns nil if loggerProvider is nil.
-func New(base slog.Handler, serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider) *SlogOTelHandler {
+func New(base slog.Handler, serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *SlogOTelHandler {
 	if loggerProvider == nil {
 		return nil
 	}
 
+	cfg := newConfig(opts)
+
 	return &SlogOTelHandler{
 		base:           base,
 		logger:         loggerProvider.Logger(serviceName),
 		serviceName:    serviceName,
 		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
+		level:          &otelLevel{},
+	}
+}
+
+// Level returns the minimum level currently sent to OpenTelemetry.
+// Until SetLevel is called, it reports the lowest standard level the base
+// handler enables.
+func (h *SlogOTelHandler) Level() slog.Level {
+	if h.level.set.Load() {
+		return h.level.level.Level()
+	}
+	for _, level := range []slog.Level{slog.LevelDebug, slog.LevelInfo, slog.LevelWarn} {
+		if h.base.Enabled(context.Background(), level) {
+			return level
+		}
 	}
+	return slog.LevelError
+}
+
+// SetLevel changes the minimum level sent to OpenTelemetry at runtime.
+// Until SetLevel is called, the handler sends whatever the base handler accepts.
+// It is safe to call concurrently with logging, which makes it suitable for
+// toggling debug output from an admin endpoint without rebuilding the logger.
+func (h *SlogOTelHandler) SetLevel(level slog.Level) {
+	h.level.level.Set(level)
+	h.level.set.Store(true)
 }
 
 // Enabled reports whether the handler handles records at the given level.
 func (h *SlogOTelHandler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.base.Enabled(ctx, level)
+	return h.base.Enabled(ctx, level) || h.otelEnabled(ctx, level)
 }
 
 // Handle handles the Record.
 // It sends the log to both the base handler and OTel.
 func (h *SlogOTelHandler) Handle(ctx context.Context, record slog.Record) error {
 	// First, handle with the base handler
-	if err := h.base.Handle(ctx, record); err != nil {
-		return err
+	if h.base.Enabled(ctx, record.Level) {
+		if err := h.base.Handle(ctx, record); err != nil {
+			return err
+		}
 	}
 
 	// Then send to OTel
-	if h.logger != nil {
+	if h.logger != nil && h.otelEnabled(ctx, record.Level) {
 		h.sendToOTel(ctx, record)
 	}
 
 	return nil
 }
 
+// otelEnabled reports whether records at the given level are sent to OTel.
+// It also consults the OTel logger so records the pipeline would drop are
+// skipped before any attributes are converted.
+func (h *SlogOTelHandler) otelEnabled(ctx context.Context, level slog.Level) bool {
+	if h.level.set.Load() {
+		if level < h.level.level.Level() {
+			return false
+		}
+	} else if !h.base.Enabled(ctx, level) {
+		return false
+	}
+	severity, _ := h.mapLevel(level)
+	return severity >= h.minSeverity && h.logger.Enabled(ctx, log.EnabledParameters{Severity: severity})
+}
+
 // WithAttrs returns a new Handler whose attributes consist of
 // both the receiver's attributes and the arguments.
+// The attributes are converted once here and added to every record sent to
+// OTel, inside the groups opened with WithGroup.
 func (h *SlogOTelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &SlogOTelHandler{
-		base:           h.base.WithAttrs(attrs),
-		logger:         h.logger,
-		serviceName:    h.serviceName,
-		serviceVersion: h.serviceVersion,
+	if len(attrs) == 0 {
+		return h
+	}
+
+	frames := h.cloneFrames()
+	last := &frames[len(frames)-1]
+	last.attrs = slices.Clone(last.attrs)
+	for _, attr := range attrs {
+		last.attrs = h.appendRecordAttr(last.attrs, attr)
 	}
+	return h.with(h.base.WithAttrs(attrs), frames)
 }
 
 // WithGroup returns a new Handler with the given group appended to
 // the receiver's existing groups.
+// Attributes added later, and the attributes of each record, are sent to OTel
+// nested in a map value under the group name.
 func (h *SlogOTelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	frames := append(h.cloneFrames(), attrFrame{group: name})
+	return h.with(h.base.WithGroup(name), frames)
+}
+
+// with returns a copy of the handler with the given base handler and frames.
+func (h *SlogOTelHandler) with(base slog.Handler, frames []attrFrame) *SlogOTelHandler {
 	return &SlogOTelHandler{
-		base:           h.base.WithGroup(name),
+		base:           base,
 		logger:         h.logger,
 		serviceName:    h.serviceName,
 		serviceVersion: h.serviceVersion,
+		minSeverity:    h.minSeverity,
+		attributes:     h.attributes,
+		severities:     h.severities,
+		level:          h.level,
+		frames:         frames,
+	}
+}
+
+// cloneFrames returns a copy of the handler's frames, starting with the root
+// frame. The attribute slices are shared and must be cloned before appending.
+func (h *SlogOTelHandler) cloneFrames() []attrFrame {
+	if len(h.frames) == 0 {
+		return []attrFrame{{}}
+	}
+	return slices.Clone(h.frames)
+}
+
+// nest combines the record attributes with the handler attributes, wrapping
+// each group's attributes in a map value under the group name. Empty groups
+// are omitted, following slog semantics.
+func (h *SlogOTelHandler) nest(attrs []log.KeyValue) []log.KeyValue {
+	for i := len(h.frames) - 1; i >= 0; i-- {
+		frame := h.frames[i]
+		merged := make([]log.KeyValue, 0, len(frame.attrs)+len(attrs))
+		merged = append(append(merged, frame.attrs...), attrs...)
+		if frame.group == "" {
+			return merged
+		}
+		attrs = nil
+		if len(merged) > 0 {
+			attrs = []log.KeyValue{log.Map(frame.group, merged...)}
+		}
 	}
+	return attrs
 }
 
 // sendToOTel sends the log record to OpenTelemetry.
 func (h *SlogOTelHandler) sendToOTel(ctx context.Context, record slog.Record) {
 	// Convert slog level to OTel severity
-	severity, severityText := h.slogLevelToOTel(record.Level)
+	severity, severityText := h.mapLevel(record.Level)
 
 	// Create OTel log record
 	var logRecord log.Record
@@ -117,21 +241,37 @@ func (h *SlogOTelHandler) sendToOTel(ctx context.Context, record slog.Record) {
 	logRecord.SetSeverity(severity)
 	logRecord.SetSeverityText(severityText)
 
-	// Add attributes from the slog record
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(h.attributes...)
+
+	// Add attributes from the slog record, collected first so the record grows only once
+	attrs := getAttrs(record.NumAttrs())
+	defer putAttrs(attrs)
 	record.Attrs(func(attr slog.Attr) bool {
-		// Skip trace fields as they're already set on the record
-		if attr.Key == "trace_id" || attr.Key == "span_id" {
-			return true
-		}
-		// Convert slog.Attr to OTel attribute
-		logRecord.AddAttributes(h.convertAttr(attr))
+		*attrs = h.appendRecordAttr(*attrs, attr)
 		return true
 	})
+	if len(h.frames) == 0 {
+		logRecord.AddAttributes(*attrs...)
+	} else {
+		// Carry the WithAttrs attributes and WithGroup groups into the record
+		logRecord.AddAttributes(h.nest(*attrs)...)
+	}
 
 	// Emit the log record with the context
 	h.logger.Emit(ctx, logRecord)
 }
 
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (h *SlogOTelHandler) mapLevel(level slog.Level) (log.Severity, string) {
+	severity, severityText := h.slogLevelToOTel(level)
+	if override, ok := h.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
+}
+
 // slogLevelToOTel converts slog.Level to log.Severity.
 func (h *SlogOTelHandler) slogLevelToOTel(level slog.Level) (log.Severity, string) {
 	switch {
@@ -146,24 +286,74 @@ func (h *SlogOTelHandler) slogLevelToOTel(level slog.Level) (log.Severity, strin
 	}
 }
 
+// appendRecordAttr appends a record or WithAttrs attribute to attrs, skipping
+// trace fields and inlining groups with an empty key.
+func (h *SlogOTelHandler) appendRecordAttr(attrs []log.KeyValue, attr slog.Attr) []log.KeyValue {
+	// Skip trace fields as they're already set on the record
+	if attr.Key == "trace_id" || attr.Key == "span_id" {
+		return attrs
+	}
+	// Groups with an empty key are inlined, following slog semantics
+	if attr.Key == "" && attr.Value.Kind() == slog.KindGroup {
+		for _, groupAttr := range attr.Value.Group() {
+			attrs = h.appendAttr(attrs, groupAttr)
+		}
+		return attrs
+	}
+	// Convert slog.Attr to OTel attribute
+	return h.appendAttr(attrs, attr)
+}
+
+// appendAttr appends attr to attrs as OTel attributes. Error values are
+// expanded into type, message, and causes; other values are converted with
+// convertAttr.
+func (h *SlogOTelHandler) appendAttr(attrs []log.KeyValue, attr slog.Attr) []log.KeyValue {
+	if attr.Value.Kind() == slog.KindAny {
+		if err, ok := attr.Value.Any().(error); ok {
+			return append(attrs, errorAttrs(attr.Key, err)...)
+		}
+	}
+	return append(attrs, h.convertAttr(attr))
+}
+
 // convertAttr converts a slog.Attr to an OTel log.KeyValue.
 func (h *SlogOTelHandler) convertAttr(attr slog.Attr) log.KeyValue {
-	key := attr.Key
-	value := attr.Value
+	return log.KeyValue{Key: attr.Key, Value: convertValue(attr.Value)}
+}
+
+// convertValue converts a slog.Value to a typed OTel log.Value.
+// slog.LogValuer values are resolved here, so expensive values are only
+// computed for records that are actually sent to OpenTelemetry.
+func convertValue(value slog.Value) log.Value {
+	value = value.Resolve()
 
 	switch value.Kind() {
 	case slog.KindString:
-		return log.String(key, value.String())
+		return log.StringValue(value.String())
 	case slog.KindInt64:
-		return log.Int64(key, value.Int64())
+		return log.Int64Value(value.Int64())
 	case slog.KindUint64:
-		return log.Int64(key, int64(value.Uint64()))
+		return uintValue(value.Uint64())
 	case slog.KindFloat64:
-		return log.Float64(key, value.Float64())
+		return log.Float64Value(value.Float64())
 	case slog.KindBool:
-		return log.Bool(key, value.Bool())
+		return log.BoolValue(value.Bool())
+	case slog.KindDuration:
+		return log.StringValue(value.Duration().String())
+	case slog.KindTime:
+		return log.StringValue(value.Time().Format(time.RFC3339Nano))
+	case slog.KindAny:
+		return toLogValue(value.Any())
+	case slog.KindGroup:
+		// Nested groups are exported as OTel map values
+		attrs := value.Group()
+		kvs := make([]log.KeyValue, 0, len(attrs))
+		for _, attr := range attrs {
+			kvs = append(kvs, log.KeyValue{Key: attr.Key, Value: convertValue(attr.Value)})
+		}
+		return log.MapValue(kvs...)
 	default:
 		// For complex types, convert to string
-		return log.String(key, value.String())
+		return log.StringValue(value.String())
 	}
 }
@@ -0,0 +1,373 @@
+package slog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// discardExporter drops every record.
+type discardExporter struct{}
+
+func (discardExporter) Export(context.Context, []sdklog.Record) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                { return nil }
+func (discardExporter) ForceFlush(context.Context) error              { return nil }
+
+func BenchmarkHandler_Handle(b *testing.B) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	logger := slog.New(New(slog.NewTextHandler(io.Discard, nil), "bench", "1.0.0", lp))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.InfoContext(ctx, "request handled",
+			slog.String("user", "alice"),
+			slog.Int("attempt", 3),
+			slog.Float64("latency", 1.5),
+			slog.Bool("success", true),
+			slog.String("endpoint", "/api/v1/users"),
+			slog.String("region", "us-east-1"),
+			slog.Int64("bytes", 2048),
+		)
+	}
+}
+
+// payload is an slog.LogValuer that counts how often it is resolved.
+type payload struct {
+	resolved *int
+}
+
+func (p payload) LogValue() slog.Value {
+	*p.resolved++
+	return slog.GroupValue(slog.String("id", "42"))
+}
+
+func TestConvertValue_ResolvesLogValuer(t *testing.T) {
+	resolved := 0
+	value := convertValue(slog.AnyValue(payload{resolved: &resolved}))
+
+	if resolved != 1 {
+		t.Errorf("LogValue() called %d times, want 1", resolved)
+	}
+	kvs := value.AsMap()
+	if len(kvs) != 1 || kvs[0].Key != "id" || kvs[0].Value.AsString() != "42" {
+		t.Errorf("convertValue() = %v, want map with id=42", value)
+	}
+}
+
+func TestHandler_DisabledLevelSkipsLogValuer(t *testing.T) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	handler := New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}), "test", "1.0.0", lp)
+	handler.SetLevel(slog.LevelError)
+
+	resolved := 0
+	slog.New(handler).Info("not exported", slog.Any("payload", payload{resolved: &resolved}))
+
+	if resolved != 0 {
+		t.Errorf("LogValue() called %d times for a disabled level, want 0", resolved)
+	}
+}
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func TestHandler_WithAttrsAndGroups(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := slog.New(New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)).
+		With("service", "api").
+		WithGroup("request").
+		With("method", "GET")
+	logger.Info("handled", "status", 200)
+	logger.WithGroup("empty").Info("no attrs")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("exported %d records, want 2", len(exporter.records))
+	}
+
+	attrs := map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	if got := attrs["service"].AsString(); got != "api" {
+		t.Errorf("service = %q, want api", got)
+	}
+	request := map[string]log.Value{}
+	for _, kv := range attrs["request"].AsMap() {
+		request[kv.Key] = kv.Value
+	}
+	if request["method"].AsString() != "GET" || request["status"].AsInt64() != 200 {
+		t.Errorf("request = %v, want method=GET and status=200", attrs["request"])
+	}
+
+	// Groups without attributes are omitted
+	var keys []string
+	exporter.records[1].WalkAttributes(func(kv log.KeyValue) bool {
+		keys = append(keys, kv.Key)
+		return true
+	})
+	if fmt.Sprint(keys) != "[service request]" {
+		t.Errorf("attribute keys = %v, want [service request]", keys)
+	}
+}
+
+func TestHandler_AnyNestedValues(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	type address struct {
+		City string `json:"city"`
+	}
+	resolved := 0
+	slog.New(New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)).Info("signup",
+		slog.Any("user", map[string]any{"name": "alice", "roles": []string{"admin"}}),
+		slog.Any("address", address{City: "Berlin"}),
+		slog.Any("items", []any{payload{resolved: &resolved}}),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	attrs := map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	// Maps and structs are nested OTel maps, as in the logrus and zap hooks
+	user := attrs["user"]
+	if user.Kind() != log.KindMap || len(user.AsMap()) != 2 || user.AsMap()[0].Value.AsString() != "alice" {
+		t.Errorf("user = %v, want map with name=alice and roles", user)
+	}
+	if address := attrs["address"]; address.Kind() != log.KindMap || address.AsMap()[0].Key != "city" {
+		t.Errorf("address = %v, want map with city", address)
+	}
+
+	// LogValuers nested in slices are resolved
+	items := attrs["items"].AsSlice()
+	if len(items) != 1 || items[0].Kind() != log.KindMap || resolved != 1 {
+		t.Errorf("items = %v (resolved %d times), want [map[id:42]] resolved once", attrs["items"], resolved)
+	}
+}
+
+func TestHandler_SetLevel(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	handler := New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)
+	logger := slog.New(handler)
+
+	handler.SetLevel(slog.LevelWarn)
+	logger.Info("info dropped")
+	logger.Warn("warn kept")
+
+	if len(exporter.records) != 1 || exporter.records[0].Body().AsString() != "warn kept" {
+		t.Fatalf("exported %d records at LevelWarn, want only %q", len(exporter.records), "warn kept")
+	}
+
+	// Lowering the level also exports records the base handler skips
+	handler.SetLevel(slog.LevelDebug)
+	logger.Debug("debug kept")
+
+	if len(exporter.records) != 2 || exporter.records[1].Body().AsString() != "debug kept" {
+		t.Errorf("exported %d records after lowering to LevelDebug, want %q last", len(exporter.records), "debug kept")
+	}
+}
+
+func TestHandler_Level(t *testing.T) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	handler := New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}), "test", "1.0.0", lp)
+
+	// Until SetLevel, the level follows the base handler
+	if got := handler.Level(); got != slog.LevelWarn {
+		t.Errorf("Level() = %v, want WARN from the base handler", got)
+	}
+	if handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("Enabled(INFO) = true below the base handler level, want false")
+	}
+
+	handler.SetLevel(slog.LevelDebug)
+	if got := handler.Level(); got != slog.LevelDebug {
+		t.Errorf("Level() = %v after SetLevel, want DEBUG", got)
+	}
+	if !handler.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Enabled(DEBUG) = false after SetLevel(DEBUG), want true")
+	}
+}
+
+func TestHandler_RawJSON(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	raw := `{"id":42,"tags":["a","b"]}`
+	slog.New(New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)).
+		Info("received", slog.Any("payload", json.RawMessage(raw)))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	var payload log.Value
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == "payload" {
+			payload = kv.Value
+		}
+		return true
+	})
+	if payload.Kind() != log.KindString || payload.AsString() != raw {
+		t.Errorf("payload = %v (%v), want the raw JSON string %s", payload, payload.Kind(), raw)
+	}
+}
+
+func TestHandler_TypedValues(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	slog.New(New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)).Info("request handled",
+		slog.Int("attempt", 3),
+		slog.Float64("latency", 1.5),
+		slog.Bool("success", true),
+		slog.Duration("elapsed", 2*time.Second),
+		slog.Uint64("big", math.MaxUint64),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	got := map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		got[kv.Key] = kv.Value
+		return true
+	})
+
+	want := map[string]log.Kind{
+		"attempt": log.KindInt64,
+		"latency": log.KindFloat64,
+		"success": log.KindBool,
+		"elapsed": log.KindString,
+		"big":     log.KindString,
+	}
+	for key, kind := range want {
+		if k := got[key].Kind(); k != kind {
+			t.Errorf("%s kind = %v, want %v", key, k, kind)
+		}
+	}
+	if got["attempt"].AsInt64() != 3 || got["latency"].AsFloat64() != 1.5 || !got["success"].AsBool() {
+		t.Errorf("attempt/latency/success = %v/%v/%v, want 3/1.5/true", got["attempt"], got["latency"], got["success"])
+	}
+	if got["elapsed"].AsString() != "2s" {
+		t.Errorf("elapsed = %v, want 2s", got["elapsed"])
+	}
+	// Values above MaxInt64 are exported as strings instead of wrapping negative
+	if got["big"].AsString() != "18446744073709551615" {
+		t.Errorf("big = %v, want 18446744073709551615", got["big"])
+	}
+}
+
+func TestHandler_GroupAttr(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	slog.New(New(slog.NewTextHandler(io.Discard, nil), "test", "1.0.0", lp)).Info("handled",
+		slog.Group("http",
+			slog.String("method", "GET"),
+			slog.Int("status", 200),
+			slog.Group("client", slog.String("ip", "10.0.0.1")),
+		),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	var group log.Value
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == "http" {
+			group = kv.Value
+		}
+		return true
+	})
+	if group.Kind() != log.KindMap {
+		t.Fatalf("http kind = %v, want map", group.Kind())
+	}
+
+	fields := map[string]log.Value{}
+	for _, kv := range group.AsMap() {
+		fields[kv.Key] = kv.Value
+	}
+	if fields["method"].AsString() != "GET" || fields["status"].AsInt64() != 200 {
+		t.Errorf("http = %v, want method=GET and status=200", group)
+	}
+	client := fields["client"]
+	if client.Kind() != log.KindMap || len(client.AsMap()) != 1 || client.AsMap()[0].Value.AsString() != "10.0.0.1" {
+		t.Errorf("http.client = %v, want nested map with ip=10.0.0.1", client)
+	}
+}
+
+// warnProcessor is a processor that is only enabled at WARN and above and
+// counts the records emitted to it, so tests can tell whether a hook built
+// a record the pipeline would drop.
+type warnProcessor struct {
+	emitted int
+}
+
+func (p *warnProcessor) OnEmit(context.Context, *sdklog.Record) error { p.emitted++; return nil }
+func (p *warnProcessor) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= log.SeverityWarn
+}
+func (p *warnProcessor) Shutdown(context.Context) error   { return nil }
+func (p *warnProcessor) ForceFlush(context.Context) error { return nil }
+
+func TestHandler_SkipsRecordsThePipelineDrops(t *testing.T) {
+	processor := &warnProcessor{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	defer lp.Shutdown(context.Background())
+
+	// The base handler drops everything, so only the OTel path could resolve values
+	handler := New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.Level(100)}), "test", "1.0.0", lp)
+	handler.SetLevel(slog.LevelDebug)
+	logger := slog.New(handler)
+
+	resolved := 0
+	logger.Info("dropped", slog.Any("payload", payload{resolved: &resolved}))
+	if processor.emitted != 0 || resolved != 0 {
+		t.Errorf("INFO emitted %d records and resolved values %d times, want 0 and 0", processor.emitted, resolved)
+	}
+
+	logger.Warn("kept", slog.Any("payload", payload{resolved: &resolved}))
+	if processor.emitted != 1 || resolved != 1 {
+		t.Errorf("WARN emitted %d records and resolved values %d times, want 1 and 1", processor.emitted, resolved)
+	}
+}
@@ -0,0 +1,178 @@
+package stdlog
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// StdlogOTelWriter is an io.Writer that sends standard library log output to
+// OpenTelemetry. It wraps another writer and forwards output to both the
+// wrapped writer and OTel, so legacy log.Printf calls and components that only
+// accept a *log.Logger (such as http.Server.ErrorLog) end up in OTel.
+//
+// Example usage:
+//
+//	// Create telemetry for OTel
+//	t, _ := telemetry.New(ctx, &telemetry.Options{
+//	    ServiceName: "my-service",
+//	})
+//
+//	// Route the standard logger through the OTel writer
+//	w := stdloghook.New(os.Stderr, "my-service", "v1.0.0", t.LoggerProvider())
+//	log.SetOutput(w)
+//
+//	// Or hand a dedicated logger to components that need one
+//	srv := &http.Server{ErrorLog: log.New(w, "", log.LstdFlags)}
+//
+//	// Use logger as normal - logs go to both stderr and OTel
+//	log.Printf("[WARN] cache miss for %s", key)
+type StdlogOTelWriter struct {
+	base           io.Writer
+	logger         log.Logger
+	serviceName    string
+	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+}
+
+// New creates a new OpenTelemetry writer for the standard library logger.
+// This is the recommended way to add OTel integration to an existing *log.Logger.
+//
+// It wraps the provided base writer and also sends each log line to OTel:
+//
+//	w := New(os.Stderr, "my-service", "v1.0.0", loggerProvider)
+//	logger := log.New(w, "", log.LstdFlags)
+//
+// The base writer may be nil, in which case logs are only sent to OTel.
+// The date, time, and file prefixes added by the log flags are stripped from
+// the OTel body, with the file and line exported as code.file.path and
+// code.line.number, and the severity is inferred from the message (see inferSeverity).
+//
+// Returns nil if loggerProvider is nil.
+func New(base io.Writer, serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *StdlogOTelWriter {
+	if loggerProvider == nil {
+		return nil
+	}
+
+	cfg := newConfig(opts)
+
+	return &StdlogOTelWriter{
+		base:           base,
+		logger:         loggerProvider.Logger(serviceName),
+		serviceName:    serviceName,
+		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+	}
+}
+
+// Write implements io.Writer. The standard library logger calls Write once
+// per log entry, so each call becomes a single OTel log record.
+func (w *StdlogOTelWriter) Write(p []byte) (int, error) {
+	if w.base != nil {
+		if n, err := w.base.Write(p); err != nil {
+			return n, err
+		}
+	}
+
+	w.sendToOTel(string(p))
+
+	return len(p), nil
+}
+
+// timestampPrefix matches the date and time prefixes produced by log.Ldate,
+// log.Ltime, and log.Lmicroseconds.
+var timestampPrefix = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d{6})? )?`)
+
+// filePrefix matches the "file.go:23: " prefix produced by log.Lshortfile and
+// log.Llongfile.
+var filePrefix = regexp.MustCompile(`^(\S+\.go):(\d+): `)
+
+// levelToken matches a leading level marker: a bracketed word ("[ERROR]") or
+// a bare word followed by a colon, whitespace, or the end of the message.
+var levelToken = regexp.MustCompile(`^\s*(?:\[\s*(\w+)\s*\]|(\w+)(?::|\s|$))`)
+
+// sendToOTel sends the log line to OpenTelemetry.
+func (w *StdlogOTelWriter) sendToOTel(line string) {
+	msg := strings.TrimRight(line, "\n")
+	msg = timestampPrefix.ReplaceAllString(msg, "")
+
+	// The file prefix is exported as attributes so the body starts with the
+	// message itself
+	var file, lineNo string
+	if m := filePrefix.FindStringSubmatch(msg); m != nil {
+		file, lineNo = m[1], m[2]
+		msg = msg[len(m[0]):]
+	}
+
+	severity, severityText := inferSeverity(msg)
+
+	ctx := context.TODO()
+
+	// Skip building the record if the OTel pipeline would drop it
+	if severity < w.minSeverity || !w.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	// Create OTel log record
+	var logRecord log.Record
+	logRecord.SetTimestamp(time.Now())
+	logRecord.SetBody(log.StringValue(msg))
+	logRecord.SetSeverity(severity)
+	logRecord.SetSeverityText(severityText)
+
+	// Add the static attributes from WithAttributes
+	logRecord.AddAttributes(w.attributes...)
+
+	if file != "" {
+		logRecord.AddAttributes(log.String("code.file.path", file))
+		if n, err := strconv.Atoi(lineNo); err == nil {
+			logRecord.AddAttributes(log.Int("code.line.number", n))
+		}
+	}
+
+	// Emit the log record
+	w.logger.Emit(ctx, logRecord)
+}
+
+// inferSeverity infers the severity of a standard library log line.
+// It recognizes a whole level word at the start of the message, such as
+// "[ERROR]", "WARN:", or "debug ...", so words like "errand" or
+// "information" are not mistaken for levels, and treats net/http server
+// errors ("http: ...") as errors. Anything else is logged as INFO.
+func inferSeverity(msg string) (log.Severity, string) {
+	if strings.HasPrefix(msg, "http: ") {
+		return log.SeverityError, "ERROR"
+	}
+
+	m := levelToken.FindStringSubmatch(msg)
+	if m == nil {
+		return log.SeverityInfo, "INFO"
+	}
+	token := m[1]
+	if token == "" {
+		token = m[2]
+	}
+
+	switch strings.ToLower(token) {
+	case "trace":
+		return log.SeverityTrace, "TRACE"
+	case "debug":
+		return log.SeverityDebug, "DEBUG"
+	case "warn", "warning":
+		return log.SeverityWarn, "WARN"
+	case "err", "error":
+		return log.SeverityError, "ERROR"
+	case "fatal", "panic":
+		return log.SeverityFatal, "FATAL"
+	default:
+		return log.SeverityInfo, "INFO"
+	}
+}
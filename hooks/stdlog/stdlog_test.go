@@ -0,0 +1,130 @@
+package stdlog
+
+import (
+	"bytes"
+	"context"
+	stdlog "log"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func TestInferSeverity(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want log.Severity
+	}{
+		{"[ERROR] connection refused", log.SeverityError},
+		{"error: connection refused", log.SeverityError},
+		{"ERROR connection refused", log.SeverityError},
+		{"[ warn ] disk almost full", log.SeverityWarn},
+		{"WARNING: disk almost full", log.SeverityWarn},
+		{"debug cache hit", log.SeverityDebug},
+		{"[TRACE] entering handler", log.SeverityTrace},
+		{"panic: nil map", log.SeverityFatal},
+		{"http: TLS handshake error", log.SeverityError},
+		{"errand finished", log.SeverityInfo},
+		{"information retrieved", log.SeverityInfo},
+		{"debugger attached", log.SeverityInfo},
+		{"warned the user", log.SeverityInfo},
+		{"server started", log.SeverityInfo},
+		{"", log.SeverityInfo},
+	}
+
+	for _, tt := range tests {
+		if got, _ := inferSeverity(tt.msg); got != tt.want {
+			t.Errorf("inferSeverity(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestWriter_StripsFlagPrefixes(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags int
+	}{
+		{"shortfile", stdlog.LstdFlags | stdlog.Lshortfile},
+		{"longfile", stdlog.Ldate | stdlog.Lmicroseconds | stdlog.Llongfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &recordingExporter{}
+			lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+			defer lp.Shutdown(context.Background())
+
+			var buf bytes.Buffer
+			logger := stdlog.New(New(&buf, "test", "1.0.0", lp), "", tt.flags)
+			logger.Print("[ERROR] connection refused")
+
+			if buf.Len() == 0 {
+				t.Error("base writer received nothing")
+			}
+			if len(exporter.records) != 1 {
+				t.Fatalf("exported %d records, want 1", len(exporter.records))
+			}
+			record := exporter.records[0]
+			if got := record.Body().AsString(); got != "[ERROR] connection refused" {
+				t.Errorf("Body() = %q, want the message without flag prefixes", got)
+			}
+			if got := record.Severity(); got != log.SeverityError {
+				t.Errorf("Severity() = %v, want %v", got, log.SeverityError)
+			}
+
+			attrs := map[string]log.Value{}
+			record.WalkAttributes(func(kv log.KeyValue) bool {
+				attrs[kv.Key] = kv.Value
+				return true
+			})
+			if got := attrs["code.file.path"].AsString(); got == "" {
+				t.Error("code.file.path is empty, want the caller file")
+			}
+			if got := attrs["code.line.number"].AsInt64(); got == 0 {
+				t.Error("code.line.number is 0, want the caller line")
+			}
+		})
+	}
+}
+
+func TestWriter_WithMinSeverity(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := stdlog.New(New(nil, "test", "1.0.0", lp,
+		WithMinSeverity(log.SeverityWarn),
+		WithAttributes(log.String("env", "prod")),
+	), "", stdlog.LstdFlags)
+
+	logger.Print("errand finished")
+	logger.Print("[WARN] disk almost full")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	var env string
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == "env" {
+			env = kv.Value.AsString()
+		}
+		return true
+	})
+	if env != "prod" {
+		t.Errorf("attribute env = %q, want prod", env)
+	}
+}
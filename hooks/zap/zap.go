@@ -2,9 +2,11 @@ package zap
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/log"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
+	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -34,9 +36,17 @@ import (
 //	logger.Info("Hello", zap.String("key", "value"))
 type ZapOTelCore struct {
 	logger         log.Logger
+	provider       *sdklog.LoggerProvider
 	serviceName    string
 	serviceVersion string
-	level          zapcore.Level
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[zapcore.Level]log.Severity
+	level          uberzap.AtomicLevel
+
+	// fields and ctx hold structured context added via With
+	fields []zapcore.Field
+	ctx    context.Context
 }
 
 // New creates a new OpenTelemetry core for zap.
@@ -49,29 +59,76 @@ type ZapOTelCore struct {
 //	logger := zap.New(combinedCore)
 //
 // Returns nil if loggerProvider is nil.
-func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider) zapcore.Core {
+func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) zapcore.Core {
 	if loggerProvider == nil {
 		return nil
 	}
 
+	cfg := newConfig(opts)
+
 	return &ZapOTelCore{
 		logger:         loggerProvider.Logger(serviceName),
+		provider:       loggerProvider,
 		serviceName:    serviceName,
 		serviceVersion: serviceVersion,
-		level:          zapcore.DebugLevel, // Log everything, let OTel decide
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
+		level:          uberzap.NewAtomicLevelAt(zapcore.DebugLevel), // Log everything, let OTel decide
 	}
 }
 
 // Enabled returns whether the given level is enabled.
+// It also consults the OTel logger so entries the pipeline would drop are
+// rejected in Check, before zap encodes any fields for this core.
 func (c *ZapOTelCore) Enabled(level zapcore.Level) bool {
-	return level >= c.level
+	if !c.level.Enabled(level) {
+		return false
+	}
+	severity, _ := c.mapLevel(level)
+	return severity >= c.minSeverity && c.logger.Enabled(context.Background(), log.EnabledParameters{Severity: severity})
+}
+
+// Level returns the minimum level currently sent to OpenTelemetry.
+// It also lets zapcore.LevelOf report the core's level accurately.
+func (c *ZapOTelCore) Level() zapcore.Level {
+	return c.level.Level()
+}
+
+// SetLevel changes the minimum level sent to OpenTelemetry at runtime.
+// It is safe to call concurrently with logging, which makes it suitable for
+// toggling debug output from an admin endpoint without rebuilding the logger.
+func (c *ZapOTelCore) SetLevel(level zapcore.Level) {
+	c.level.SetLevel(level)
+}
+
+// Context returns a field that carries ctx to the OTel core so that log records
+// are correlated with the active span. Other cores ignore the field, so it is
+// safe to use with zapcore.NewTee:
+//
+//	logger.Info("Processing", zaphook.Context(ctx))
+//	reqLogger := logger.With(zaphook.Context(ctx))
+func Context(ctx context.Context) zapcore.Field {
+	return zapcore.Field{Key: "context", Type: zapcore.SkipType, Interface: ctx}
 }
 
 // With adds structured context to the Core.
+// A context added with Context is kept and used for every record written by
+// the returned core, unless a log call supplies its own.
 func (c *ZapOTelCore) With(fields []zapcore.Field) zapcore.Core {
-	// For simplicity, return the same core
-	// In a production implementation, you might want to store fields
-	return c
+	clone := *c
+	clone.fields = make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	clone.fields = append(clone.fields, c.fields...)
+
+	for _, field := range fields {
+		if ctx, ok := contextFromField(field); ok {
+			clone.ctx = ctx
+			continue
+		}
+		clone.fields = append(clone.fields, field)
+	}
+
+	return &clone
 }
 
 // Check determines whether the supplied Entry should be logged.
@@ -86,7 +143,7 @@ func (c *ZapOTelCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapc
 // writes them to OpenTelemetry.
 func (c *ZapOTelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 	// Convert zap level to OTel severity
-	severity, severityText := c.zapLevelToOTel(entry.Level)
+	severity, severityText := c.mapLevel(entry.Level)
 
 	// Create OTel log record
 	var logRecord log.Record
@@ -95,9 +152,16 @@ func (c *ZapOTelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 	logRecord.SetSeverity(severity)
 	logRecord.SetSeverityText(severityText)
 
+	// Static attributes go first so fields from the log call override them
+	logRecord.AddAttributes(c.attributes...)
+
+	// Collect attributes first so the record grows only once
+	attrs := getAttrs(len(c.fields) + len(fields) + 4)
+	defer putAttrs(attrs)
+
 	// Add caller information if available
 	if entry.Caller.Defined {
-		logRecord.AddAttributes(
+		*attrs = append(*attrs,
 			log.String("caller", entry.Caller.String()),
 			log.String("function", entry.Caller.Function),
 		)
@@ -105,25 +169,43 @@ func (c *ZapOTelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 
 	// Add logger name
 	if entry.LoggerName != "" {
-		logRecord.AddAttributes(log.String("logger", entry.LoggerName))
+		*attrs = append(*attrs, log.String("logger", entry.LoggerName))
 	}
 
 	// Add stack trace if present
 	if entry.Stack != "" {
-		logRecord.AddAttributes(log.String("stacktrace", entry.Stack))
+		*attrs = append(*attrs, log.String("stacktrace", entry.Stack))
 	}
 
 	// Convert fields to attributes and look for trace context
 	enc := zapcore.NewMapObjectEncoder()
-	var ctx context.Context
+	ctx := c.ctx
+
+	// Once a zap.Namespace is open, every later field belongs inside it, so
+	// errors are left to the encoder instead of being expanded at the top level
+	namespaced := false
+
+	for _, field := range c.fields {
+		if err, ok := errorFromField(field); ok && !namespaced {
+			*attrs = append(*attrs, errorAttrs(field.Key, err)...)
+			continue
+		}
+		namespaced = namespaced || field.Type == zapcore.NamespaceType
+		field.AddTo(enc)
+	}
 
 	for _, field := range fields {
-		// Check for context field (zap doesn't support context natively, but user might add it via WithContext)
-		if field.Key == "context" {
-			if val, ok := field.Interface.(context.Context); ok {
-				ctx = val
-			}
+		// Check for context field (zap doesn't support context natively, but user might add it via Context)
+		if val, ok := contextFromField(field); ok {
+			ctx = val
+			continue
+		}
+		// Errors are expanded into type, message, and causes
+		if err, ok := errorFromField(field); ok && !namespaced {
+			*attrs = append(*attrs, errorAttrs(field.Key, err)...)
+			continue
 		}
+		namespaced = namespaced || field.Type == zapcore.NamespaceType
 		field.AddTo(enc)
 	}
 
@@ -137,20 +219,62 @@ func (c *ZapOTelCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
 		if key == "context" {
 			continue
 		}
-		logRecord.AddAttributes(log.String(key, formatValue(value)))
+		*attrs = append(*attrs, log.KeyValue{Key: key, Value: toLogValue(value)})
 	}
+	logRecord.AddAttributes(*attrs...)
 
 	// Emit the log record
-	// Note: We use context.TODO() here because zap doesn't pass context to Write()
-	// The trace context is already extracted and set on the logRecord above
+	// The SDK extracts the trace context from ctx; when no context field was
+	// supplied we fall back to context.TODO() since zap doesn't pass one to Write()
 	c.logger.Emit(ctx, logRecord)
 
+	// DPanic, Panic, and Fatal may end the process right after the write, so
+	// flush now like zap's own cores sync on those levels
+	if entry.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+
 	return nil
 }
 
-// Sync flushes buffered logs.
+// contextFromField returns the context carried by a "context" field, as added
+// by Context or zap.Any("context", ctx).
+func contextFromField(field zapcore.Field) (context.Context, bool) {
+	if field.Key != "context" {
+		return nil, false
+	}
+	ctx, ok := field.Interface.(context.Context)
+	return ctx, ok
+}
+
+// errorFromField returns the error carried by a zap.Error or zap.NamedError field.
+func errorFromField(field zapcore.Field) (error, bool) {
+	if field.Type != zapcore.ErrorType {
+		return nil, false
+	}
+	err, ok := field.Interface.(error)
+	return err, ok
+}
+
+// flushTimeout bounds the flush performed before a Fatal exit or Panic.
+const flushTimeout = 5 * time.Second
+
+// Sync flushes buffered logs by force-flushing the logger provider, bounded
+// by flushTimeout.
 func (c *ZapOTelCore) Sync() error {
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	return c.provider.ForceFlush(ctx)
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (c *ZapOTelCore) mapLevel(level zapcore.Level) (log.Severity, string) {
+	severity, severityText := c.zapLevelToOTel(level)
+	if override, ok := c.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
 }
 
 // zapLevelToOTel converts zapcore.Level to log.Severity.
@@ -172,19 +296,3 @@ func (c *ZapOTelCore) zapLevelToOTel(level zapcore.Level) (log.Severity, string)
 		return log.SeverityInfo, "INFO"
 	}
 }
-
-// formatValue converts any value to a string for OTel attributes.
-func formatValue(v interface{}) string {
-	if v == nil {
-		return ""
-	}
-
-	switch val := v.(type) {
-	case string:
-		return val
-	case error:
-		return val.Error()
-	default:
-		return ""
-	}
-}
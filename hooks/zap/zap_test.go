@@ -0,0 +1,360 @@
+package zap
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// discardExporter drops every record.
+type discardExporter struct{}
+
+func (discardExporter) Export(context.Context, []sdklog.Record) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                { return nil }
+func (discardExporter) ForceFlush(context.Context) error              { return nil }
+
+func BenchmarkCore_Write(b *testing.B) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	logger := uberzap.New(New("bench", "1.0.0", lp)).With(uberzap.String("region", "us-east-1"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request handled",
+			uberzap.String("user", "alice"),
+			uberzap.Int("attempt", 3),
+			uberzap.Float64("latency", 1.5),
+			uberzap.Bool("success", true),
+			uberzap.String("endpoint", "/api/v1/users"),
+			uberzap.Int64("bytes", 2048),
+		)
+	}
+}
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+// user is a zapcore.ObjectMarshaler.
+type user struct {
+	name string
+	age  int
+}
+
+func (u user) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.name)
+	enc.AddInt("age", u.age)
+	return nil
+}
+
+func TestCore_WriteNestedFields(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	logger := uberzap.New(New("test", "1.0.0", lp))
+	logger.Info("signup",
+		uberzap.Object("user", user{name: "alice", age: 30}),
+		uberzap.Namespace("request"),
+		uberzap.String("method", "POST"),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	attrs := map[string]map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Value.Kind() != log.KindMap {
+			t.Errorf("attribute %q kind = %v, want map", kv.Key, kv.Value.Kind())
+			return true
+		}
+		attrs[kv.Key] = map[string]log.Value{}
+		for _, nested := range kv.Value.AsMap() {
+			attrs[kv.Key][nested.Key] = nested.Value
+		}
+		return true
+	})
+
+	if attrs["user"]["name"].AsString() != "alice" || attrs["user"]["age"].AsInt64() != 30 {
+		t.Errorf("user = %v, want name=alice and age=30", attrs["user"])
+	}
+	if attrs["request"]["method"].AsString() != "POST" {
+		t.Errorf("request = %v, want method=POST", attrs["request"])
+	}
+}
+
+func TestCore_WithAttributes(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	core := New("test", "1.0.0", lp, WithAttributes(log.String("env", "prod"), log.String("region", "us-east-1")))
+	uberzap.New(core).Info("request", uberzap.String("region", "eu-west-1"))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	attrs := map[string]string{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.AsString()
+		return true
+	})
+	if attrs["env"] != "prod" {
+		t.Errorf("env = %q, want prod", attrs["env"])
+	}
+	if attrs["region"] != "eu-west-1" {
+		t.Errorf("region = %q, want the log call's eu-west-1", attrs["region"])
+	}
+}
+
+func TestCore_SetLevel(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	core := New("test", "1.0.0", lp).(*ZapOTelCore)
+	logger := uberzap.New(core)
+
+	core.SetLevel(zapcore.WarnLevel)
+	logger.Debug("debug dropped")
+	logger.Info("info dropped")
+	logger.Warn("warn kept")
+
+	if len(exporter.records) != 1 || exporter.records[0].Body().AsString() != "warn kept" {
+		t.Fatalf("exported %d records at WarnLevel, want only %q", len(exporter.records), "warn kept")
+	}
+
+	core.SetLevel(zapcore.DebugLevel)
+	logger.Debug("debug kept")
+
+	if len(exporter.records) != 2 || exporter.records[1].Body().AsString() != "debug kept" {
+		t.Errorf("exported %d records after lowering to DebugLevel, want %q last", len(exporter.records), "debug kept")
+	}
+}
+
+func TestCore_EnabledBoundaries(t *testing.T) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(discardExporter{})))
+	defer lp.Shutdown(context.Background())
+
+	core := New("test", "1.0.0", lp).(*ZapOTelCore)
+	if got := zapcore.LevelOf(core); got != zapcore.DebugLevel {
+		t.Errorf("LevelOf() = %v, want debug by default", got)
+	}
+
+	core.SetLevel(zapcore.InfoLevel)
+	if got := zapcore.LevelOf(core); got != zapcore.InfoLevel {
+		t.Errorf("LevelOf() = %v, want info", got)
+	}
+	for level, want := range map[zapcore.Level]bool{
+		zapcore.DebugLevel: false,
+		zapcore.InfoLevel:  true,
+		zapcore.WarnLevel:  true,
+		zapcore.FatalLevel: true,
+	} {
+		if got := core.Enabled(level); got != want {
+			t.Errorf("Enabled(%v) = %v, want %v", level, got, want)
+		}
+	}
+
+	// WithMinSeverity raises the boundary without changing the reported level
+	minCore := New("test", "1.0.0", lp, WithMinSeverity(log.SeverityWarn))
+	if minCore.Enabled(zapcore.InfoLevel) {
+		t.Error("Enabled(info) = true below WithMinSeverity(warn), want false")
+	}
+	if !minCore.Enabled(zapcore.WarnLevel) {
+		t.Error("Enabled(warn) = false at WithMinSeverity(warn), want true")
+	}
+	if got := zapcore.LevelOf(minCore); got != zapcore.DebugLevel {
+		t.Errorf("LevelOf() = %v with WithMinSeverity, want debug", got)
+	}
+}
+
+func TestCore_RawJSON(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	raw := `{"id":42,"tags":["a","b"]}`
+	uberzap.New(New("test", "1.0.0", lp)).Info("received", uberzap.Any("payload", json.RawMessage(raw)))
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	var payload log.Value
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == "payload" {
+			payload = kv.Value
+		}
+		return true
+	})
+	if payload.Kind() != log.KindString || payload.AsString() != raw {
+		t.Errorf("payload = %v (%v), want the raw JSON string %s", payload, payload.Kind(), raw)
+	}
+}
+
+func TestCore_WithFieldsAndContext(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02},
+		SpanID:     trace.SpanID{0x03, 0x04},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	reqLogger := uberzap.New(New("test", "1.0.0", lp)).With(
+		uberzap.String("request_id", "req-1"),
+		Context(ctx),
+	)
+	reqLogger.Info("first")
+	reqLogger.With(uberzap.Int("attempt", 2)).Info("second")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("exported %d records, want 2", len(exporter.records))
+	}
+	for _, record := range exporter.records {
+		if record.TraceID() != sc.TraceID() || record.SpanID() != sc.SpanID() {
+			t.Errorf("%q trace/span = %s/%s, want %s/%s", record.Body().AsString(),
+				record.TraceID(), record.SpanID(), sc.TraceID(), sc.SpanID())
+		}
+		attrs := map[string]log.Value{}
+		record.WalkAttributes(func(kv log.KeyValue) bool {
+			attrs[kv.Key] = kv.Value
+			return true
+		})
+		if got := attrs["request_id"].AsString(); got != "req-1" {
+			t.Errorf("%q request_id = %q, want req-1", record.Body().AsString(), got)
+		}
+		if _, ok := attrs["context"]; ok {
+			t.Errorf("%q exported the context field as an attribute", record.Body().AsString())
+		}
+	}
+	if got := exporter.records[1].Body().AsString(); got != "second" {
+		t.Fatalf("second record body = %q, want second", got)
+	}
+	var attempt log.Value
+	exporter.records[1].WalkAttributes(func(kv log.KeyValue) bool {
+		if kv.Key == "attempt" {
+			attempt = kv.Value
+		}
+		return true
+	})
+	if attempt.AsInt64() != 2 {
+		t.Errorf("attempt = %v, want 2 from the nested With", attempt)
+	}
+}
+
+func TestCore_TypedValues(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	uberzap.New(New("test", "1.0.0", lp)).Info("request handled",
+		uberzap.Int("attempt", 3),
+		uberzap.Float64("latency", 1.5),
+		uberzap.Bool("success", true),
+		uberzap.Duration("elapsed", 2*time.Second),
+		uberzap.Uint64("big", math.MaxUint64),
+	)
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	got := map[string]log.Value{}
+	exporter.records[0].WalkAttributes(func(kv log.KeyValue) bool {
+		got[kv.Key] = kv.Value
+		return true
+	})
+
+	want := map[string]log.Kind{
+		"attempt": log.KindInt64,
+		"latency": log.KindFloat64,
+		"success": log.KindBool,
+		"elapsed": log.KindString,
+		"big":     log.KindString,
+	}
+	for key, kind := range want {
+		if k := got[key].Kind(); k != kind {
+			t.Errorf("%s kind = %v, want %v", key, k, kind)
+		}
+	}
+	if got["attempt"].AsInt64() != 3 || got["latency"].AsFloat64() != 1.5 || !got["success"].AsBool() {
+		t.Errorf("attempt/latency/success = %v/%v/%v, want 3/1.5/true", got["attempt"], got["latency"], got["success"])
+	}
+	if got["elapsed"].AsString() != "2s" {
+		t.Errorf("elapsed = %v, want 2s", got["elapsed"])
+	}
+	// Values above MaxInt64 are exported as strings instead of wrapping negative
+	if got["big"].AsString() != "18446744073709551615" {
+		t.Errorf("big = %v, want 18446744073709551615", got["big"])
+	}
+}
+
+// warnProcessor is a processor that is only enabled at WARN and above and
+// counts the records emitted to it, so tests can tell whether a hook built
+// a record the pipeline would drop.
+type warnProcessor struct {
+	emitted int
+}
+
+func (p *warnProcessor) OnEmit(context.Context, *sdklog.Record) error { p.emitted++; return nil }
+func (p *warnProcessor) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= log.SeverityWarn
+}
+func (p *warnProcessor) Shutdown(context.Context) error   { return nil }
+func (p *warnProcessor) ForceFlush(context.Context) error { return nil }
+
+// stringer counts how often it is formatted.
+type stringer struct {
+	calls *int
+}
+
+func (s stringer) String() string {
+	*s.calls++
+	return "formatted"
+}
+
+func TestCore_SkipsRecordsThePipelineDrops(t *testing.T) {
+	processor := &warnProcessor{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	defer lp.Shutdown(context.Background())
+
+	core := New("test", "1.0.0", lp)
+	if ce := core.Check(zapcore.Entry{Level: zapcore.InfoLevel}, nil); ce != nil {
+		t.Error("Check(info) added the core for a level the pipeline drops")
+	}
+
+	calls := 0
+	logger := uberzap.New(core)
+	logger.Info("dropped", uberzap.Stringer("value", stringer{calls: &calls}))
+	if processor.emitted != 0 || calls != 0 {
+		t.Errorf("INFO emitted %d records and formatted fields %d times, want 0 and 0", processor.emitted, calls)
+	}
+
+	logger.Warn("kept", uberzap.Stringer("value", stringer{calls: &calls}))
+	if processor.emitted != 1 || calls != 1 {
+		t.Errorf("WARN emitted %d records and formatted fields %d times, want 1 and 1", processor.emitted, calls)
+	}
+}
@@ -1,6 +1,7 @@
 package zerolog
 
 import (
+	"context"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -34,8 +35,12 @@ import (
 //	log.Info().Str("key", "value").Msg("Hello")
 type ZerologOTelHook struct {
 	logger         log.Logger
+	provider       *sdklog.LoggerProvider
 	serviceName    string
 	serviceVersion string
+	minSeverity    log.Severity
+	attributes     []log.KeyValue
+	severities     map[zerolog.Level]log.Severity
 }
 
 // New creates a new OpenTelemetry hook for zerolog.
@@ -47,15 +52,21 @@ type ZerologOTelHook struct {
 //	logger := logger.Hook(hook)
 //
 // Returns nil if loggerProvider is nil.
-func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider) *ZerologOTelHook {
+func New(serviceName, serviceVersion string, loggerProvider *sdklog.LoggerProvider, opts ...Option) *ZerologOTelHook {
 	if loggerProvider == nil {
 		return nil
 	}
 
+	cfg := newConfig(opts)
+
 	return &ZerologOTelHook{
 		logger:         loggerProvider.Logger(serviceName),
+		provider:       loggerProvider,
 		serviceName:    serviceName,
 		serviceVersion: serviceVersion,
+		minSeverity:    cfg.minSeverity,
+		attributes:     cfg.attributes,
+		severities:     cfg.severities,
 	}
 }
 
@@ -65,8 +76,15 @@ func (h *ZerologOTelHook) Run(e *zerolog.Event, level zerolog.Level, msg string)
 		return
 	}
 
+	ctx := e.GetCtx()
+
 	// Convert zerolog level to OTel severity
-	severity, severityText := h.zerologLevelToOTel(level)
+	severity, severityText := h.mapLevel(level)
+
+	// Skip building the record if the OTel pipeline would drop it
+	if severity < h.minSeverity || !h.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
 
 	// Create OTel log record
 	var logRecord log.Record
@@ -75,8 +93,37 @@ func (h *ZerologOTelHook) Run(e *zerolog.Event, level zerolog.Level, msg string)
 	logRecord.SetSeverity(severity)
 	logRecord.SetSeverityText(severityText)
 
+	// Add the static attributes from WithAttributes
+	logRecord.AddAttributes(h.attributes...)
+
 	// Emit the log record
-	h.logger.Emit(e.GetCtx(), logRecord)
+	h.logger.Emit(ctx, logRecord)
+
+	// zerolog exits or panics once the event is written, so flush now
+	if level == zerolog.FatalLevel || level == zerolog.PanicLevel {
+		h.flush()
+	}
+}
+
+// flushTimeout bounds the flush performed before a Fatal exit or Panic.
+const flushTimeout = 5 * time.Second
+
+// flush force-flushes the logger provider so batched records are exported
+// before the process exits or unwinds.
+func (h *ZerologOTelHook) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	_ = h.provider.ForceFlush(ctx)
+}
+
+// mapLevel converts a level to log.Severity, applying the overrides set
+// with WithSeverityMapping.
+func (h *ZerologOTelHook) mapLevel(level zerolog.Level) (log.Severity, string) {
+	severity, severityText := h.zerologLevelToOTel(level)
+	if override, ok := h.severities[level]; ok {
+		severity = override
+	}
+	return severity, severityText
 }
 
 // zerologLevelToOTel converts zerolog.Level to log.Severity.
@@ -0,0 +1,111 @@
+package zerolog
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingExporter keeps every exported record.
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func TestHook_EventContext(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	logger := zerolog.New(io.Discard).Hook(New("test", "1.0.0", lp))
+	logger.Info().Ctx(ctx).Msg("correlated")
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if record.TraceID() != spanContext.TraceID() {
+		t.Errorf("TraceID() = %v, want %v", record.TraceID(), spanContext.TraceID())
+	}
+	if record.SpanID() != spanContext.SpanID() {
+		t.Errorf("SpanID() = %v, want %v", record.SpanID(), spanContext.SpanID())
+	}
+}
+
+func TestHook_WithSeverityMapping(t *testing.T) {
+	exporter := &recordingExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	defer lp.Shutdown(context.Background())
+
+	hook := New("test", "1.0.0", lp, WithSeverityMapping(map[zerolog.Level]log.Severity{
+		zerolog.WarnLevel: log.SeverityInfo4,
+	}))
+	logger := zerolog.New(io.Discard).Hook(hook)
+	logger.Warn().Msg("remapped")
+	logger.Error().Msg("default")
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("exported %d records, want 2", len(exporter.records))
+	}
+	if got := exporter.records[0].Severity(); got != log.SeverityInfo4 {
+		t.Errorf("warn Severity() = %v, want %v", got, log.SeverityInfo4)
+	}
+	if got := exporter.records[0].SeverityText(); got != "WARN" {
+		t.Errorf("warn SeverityText() = %q, want WARN", got)
+	}
+	if got := exporter.records[1].Severity(); got != log.SeverityError {
+		t.Errorf("error Severity() = %v, want %v", got, log.SeverityError)
+	}
+}
+
+// warnProcessor is a processor that is only enabled at WARN and above and
+// counts the records emitted to it, so tests can tell whether a hook built
+// a record the pipeline would drop.
+type warnProcessor struct {
+	emitted int
+}
+
+func (p *warnProcessor) OnEmit(context.Context, *sdklog.Record) error { p.emitted++; return nil }
+func (p *warnProcessor) Enabled(_ context.Context, param sdklog.EnabledParameters) bool {
+	return param.Severity >= log.SeverityWarn
+}
+func (p *warnProcessor) Shutdown(context.Context) error   { return nil }
+func (p *warnProcessor) ForceFlush(context.Context) error { return nil }
+
+func TestHook_SkipsRecordsThePipelineDrops(t *testing.T) {
+	processor := &warnProcessor{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+	defer lp.Shutdown(context.Background())
+
+	logger := zerolog.New(io.Discard).Hook(New("test", "1.0.0", lp))
+
+	logger.Info().Msg("dropped")
+	if processor.emitted != 0 {
+		t.Errorf("INFO emitted %d records, want 0", processor.emitted)
+	}
+
+	logger.Warn().Msg("kept")
+	if processor.emitted != 1 {
+		t.Errorf("WARN emitted %d records, want 1", processor.emitted)
+	}
+}
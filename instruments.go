@@ -0,0 +1,222 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// instrumentKey identifies a cached instrument.
+type instrumentKey struct {
+	kind string
+	name string
+}
+
+// instrumentCache holds the instruments created with the Counter, Histogram,
+// and related shortcuts, so they are created only once. It lives as long as
+// its Telemetry; Reconfigure rebinds the instruments to the new meter.
+type instrumentCache struct {
+	mu          sync.Mutex
+	meter       metric.Meter
+	instruments map[instrumentKey]rebindable
+}
+
+// newInstrumentCache returns an instrument cache using a meter named after the
+// service, with attrs as scope attributes, from mp, or a noop meter if mp is nil.
+func newInstrumentCache(mp *sdkmetric.MeterProvider, serviceName, serviceVersion string, attrs ...attribute.KeyValue) *instrumentCache {
+	var meter metric.Meter
+	if mp != nil {
+		meter = mp.Meter(serviceName,
+			metric.WithInstrumentationVersion(serviceVersion),
+			metric.WithInstrumentationAttributes(attrs...),
+		)
+	} else {
+		meter = metricnoop.NewMeterProvider().Meter(serviceName)
+	}
+	return &instrumentCache{meter: meter, instruments: make(map[instrumentKey]rebindable)}
+}
+
+// rebind switches the cache and every cached instrument to meter.
+func (c *instrumentCache) rebind(meter metric.Meter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.meter = meter
+	for _, instrument := range c.instruments {
+		instrument.bind(meter)
+	}
+}
+
+// instrumentCache returns the instrument cache of t, or an empty noop cache.
+func (t *Telemetry) instrumentCache() *instrumentCache {
+	t.mu.RLock()
+	c := t.instruments
+	t.mu.RUnlock()
+	if c == nil {
+		c = newInstrumentCache(nil, "", "")
+	}
+	return c
+}
+
+// cachedInstrument returns the instrument of the given kind and name, creating
+// it with newInstrument on first use.
+func cachedInstrument[T rebindable](t *Telemetry, kind, name string, newInstrument func() T) T {
+	c := t.instrumentCache()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := instrumentKey{kind: kind, name: name}
+	if instrument, ok := c.instruments[key]; ok {
+		return instrument.(T)
+	}
+	instrument := newInstrument()
+	instrument.bind(c.meter)
+	c.instruments[key] = instrument
+	return instrument
+}
+
+// rebindable is a cached instrument that can move to another meter.
+type rebindable interface {
+	bind(metric.Meter)
+}
+
+// boundInstrument holds the instrument created from the current meter.
+type boundInstrument[T any] struct {
+	create  func(metric.Meter) (T, error)
+	current atomic.Pointer[T]
+}
+
+// bind creates the instrument from meter. Creation errors are reported to the
+// OTel error handler; the SDK still returns a usable instrument.
+func (b *boundInstrument[T]) bind(meter metric.Meter) {
+	instrument, err := b.create(meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	b.current.Store(&instrument)
+}
+
+func (b *boundInstrument[T]) load() T {
+	return *b.current.Load()
+}
+
+type reconfigurableCounter struct {
+	embedded.Int64Counter
+	boundInstrument[metric.Int64Counter]
+}
+
+func (c *reconfigurableCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.load().Add(ctx, incr, opts...)
+}
+
+func (c *reconfigurableCounter) Enabled(ctx context.Context) bool {
+	return c.load().Enabled(ctx)
+}
+
+type reconfigurableUpDownCounter struct {
+	embedded.Int64UpDownCounter
+	boundInstrument[metric.Int64UpDownCounter]
+}
+
+func (c *reconfigurableUpDownCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.load().Add(ctx, incr, opts...)
+}
+
+func (c *reconfigurableUpDownCounter) Enabled(ctx context.Context) bool {
+	return c.load().Enabled(ctx)
+}
+
+type reconfigurableHistogram struct {
+	embedded.Float64Histogram
+	boundInstrument[metric.Float64Histogram]
+}
+
+func (h *reconfigurableHistogram) Record(ctx context.Context, value float64, opts ...metric.RecordOption) {
+	h.load().Record(ctx, value, opts...)
+}
+
+func (h *reconfigurableHistogram) Enabled(ctx context.Context) bool {
+	return h.load().Enabled(ctx)
+}
+
+type reconfigurableGauge struct {
+	embedded.Float64Gauge
+	boundInstrument[metric.Float64Gauge]
+}
+
+func (g *reconfigurableGauge) Record(ctx context.Context, value float64, opts ...metric.RecordOption) {
+	g.load().Record(ctx, value, opts...)
+}
+
+func (g *reconfigurableGauge) Enabled(ctx context.Context) bool {
+	return g.load().Enabled(ctx)
+}
+
+// Meter returns the meter named after the service that Counter, Histogram, and
+// the related shortcuts use, for instruments they don't cover. Instruments
+// created from it belong to the current configuration, so create them again
+// after Reconfigure. If metrics are disabled, a noop meter is returned.
+func (t *Telemetry) Meter() metric.Meter {
+	c := t.instrumentCache()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.meter
+}
+
+// Counter returns the int64 counter with the given name, creating it on first
+// use with a meter named after the service. Later calls with the same name
+// return the same instrument and ignore opts. The instrument stays valid across
+// Reconfigure, which creates it again from the new meter provider. If metrics
+// are disabled, a noop counter is returned.
+//
+//	t.Counter("orders.placed", metric.WithUnit("{order}")).Add(ctx, 1)
+func (t *Telemetry) Counter(name string, opts ...metric.Int64CounterOption) metric.Int64Counter {
+	return cachedInstrument(t, "counter", name, func() *reconfigurableCounter {
+		i := &reconfigurableCounter{}
+		i.create = func(m metric.Meter) (metric.Int64Counter, error) {
+			return m.Int64Counter(name, opts...)
+		}
+		return i
+	})
+}
+
+// UpDownCounter returns the cached int64 up-down counter with the given name;
+// see Counter.
+func (t *Telemetry) UpDownCounter(name string, opts ...metric.Int64UpDownCounterOption) metric.Int64UpDownCounter {
+	return cachedInstrument(t, "updowncounter", name, func() *reconfigurableUpDownCounter {
+		i := &reconfigurableUpDownCounter{}
+		i.create = func(m metric.Meter) (metric.Int64UpDownCounter, error) {
+			return m.Int64UpDownCounter(name, opts...)
+		}
+		return i
+	})
+}
+
+// Histogram returns the cached float64 histogram with the given name; see Counter.
+func (t *Telemetry) Histogram(name string, opts ...metric.Float64HistogramOption) metric.Float64Histogram {
+	return cachedInstrument(t, "histogram", name, func() *reconfigurableHistogram {
+		i := &reconfigurableHistogram{}
+		i.create = func(m metric.Meter) (metric.Float64Histogram, error) {
+			return m.Float64Histogram(name, opts...)
+		}
+		return i
+	})
+}
+
+// Gauge returns the cached float64 gauge with the given name; see Counter.
+func (t *Telemetry) Gauge(name string, opts ...metric.Float64GaugeOption) metric.Float64Gauge {
+	return cachedInstrument(t, "gauge", name, func() *reconfigurableGauge {
+		i := &reconfigurableGauge{}
+		i.create = func(m metric.Meter) (metric.Float64Gauge, error) {
+			return m.Float64Gauge(name, opts...)
+		}
+		return i
+	})
+}
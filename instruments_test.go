@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestTelemetry_CachedInstruments(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if tel.Counter("orders.placed") != tel.Counter("orders.placed") {
+		t.Error("Counter() returned a different instrument for the same name")
+	}
+
+	tel.Counter("orders.placed").Add(ctx, 2)
+	tel.Counter("orders.placed").Add(ctx, 3)
+	tel.Histogram("orders.value").Record(ctx, 42.5)
+	tel.UpDownCounter("orders.pending").Add(ctx, -1)
+	tel.Gauge("orders.queue").Record(ctx, 7)
+
+	collected, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range collected.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+
+	placed, ok := metrics["orders.placed"].Data.(metricdata.Sum[int64])
+	if !ok || sumInt64(placed) != 5 {
+		t.Errorf("orders.placed = %+v, want 5", metrics["orders.placed"].Data)
+	}
+	for _, name := range []string{"orders.value", "orders.pending", "orders.queue"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("metric %q was not collected", name)
+		}
+	}
+}
+
+func TestTelemetry_CachedInstrumentsDisabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	tel, err := New(context.Background(), &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	// Metrics are disabled, so a noop counter is returned
+	tel.Counter("orders.placed").Add(context.Background(), 1)
+}
@@ -0,0 +1,215 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_OTLPExport_EndToEnd(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	receiver := telemetrytest.NewOTLPReceiver(t)
+	receiver.SetEnv(t)
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, span := tel.StartSpan(ctx, "test-span")
+	span.End()
+
+	var record otellog.Record
+	record.SetSeverity(otellog.SeverityInfo)
+	record.SetBody(otellog.StringValue("test-log"))
+	tel.Logger().Emit(ctx, record)
+
+	counter, err := tel.MeterProvider().Meter("test").Int64Counter("test.counter")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+	counter.Add(ctx, 1)
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if spans := receiver.Spans(); len(spans) != 1 || spans[0].GetName() != "test-span" {
+		t.Errorf("received spans = %v, want one 'test-span'", spans)
+	}
+	if logs := receiver.LogRecords(); len(logs) != 1 || logs[0].GetBody().GetStringValue() != "test-log" {
+		t.Errorf("received logs = %v, want one 'test-log'", logs)
+	}
+	if !receivedMetric(receiver, "test.counter") {
+		t.Errorf("received metrics = %v, want 'test.counter'", metricNames(receiver))
+	}
+}
+
+// receivedMetric reports whether the receiver got a metric with the given
+// name, alongside the self-metrics.
+func receivedMetric(receiver *telemetrytest.OTLPReceiver, name string) bool {
+	return slices.Contains(metricNames(receiver), name)
+}
+
+// metricNames returns the names of the metrics the receiver got.
+func metricNames(receiver *telemetrytest.OTLPReceiver) []string {
+	var names []string
+	for _, m := range receiver.Metrics() {
+		names = append(names, m.GetName())
+	}
+	return names
+}
+
+func TestTelemetry_SharedGRPCConn(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	receiver := telemetrytest.NewOTLPReceiver(t)
+
+	conn, err := grpc.NewClient(strings.TrimPrefix(receiver.Endpoint(), "http://"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	// Enable all signals; the endpoint is deliberately unreachable so export
+	// only succeeds through the shared connection
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:1")
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName: "test-service",
+		GRPCConn:    conn,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, span := tel.StartSpan(ctx, "test-span")
+	span.End()
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("test-log"))
+	tel.Logger().Emit(ctx, record)
+
+	counter, err := tel.MeterProvider().Meter("test").Int64Counter("test.counter")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+	counter.Add(ctx, 1)
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if len(receiver.Spans()) != 1 || len(receiver.LogRecords()) != 1 || !receivedMetric(receiver, "test.counter") {
+		t.Errorf("received %d spans, %d logs, metrics %v over the shared connection, want 1 span, 1 log, and test.counter",
+			len(receiver.Spans()), len(receiver.LogRecords()), metricNames(receiver))
+	}
+}
+
+func TestTelemetry_ExporterState(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	receiver := telemetrytest.NewOTLPReceiver(t)
+	receiver.SetEnv(t)
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	state := tel.ExporterState()
+	if state.State != ExportStateIdle || len(state.Signals) != 2 {
+		t.Fatalf("ExporterState() before export = %+v, want idle logs and traces", state)
+	}
+
+	_, span := tel.StartSpan(ctx, "test-span")
+	span.End()
+
+	traces := tel.ExporterState().Signals["traces"]
+	if traces.State != ExportStateReady || traces.LastSuccess.IsZero() {
+		t.Errorf("traces state = %+v, want ready", traces)
+	}
+}
+
+func TestTelemetry_ExporterState_CollectorDown(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://127.0.0.1:1")
+	t.Setenv("OTEL_EXPORTER_OTLP_TIMEOUT", "200")
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+	t.Setenv("OTEL_LOGS_EXPORTER", "none")
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v with the collector down, want lazy connection", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	_, span := tel.StartSpan(ctx, "test-span")
+	span.End()
+
+	state := tel.ExporterState()
+	if state.State != ExportStateDegraded {
+		t.Errorf("ExporterState().State = %v, want degraded", state.State)
+	}
+	if state.Signals["traces"].LastError == nil {
+		t.Error("traces LastError = nil, want export error")
+	}
+}
+
+func TestTelemetry_PipelineMetrics(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	receiver := telemetrytest.NewOTLPReceiver(t)
+	receiver.SetEnv(t)
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	for i := 0; i < 3; i++ {
+		_, span := tel.StartSpan(ctx, "test-span")
+		span.End()
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	telemetrytest.AssertSum(t, rm, "telemetry.exporter.items", 3)
+	telemetrytest.AssertHistogramCount(t, rm, "telemetry.exporter.duration", 3)
+}
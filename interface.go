@@ -3,6 +3,8 @@ package telemetry
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -29,3 +31,19 @@ type ITelemetry interface {
 	// by the logger's OTel integration.
 	StartSpan(ctx context.Context, name string) (context.Context, trace.Span)
 }
+
+// ISpanShorthands is implemented by telemetry systems that provide the span
+// shorthands. It is kept out of ITelemetry so existing implementations keep
+// satisfying it; check for it with a type assertion:
+//
+//	if shorthands, ok := tel.(telemetry.ISpanShorthands); ok {
+//	    ctx, span, logger = shorthands.StartSpanWithLogger(ctx, "work")
+//	}
+type ISpanShorthands interface {
+	// StartSpanWithAttributes starts a new span with the given name and attributes.
+	StartSpanWithAttributes(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span)
+	// StartSpanWithLogger starts a new span and returns an OTel logger scoped to it.
+	StartSpanWithLogger(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span, otellog.Logger)
+}
+
+var _ ISpanShorthands = (*Telemetry)(nil)
@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// stubTelemetry implements only the ITelemetry methods, like an existing
+// implementation written against the original interface.
+type stubTelemetry struct{}
+
+func (stubTelemetry) Shutdown(context.Context)                 {}
+func (stubTelemetry) Tracer() trace.Tracer                     { return tracenoop.NewTracerProvider().Tracer("") }
+func (stubTelemetry) LoggerProvider() *sdklog.LoggerProvider   { return nil }
+func (stubTelemetry) MeterProvider() *sdkmetric.MeterProvider  { return nil }
+func (stubTelemetry) TracerProvider() *sdktrace.TracerProvider { return nil }
+func (s stubTelemetry) StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return s.Tracer().Start(ctx, name)
+}
+
+func TestISpanShorthands(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	var stub ITelemetry = stubTelemetry{}
+	if _, ok := stub.(ISpanShorthands); ok {
+		t.Error("stubTelemetry implements ISpanShorthands, want ITelemetry alone to be enough")
+	}
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	var shorthands ISpanShorthands = tel
+
+	_, span := shorthands.StartSpanWithAttributes(ctx, "load", attribute.Int("items", 3))
+	span.End()
+
+	_, span, logger := shorthands.StartSpanWithLogger(ctx, "save")
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("saved"))
+	logger.Emit(ctx, record)
+	span.End()
+
+	ended := spans.GetSpans()
+	if len(ended) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(ended))
+	}
+	if ended[0].Name != "load" || len(ended[0].Attributes) != 1 || ended[0].Attributes[0] != attribute.Int("items", 3) {
+		t.Errorf("StartSpanWithAttributes() span = %s %v, want load [items=3]", ended[0].Name, ended[0].Attributes)
+	}
+	if ended[1].Name != "save" {
+		t.Errorf("StartSpanWithLogger() span = %s, want save", ended[1].Name)
+	}
+
+	logs.AssertLogged(t, telemetrytest.WithMessage("saved"), telemetrytest.WithAttr("span_id", otellog.StringValue(ended[1].SpanContext.SpanID().String())))
+}
@@ -0,0 +1,90 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+// Default Kafka topics, matching the defaults of the OTel Collector Kafka receiver.
+const (
+	defaultKafkaTracesTopic  = "otlp_spans"
+	defaultKafkaMetricsTopic = "otlp_metrics"
+	defaultKafkaLogsTopic    = "otlp_logs"
+)
+
+// KafkaProducer publishes a message to a Kafka topic. Implement it with the
+// Kafka client of your choice (e.g., franz-go or sarama) and set it in
+// Options.KafkaProducer to publish OTLP exports to Kafka.
+// Produce must be safe for concurrent use.
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// kafkaTransport publishes OTLP export requests to Kafka. It is installed as a
+// gRPC interceptor on a connection that never dials, so the OTLP exporters
+// build their requests as usual and each request is published as an
+// OTLP protobuf payload (the "otlp_proto" encoding of the Collector Kafka receiver).
+type kafkaTransport struct {
+	producer KafkaProducer
+	topics   map[string]string
+}
+
+// newKafkaConn returns a gRPC connection whose export calls are published to
+// Kafka by producer, with one topic per signal.
+func newKafkaConn(producer KafkaProducer, opts *Options) (*grpc.ClientConn, error) {
+	transport := &kafkaTransport{producer: producer, topics: kafkaTopics(opts)}
+
+	conn, err := grpc.NewClient("passthrough:///kafka",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(transport.intercept),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka transport: %w", err)
+	}
+	return conn, nil
+}
+
+// intercept publishes an export request to the topic of its signal instead of
+// sending it. The reply is left empty, which OTLP treats as full success.
+func (k *kafkaTransport) intercept(ctx context.Context, method string, req, _ any, _ *grpc.ClientConn, _ grpc.UnaryInvoker, _ ...grpc.CallOption) error {
+	m, ok := otlpExportMethods[method]
+	if !ok {
+		return fmt.Errorf("unsupported OTLP method for Kafka transport: %s", method)
+	}
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unsupported OTLP request type for Kafka transport: %T", req)
+	}
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode OTLP %s request: %w", m.signal, err)
+	}
+
+	topic := k.topics[m.signal]
+	if err := k.producer.Produce(ctx, topic, nil, data); err != nil {
+		return fmt.Errorf("failed to publish OTLP %s to Kafka topic %s: %w", m.signal, topic, err)
+	}
+	return nil
+}
+
+// kafkaTopics returns the Kafka topic of each signal.
+func kafkaTopics(opts *Options) map[string]string {
+	return map[string]string{
+		"traces":  kafkaTopic(opts.KafkaTracesTopic, defaultKafkaTracesTopic),
+		"metrics": kafkaTopic(opts.KafkaMetricsTopic, defaultKafkaMetricsTopic),
+		"logs":    kafkaTopic(opts.KafkaLogsTopic, defaultKafkaLogsTopic),
+	}
+}
+
+// kafkaTopic returns topic, or fallback if it is empty.
+func kafkaTopic(topic, fallback string) string {
+	if topic == "" {
+		return fallback
+	}
+	return topic
+}
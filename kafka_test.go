@@ -0,0 +1,149 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordingProducer is a KafkaProducer that keeps every published message.
+type recordingProducer struct {
+	mu       sync.Mutex
+	messages map[string][][]byte
+}
+
+func (p *recordingProducer) Produce(_ context.Context, topic string, _, value []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.messages == nil {
+		p.messages = map[string][][]byte{}
+	}
+	p.messages[topic] = append(p.messages[topic], value)
+	return nil
+}
+
+func TestTelemetry_KafkaProducer(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	os.Setenv("OTEL_METRICS_EXPORTER", "none")
+	os.Setenv("OTEL_LOGS_EXPORTER", "none")
+
+	producer := &recordingProducer{}
+
+	ctx := context.Background()
+	tel, err := New(ctx, &Options{
+		ServiceName:      "kafka-service",
+		KafkaProducer:    producer,
+		KafkaTracesTopic: "traces",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, span := tel.StartSpan(ctx, "publish-me")
+	span.End()
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+
+	messages := producer.messages["traces"]
+	if len(messages) != 1 {
+		t.Fatalf("published %d messages to topic traces, want 1 (topics: %v)", len(messages), producer.messages)
+	}
+
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(messages[0], &req); err != nil {
+		t.Fatalf("failed to decode OTLP payload: %v", err)
+	}
+	if got := req.ResourceSpans[0].ScopeSpans[0].Spans[0].Name; got != "publish-me" {
+		t.Errorf("span name = %q, want %q", got, "publish-me")
+	}
+
+	endpoints := tel.ExporterEndpoints()
+	if len(endpoints) != 1 || endpoints[0].Endpoint != "kafka:traces" {
+		t.Errorf("ExporterEndpoints() = %v, want traces to kafka:traces", endpoints)
+	}
+}
+
+func TestTelemetry_KafkaProducer_ReconfigureSameOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_TRACES_EXPORTER", "otlp")
+	os.Setenv("OTEL_METRICS_EXPORTER", "none")
+	os.Setenv("OTEL_LOGS_EXPORTER", "none")
+
+	producer := &recordingProducer{}
+	opts := &Options{
+		ServiceName:      "kafka-service",
+		KafkaProducer:    producer,
+		KafkaTracesTopic: "traces",
+	}
+
+	ctx := context.Background()
+	tel, err := New(ctx, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// Reusing the Options must not hand the new Kafka connection to the
+	// configuration being shut down
+	if err := tel.Reconfigure(ctx, opts); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	_, span := tel.StartSpan(ctx, "after-reconfigure")
+	span.End()
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	if got := len(producer.messages["traces"]); got != 1 {
+		t.Errorf("published %d messages to topic traces, want 1", got)
+	}
+}
+
+func TestNewWithOptions_ReleasesTransportsOnError(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	opts := &Options{
+		ServiceName:     "kafka-service",
+		KafkaProducer:   &recordingProducer{},
+		MetricsExporter: "carrier-pigeon",
+	}
+	if _, err := newWithOptions(ctx, opts, nil); err == nil {
+		t.Fatal("newWithOptions() error = nil, want unsupported exporter error")
+	}
+	if opts.kafkaConn == nil || opts.kafkaConn.GetState() != connectivity.Shutdown {
+		t.Error("Kafka transport was not closed after newWithOptions() failed")
+	}
+
+	opts = &Options{
+		ServiceName:     "spool-service",
+		SpoolDir:        t.TempDir(),
+		MetricsExporter: "carrier-pigeon",
+	}
+	if _, err := newWithOptions(ctx, opts, nil); err == nil {
+		t.Fatal("newWithOptions() error = nil, want unsupported exporter error")
+	}
+	if opts.spool == nil || opts.spool.ctx.Err() == nil {
+		t.Error("spool was not closed after newWithOptions() failed")
+	}
+}
@@ -0,0 +1,112 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// defaultPodInfoDir is where the downward API volume is conventionally mounted.
+const defaultPodInfoDir = "/etc/podinfo"
+
+// serviceAccountNamespaceFile holds the pod's namespace in every pod that
+// mounts a service account token.
+var serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// kubernetesEnv lists, per attribute, the environment variables the downward
+// API is commonly exposed as, in order of preference.
+var kubernetesEnv = []struct {
+	attr func(string) attribute.KeyValue
+	vars []string
+	file string
+}{
+	{semconv.K8SPodName, []string{"K8S_POD_NAME", "POD_NAME"}, "name"},
+	{semconv.K8SPodUID, []string{"K8S_POD_UID", "POD_UID"}, "uid"},
+	{semconv.K8SNamespaceName, []string{"K8S_NAMESPACE_NAME", "POD_NAMESPACE"}, "namespace"},
+	{semconv.K8SNodeName, []string{"K8S_NODE_NAME", "NODE_NAME"}, ""},
+	{semconv.K8SContainerName, []string{"K8S_CONTAINER_NAME", "CONTAINER_NAME"}, ""},
+}
+
+// kubernetesAttributes returns the k8s.* resource attributes read from the
+// downward API: the environment variables in kubernetesEnv, and the name, uid,
+// namespace, and labels files of the volume mounted at dir (defaultPodInfoDir
+// if empty). Labels are added as k8s.pod.label.<key>. The namespace falls
+// back to the service account's. Missing values are skipped.
+func kubernetesAttributes(dir string) []attribute.KeyValue {
+	if dir == "" {
+		dir = defaultPodInfoDir
+	}
+
+	var attrs []attribute.KeyValue
+	for _, e := range kubernetesEnv {
+		if v := firstEnv(e.vars); v != "" {
+			attrs = append(attrs, e.attr(v))
+		} else if v := readPodInfo(dir, e.file); v != "" {
+			attrs = append(attrs, e.attr(v))
+		} else if e.file == "namespace" {
+			if v := readTrimmed(serviceAccountNamespaceFile); v != "" {
+				attrs = append(attrs, e.attr(v))
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "labels")); err == nil {
+		for key, value := range parsePodInfoLabels(data) {
+			attrs = append(attrs, attribute.String("k8s.pod.label."+key, value))
+		}
+	}
+	return attrs
+}
+
+// firstEnv returns the value of the first set environment variable in names.
+func firstEnv(names []string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// readPodInfo returns the trimmed contents of file in dir, or "" if file is
+// empty or can't be read.
+func readPodInfo(dir, file string) string {
+	if file == "" {
+		return ""
+	}
+	return readTrimmed(filepath.Join(dir, file))
+}
+
+// readTrimmed returns the trimmed contents of path, or "" if it can't be read.
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// parsePodInfoLabels parses a downward API labels file, one key="value" per
+// line with the value quoted, skipping malformed lines.
+func parsePodInfoLabels(data []byte) map[string]string {
+	labels := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, quoted, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || key == "" {
+			continue
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			continue
+		}
+		labels[key] = value
+	}
+	return labels
+}
@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestKubernetesAttributes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"name":      "api-7d9f-xk2p\n",
+		"namespace": "shop\n",
+		"labels":    "app=\"api\"\npod-template-hash=\"7d9f\"\nmalformed\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, e := range kubernetesEnv {
+		for _, name := range e.vars {
+			t.Setenv(name, "")
+		}
+	}
+	t.Setenv("NODE_NAME", "node-1")
+
+	set := attribute.NewSet(kubernetesAttributes(dir)...)
+
+	want := map[attribute.Key]string{
+		"k8s.pod.name":                    "api-7d9f-xk2p",
+		"k8s.namespace.name":              "shop",
+		"k8s.node.name":                   "node-1",
+		"k8s.pod.label.app":               "api",
+		"k8s.pod.label.pod-template-hash": "7d9f",
+	}
+	for key, value := range want {
+		if got, ok := set.Value(key); !ok || got.AsString() != value {
+			t.Errorf("%s = %q (present %v), want %q", key, got.AsString(), ok, value)
+		}
+	}
+	if set.Len() != len(want) {
+		t.Errorf("got %d attributes, want %d: %v", set.Len(), len(want), set.ToSlice())
+	}
+}
+
+func TestKubernetesAttributes_EnvPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "name"), []byte("from-file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("K8S_POD_NAME", "from-env")
+
+	set := attribute.NewSet(kubernetesAttributes(dir)...)
+	if got, _ := set.Value("k8s.pod.name"); got.AsString() != "from-env" {
+		t.Errorf("k8s.pod.name = %q, want from-env", got.AsString())
+	}
+}
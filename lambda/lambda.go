@@ -0,0 +1,82 @@
+// Package lambda instruments AWS Lambda handlers with a Telemetry instance:
+// an invocation span per call, and a flush before the handler returns, since
+// Lambda freezes the sandbox between invocations and pending exports would
+// otherwise be lost. Run on Lambda with Options.DetectPlatform set,
+// telemetry.New adds the cloud.* and faas.* resource attributes of the
+// function.
+package lambda
+
+import (
+	"context"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// coldStart is true until the first invocation of the process starts.
+var coldStart atomic.Bool
+
+func init() {
+	coldStart.Store(true)
+}
+
+// WrapHandler returns handler instrumented with t. Each invocation runs in a
+// SERVER span named after the function, with the faas.coldstart,
+// faas.invocation_id, and cloud.resource_id (the invoked ARN) attributes.
+// A returned error is recorded on the span and a panic is captured like
+// t.Recover does before it propagates. Pending telemetry is flushed before
+// the wrapped handler returns.
+//
+//	lambda.Start(telemetrylambda.WrapHandler(t, handle))
+func WrapHandler[TIn, TOut any](t *telemetry.Telemetry, handler func(context.Context, TIn) (TOut, error)) func(context.Context, TIn) (TOut, error) {
+	return func(ctx context.Context, in TIn) (out TOut, err error) {
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.FaaSColdstart(coldStart.Swap(false))),
+		}
+		if lc, ok := lambdacontext.FromContext(ctx); ok {
+			opts = append(opts, trace.WithAttributes(
+				semconv.FaaSInvocationID(lc.AwsRequestID),
+				semconv.CloudResourceID(lc.InvokedFunctionArn),
+			))
+		}
+
+		ctx, span := t.StartSpan(ctx, spanName(), opts...)
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				t.CapturePanic(ctx, recovered, debug.Stack())
+				span.End()
+				flush(ctx, t)
+				panic(recovered)
+			}
+			t.RecordError(ctx, err)
+			span.End()
+			flush(ctx, t)
+		}()
+
+		return handler(ctx, in)
+	}
+}
+
+// spanName returns the invocation span name: the function name, or "invoke"
+// outside Lambda.
+func spanName() string {
+	if lambdacontext.FunctionName != "" {
+		return lambdacontext.FunctionName
+	}
+	return "invoke"
+}
+
+// flush exports the pending telemetry, even if the invocation's context is
+// canceled, reporting failures to the OTel error handler.
+func flush(ctx context.Context, t *telemetry.Telemetry) {
+	if err := t.Flush(context.WithoutCancel(ctx)); err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,68 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+func TestWrapHandler(t *testing.T) {
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := telemetry.New(ctx, &telemetry.Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		BatchExport:        true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	handler := WrapHandler(tel, func(ctx context.Context, order string) (string, error) {
+		if order == "" {
+			return "", errors.New("empty order")
+		}
+		return "ok", nil
+	})
+
+	ctx = lambdacontext.NewContext(ctx, &lambdacontext.LambdaContext{
+		AwsRequestID:       "req-1",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:orders",
+	})
+	if out, err := handler(ctx, "42"); err != nil || out != "ok" {
+		t.Fatalf("handler() = %q, %v, want ok", out, err)
+	}
+	if _, err := handler(ctx, ""); err == nil {
+		t.Fatal("handler() error = nil, want error")
+	}
+
+	// The spans are exported before the handler returns despite BatchExport
+	got := spans.GetSpans()
+	if len(got) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(got))
+	}
+
+	first := map[string]any{}
+	for _, kv := range got[0].Attributes {
+		first[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	if first["faas.invocation_id"] != "req-1" || first["faas.coldstart"] != true {
+		t.Errorf("first invocation attributes = %v, want faas.invocation_id req-1 and faas.coldstart true", first)
+	}
+	for _, kv := range got[1].Attributes {
+		if kv.Key == "faas.coldstart" && kv.Value.AsBool() {
+			t.Error("second invocation faas.coldstart = true, want false")
+		}
+	}
+	if got[1].Status.Code != codes.Error {
+		t.Errorf("failed invocation status = %v, want Error", got[1].Status.Code)
+	}
+}
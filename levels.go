@@ -0,0 +1,79 @@
+package telemetry
+
+import (
+	"maps"
+	"sync"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// componentLevels holds the minimum severity per component (instrumentation
+// scope name), overriding Options.LogsMinSeverity. It is safe for concurrent use.
+type componentLevels struct {
+	mu     sync.RWMutex
+	levels map[string]otellog.Severity
+}
+
+// newComponentLevels returns the overrides from Options.ComponentLevels.
+func newComponentLevels(levels map[string]otellog.Severity) *componentLevels {
+	c := &componentLevels{levels: make(map[string]otellog.Severity, len(levels))}
+	for component, severity := range levels {
+		c.set(component, severity)
+	}
+	return c
+}
+
+// get returns the minimum severity for component, if it is overridden.
+// It is safe to call on a nil componentLevels.
+func (c *componentLevels) get(component string) (otellog.Severity, bool) {
+	if c == nil {
+		return otellog.SeverityUndefined, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	severity, ok := c.levels[component]
+	return severity, ok
+}
+
+// set overrides the minimum severity for component; SeverityUndefined removes
+// the override.
+func (c *componentLevels) set(component string, severity otellog.Severity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if severity == otellog.SeverityUndefined {
+		delete(c.levels, component)
+		return
+	}
+	c.levels[component] = severity
+}
+
+// snapshot returns a copy of the overrides.
+func (c *componentLevels) snapshot() map[string]otellog.Severity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return maps.Clone(c.levels)
+}
+
+// SetComponentLevel sets the minimum severity exported for a component at
+// runtime, e.g. to turn on debug logs for "database" only while the rest of
+// the service stays at LogsMinSeverity. The component is the instrumentation
+// scope name: the name passed to LoggerFor, or the name a hook was created
+// with. Passing otellog.SeverityUndefined removes the override. Overrides
+// are reset to Options.ComponentLevels by Reconfigure.
+func (t *Telemetry) SetComponentLevel(component string, severity otellog.Severity) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.levels != nil {
+		t.levels.set(component, severity)
+	}
+}
+
+// ComponentLevels returns the current per-component severity overrides.
+func (t *Telemetry) ComponentLevels() map[string]otellog.Severity {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.levels == nil {
+		return map[string]otellog.Severity{}
+	}
+	return t.levels.snapshot()
+}
@@ -0,0 +1,29 @@
+package telemetry
+
+import (
+	"context"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// loggerContextKey is the context key for the logger stored by ContextWithLogger.
+type loggerContextKey struct{}
+
+// ContextWithLogger returns a copy of ctx that carries logger, so code deep in
+// the call stack can retrieve it with LoggerFromContext instead of having the
+// logger passed alongside ctx through every function.
+func ContextWithLogger(ctx context.Context, logger otellog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// LoggerFromContext returns the logger stored in ctx by ContextWithLogger or
+// StartSpanWithLogger. Without one, it returns the logger of the default
+// Telemetry, or a noop logger if no default is set.
+func LoggerFromContext(ctx context.Context) otellog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerContextKey{}).(otellog.Logger); ok {
+			return logger
+		}
+	}
+	return Logger()
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// logValueToAny converts an OTel log value into a plain Go value
+// (string, int64, float64, bool, []byte, []any, map[string]any, or nil)
+// for exporters that encode records in their own wire format.
+func logValueToAny(v otellog.Value) any {
+	switch v.Kind() {
+	case otellog.KindString:
+		return v.AsString()
+	case otellog.KindInt64:
+		return v.AsInt64()
+	case otellog.KindFloat64:
+		return v.AsFloat64()
+	case otellog.KindBool:
+		return v.AsBool()
+	case otellog.KindBytes:
+		return v.AsBytes()
+	case otellog.KindSlice:
+		items := v.AsSlice()
+		values := make([]any, 0, len(items))
+		for _, item := range items {
+			values = append(values, logValueToAny(item))
+		}
+		return values
+	case otellog.KindMap:
+		kvs := v.AsMap()
+		values := make(map[string]any, len(kvs))
+		for _, kv := range kvs {
+			values[kv.Key] = logValueToAny(kv.Value)
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// logValueToAttribute converts an OTel log value into a span attribute.
+// Scalars keep their type; slices, maps, and bytes are rendered as strings.
+func logValueToAttribute(key string, v otellog.Value) attribute.KeyValue {
+	switch v.Kind() {
+	case otellog.KindString:
+		return attribute.String(key, v.AsString())
+	case otellog.KindInt64:
+		return attribute.Int64(key, v.AsInt64())
+	case otellog.KindFloat64:
+		return attribute.Float64(key, v.AsFloat64())
+	case otellog.KindBool:
+		return attribute.Bool(key, v.AsBool())
+	default:
+		return attribute.String(key, v.String())
+	}
+}
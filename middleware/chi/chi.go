@@ -0,0 +1,82 @@
+// Package chi provides chi middleware that instruments requests with a
+// Telemetry instance: server spans, correlated access logs, and RED metrics.
+package chi
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/middleware"
+)
+
+// New returns chi middleware that instruments each request using t.
+// Spans are named after the matched route pattern (e.g., "/users/{id}", not
+// "/users/123") so span names stay low-cardinality. Middleware registered with
+// r.Use runs before chi routes the request, so the pattern is looked up on the
+// router before the span starts; http.route is then set at span start and
+// Options.TracesSamplingRules can match on it. If the lookup finds nothing
+// (e.g., a router that does not support it), the span is renamed after the
+// handler runs instead.
+//
+//	r := chi.NewRouter()
+//	r.Use(chimw.New(t))
+func New(t *telemetry.Telemetry) func(http.Handler) http.Handler {
+	instrumenter := middleware.New(t)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, req := instrumenter.Start(r.Context(), propagation.HeaderCarrier(r.Header),
+				r.Method, r.URL.Path, findRoute(r))
+			if id := req.CorrelationID(); id != "" {
+				w.Header().Set(instrumenter.CorrelationIDHeader(), id)
+			}
+
+			ww := chimiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if !req.Panic(recovered, debug.Stack()) {
+						panic(recovered)
+					}
+					if ww.Status() == 0 {
+						ww.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+			}()
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if rctx := chi.RouteContext(ctx); rctx != nil {
+				req.SetRoute(rctx.RoutePattern())
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				// Nothing was written; net/http responds with 200
+				status = http.StatusOK
+			}
+			req.End(status, nil)
+		})
+	}
+}
+
+// findRoute returns the route pattern chi will match r with, or "" if it is
+// not known before routing. The lookup uses a fresh routing context so the
+// request's own context is left for chi to fill in.
+func findRoute(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+
+	// Routes is the root router, so look up the full path as chi does
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+
+	return rctx.Routes.Find(chi.NewRouteContext(), r.Method, path)
+}
@@ -0,0 +1,134 @@
+package chi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// newTelemetry returns a Telemetry exporting spans and logs in memory.
+func newTelemetry(t *testing.T, opts telemetry.Options) (*telemetry.Telemetry, *tracetest.InMemoryExporter, *telemetrytest.LogRecorder) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	opts.ServiceName = "test-service"
+	opts.LogsExporter = "none"
+	opts.MetricsExporter = "none"
+	opts.CustomSpanExporter = spans
+	opts.CustomLogExporter = logs
+
+	tel, err := telemetry.New(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { tel.Shutdown(context.Background()) })
+	return tel, spans, logs
+}
+
+func newRouter(tel *telemetry.Telemetry) chi.Router {
+	r := chi.NewRouter()
+	r.Use(New(tel))
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	r.Get("/healthz", func(w http.ResponseWriter, r *http.Request) {})
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		})
+	})
+	r.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	return r
+}
+
+func serve(r http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestNew(t *testing.T) {
+	tel, spans, logs := newTelemetry(t, telemetry.Options{CorrelationID: true})
+	r := newRouter(tel)
+
+	rec := serve(r, "/users/123")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Header().Get(tel.CorrelationIDHeader()) == "" {
+		t.Error("response has no correlation ID header")
+	}
+	serve(r, "/api/orders/7")
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(stubs))
+	}
+	for i, want := range []string{"GET /users/{id}", "GET /api/orders/{id}"} {
+		if stubs[i].Name != want {
+			t.Errorf("span name = %q, want %q", stubs[i].Name, want)
+		}
+	}
+
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /users/123 200"),
+		telemetrytest.WithAttr("http.route", otellog.StringValue("/users/{id}")),
+		telemetrytest.WithTraceID(stubs[0].SpanContext.TraceID()),
+	)
+	logs.AssertLogged(t, telemetrytest.WithMessage("GET /api/orders/7 202"))
+}
+
+func TestNew_SamplingRulesMatchRoute(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{
+		TracesSamplingRules: []telemetry.SamplingRule{
+			{Match: "/users/{id}", Ratio: 0},
+			{Match: "/api/orders/{id}", Ratio: 0},
+		},
+	})
+	r := newRouter(tel)
+
+	serve(r, "/users/123")
+	serve(r, "/api/orders/7")
+	serve(r, "/healthz")
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 || stubs[0].Name != "GET /healthz" {
+		t.Errorf("exported %v, want only GET /healthz; the route must be known when the span starts", stubs)
+	}
+}
+
+func TestNew_UnmatchedRoute(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{})
+
+	rec := serve(newRouter(tel), "/nope")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 || stubs[0].Name != "GET" {
+		t.Errorf("exported %v, want one span named GET", stubs)
+	}
+}
+
+func TestNew_RecoverPanics(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{RecoverPanics: true})
+
+	rec := serve(newRouter(tel), "/panic")
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported %d spans, want 1", got)
+	}
+}
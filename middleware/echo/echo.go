@@ -0,0 +1,57 @@
+// Package echo provides Echo middleware that instruments requests with a
+// Telemetry instance: server spans, correlated access logs, and RED metrics.
+package echo
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/middleware"
+)
+
+// New returns Echo middleware that instruments each request using t.
+// Spans are named after the matched route (c.Path()), and the span context is
+// stored on the request so handlers can pass c.Request().Context() to loggers
+// and downstream calls. Register it with e.Use so the route is known.
+//
+//	e := echo.New()
+//	e.Use(echomw.New(t))
+func New(t *telemetry.Telemetry) echo.MiddlewareFunc {
+	instrumenter := middleware.New(t)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			ctx, req := instrumenter.Start(r.Context(), propagation.HeaderCarrier(r.Header),
+				r.Method, r.URL.Path, c.Path())
+			c.SetRequest(r.WithContext(ctx))
+			if id := req.CorrelationID(); id != "" {
+				c.Response().Header().Set(instrumenter.CorrelationIDHeader(), id)
+			}
+
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if !req.Panic(recovered, debug.Stack()) {
+						panic(recovered)
+					}
+					if !c.Response().Committed {
+						_ = c.NoContent(http.StatusInternalServerError)
+					}
+				}
+			}()
+
+			err := next(c)
+			if err != nil {
+				// Let the error handler write the response so the recorded status is final
+				c.Error(err)
+			}
+
+			req.End(c.Response().Status, err)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package echo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// newTelemetry returns a Telemetry exporting spans and logs in memory.
+func newTelemetry(t *testing.T, opts telemetry.Options) (*telemetry.Telemetry, *tracetest.InMemoryExporter, *telemetrytest.LogRecorder) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	opts.ServiceName = "test-service"
+	opts.LogsExporter = "none"
+	opts.MetricsExporter = "none"
+	opts.CustomSpanExporter = spans
+	opts.CustomLogExporter = logs
+
+	tel, err := telemetry.New(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { tel.Shutdown(context.Background()) })
+	return tel, spans, logs
+}
+
+func newServer(tel *telemetry.Telemetry) *echo.Echo {
+	e := echo.New()
+	e.Use(New(tel))
+	e.GET("/users/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("database unavailable")
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "no such user")
+	})
+	e.GET("/panic", func(c echo.Context) error {
+		panic("boom")
+	})
+	return e
+}
+
+func TestNew(t *testing.T) {
+	tel, spans, logs := newTelemetry(t, telemetry.Options{CorrelationID: true})
+
+	rec := httptest.NewRecorder()
+	newServer(tel).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/123", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Header().Get(tel.CorrelationIDHeader()) == "" {
+		t.Error("response has no correlation ID header")
+	}
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(stubs))
+	}
+	if stubs[0].Name != "GET /users/:id" {
+		t.Errorf("span name = %q, want %q", stubs[0].Name, "GET /users/:id")
+	}
+
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /users/123 200"),
+		telemetrytest.WithAttr("http.route", otellog.StringValue("/users/:id")),
+		telemetrytest.WithTraceID(stubs[0].SpanContext.TraceID()),
+	)
+}
+
+func TestNew_HandlerError(t *testing.T) {
+	tel, _, logs := newTelemetry(t, telemetry.Options{})
+	e := newServer(tel)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /fail 500"),
+		telemetrytest.WithAttr("error", otellog.StringValue("database unavailable")),
+	)
+
+	// The error handler decides the status, so HTTP errors keep theirs
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	logs.AssertLogged(t, telemetrytest.WithMessage("GET /missing 404"))
+}
+
+func TestNew_RecoverPanics(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{RecoverPanics: true})
+
+	rec := httptest.NewRecorder()
+	newServer(tel).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported %d spans, want 1", got)
+	}
+}
@@ -0,0 +1,75 @@
+// Package fiber provides Fiber middleware that instruments requests with a
+// Telemetry instance: server spans, correlated access logs, and RED metrics.
+// Fiber is built on fasthttp, so it can't use the net/http based middleware.
+package fiber
+
+import (
+	"runtime/debug"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/middleware"
+)
+
+// New returns Fiber middleware that instruments each request using t.
+// Spans are named after the matched route (c.Route().Path), and the span
+// context is stored as the user context so handlers can pass c.UserContext()
+// to loggers and downstream calls.
+//
+//	app := fiber.New()
+//	app.Use(fibermw.New(t))
+func New(t *telemetry.Telemetry) fiber.Handler {
+	instrumenter := middleware.New(t)
+
+	return func(c *fiber.Ctx) error {
+		ctx, req := instrumenter.Start(c.UserContext(), headerCarrier{c: c},
+			c.Method(), c.Path(), "")
+		c.SetUserContext(ctx)
+		if id := req.CorrelationID(); id != "" {
+			c.Set(instrumenter.CorrelationIDHeader(), id)
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if !req.Panic(recovered, debug.Stack()) {
+					panic(recovered)
+				}
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}()
+
+		err := c.Next()
+		if err != nil {
+			// Let the error handler write the response so the recorded status is final
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		req.SetRoute(c.Route().Path)
+		req.End(c.Response().StatusCode(), err)
+		return nil
+	}
+}
+
+// headerCarrier adapts the fasthttp request headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, h.c.Request().Header.Len())
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
@@ -0,0 +1,140 @@
+package fiber
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// newTelemetry returns a Telemetry exporting spans and logs in memory.
+func newTelemetry(t *testing.T, opts telemetry.Options) (*telemetry.Telemetry, *tracetest.InMemoryExporter, *telemetrytest.LogRecorder) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	opts.ServiceName = "test-service"
+	opts.LogsExporter = "none"
+	opts.MetricsExporter = "none"
+	opts.CustomSpanExporter = spans
+	opts.CustomLogExporter = logs
+
+	tel, err := telemetry.New(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { tel.Shutdown(context.Background()) })
+	return tel, spans, logs
+}
+
+func newApp(tel *telemetry.Telemetry) *fiber.App {
+	app := fiber.New()
+	app.Use(New(tel))
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return errors.New("database unavailable")
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+	return app
+}
+
+func serve(t *testing.T, app *fiber.App, path string) *http.Response {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestNew(t *testing.T) {
+	tel, spans, logs := newTelemetry(t, telemetry.Options{CorrelationID: true})
+
+	resp := serve(t, newApp(tel), "/users/123")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if resp.Header.Get(tel.CorrelationIDHeader()) == "" {
+		t.Error("response has no correlation ID header")
+	}
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(stubs))
+	}
+	if stubs[0].Name != "GET /users/:id" {
+		t.Errorf("span name = %q, want %q", stubs[0].Name, "GET /users/:id")
+	}
+
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /users/123 200"),
+		telemetrytest.WithAttr("http.route", otellog.StringValue("/users/:id")),
+		telemetrytest.WithTraceID(stubs[0].SpanContext.TraceID()),
+	)
+}
+
+func TestNew_HandlerError(t *testing.T) {
+	tel, _, logs := newTelemetry(t, telemetry.Options{})
+	app := newApp(tel)
+
+	if resp := serve(t, app, "/fail"); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /fail 500"),
+		telemetrytest.WithAttr("error", otellog.StringValue("database unavailable")),
+	)
+
+	// The error handler decides the status, so fiber errors keep theirs
+	if resp := serve(t, app, "/missing"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	logs.AssertLogged(t, telemetrytest.WithMessage("GET /missing 404"))
+}
+
+func TestNew_PropagatesTraceContext(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp, err := newApp(tel).Test(req)
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	resp.Body.Close()
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(stubs))
+	}
+	if got := stubs[0].SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, want the propagated one", got)
+	}
+}
+
+func TestNew_RecoverPanics(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{RecoverPanics: true})
+
+	if resp := serve(t, newApp(tel), "/panic"); resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported %d spans, want 1", got)
+	}
+}
@@ -0,0 +1,51 @@
+// Package gin provides Gin middleware that instruments requests with a
+// Telemetry instance: server spans, correlated access logs, and RED metrics.
+package gin
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/middleware"
+)
+
+// New returns Gin middleware that instruments each request using t.
+// Spans are named after the matched route (c.FullPath()), and the span context
+// is stored on c.Request so handlers can pass c.Request.Context() to loggers
+// and downstream calls.
+//
+//	r := gin.New()
+//	r.Use(ginmw.New(t))
+func New(t *telemetry.Telemetry) gin.HandlerFunc {
+	instrumenter := middleware.New(t)
+
+	return func(c *gin.Context) {
+		ctx, req := instrumenter.Start(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header),
+			c.Request.Method, c.Request.URL.Path, c.FullPath())
+		c.Request = c.Request.WithContext(ctx)
+		if id := req.CorrelationID(); id != "" {
+			c.Header(instrumenter.CorrelationIDHeader(), id)
+		}
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if !req.Panic(recovered, debug.Stack()) {
+					panic(recovered)
+				}
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+
+		c.Next()
+
+		var err error
+		if last := c.Errors.Last(); last != nil {
+			err = last.Err
+		}
+		req.End(c.Writer.Status(), err)
+	}
+}
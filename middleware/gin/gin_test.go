@@ -0,0 +1,114 @@
+package gin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+// newTelemetry returns a Telemetry exporting spans and logs in memory.
+func newTelemetry(t *testing.T, opts telemetry.Options) (*telemetry.Telemetry, *tracetest.InMemoryExporter, *telemetrytest.LogRecorder) {
+	t.Helper()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	opts.ServiceName = "test-service"
+	opts.LogsExporter = "none"
+	opts.MetricsExporter = "none"
+	opts.CustomSpanExporter = spans
+	opts.CustomLogExporter = logs
+
+	tel, err := telemetry.New(context.Background(), &opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { tel.Shutdown(context.Background()) })
+	return tel, spans, logs
+}
+
+func newRouter(tel *telemetry.Telemetry) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(New(tel))
+	r.GET("/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	r.GET("/fail", func(c *gin.Context) {
+		_ = c.Error(errors.New("database unavailable"))
+		c.Status(http.StatusInternalServerError)
+	})
+	r.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+	return r
+}
+
+func TestNew(t *testing.T) {
+	tel, spans, logs := newTelemetry(t, telemetry.Options{CorrelationID: true})
+
+	rec := httptest.NewRecorder()
+	newRouter(tel).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/123", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Header().Get(tel.CorrelationIDHeader()) == "" {
+		t.Error("response has no correlation ID header")
+	}
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(stubs))
+	}
+	if stubs[0].Name != "GET /users/:id" {
+		t.Errorf("span name = %q, want %q", stubs[0].Name, "GET /users/:id")
+	}
+
+	logs.AssertLogged(t,
+		telemetrytest.WithMessage("GET /users/123 200"),
+		telemetrytest.WithAttr("http.route", otellog.StringValue("/users/:id")),
+		telemetrytest.WithTraceID(stubs[0].SpanContext.TraceID()),
+	)
+}
+
+func TestNew_HandlerError(t *testing.T) {
+	tel, spans, logs := newTelemetry(t, telemetry.Options{})
+
+	rec := httptest.NewRecorder()
+	newRouter(tel).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	stubs := spans.GetSpans()
+	if len(stubs) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(stubs))
+	}
+	if len(stubs[0].Events) == 0 {
+		t.Error("span has no exception event for the handler error")
+	}
+	logs.AssertLogged(t,
+		telemetrytest.WithSeverity(otellog.SeverityError),
+		telemetrytest.WithAttr("error", otellog.StringValue("database unavailable")),
+	)
+}
+
+func TestNew_RecoverPanics(t *testing.T) {
+	tel, spans, _ := newTelemetry(t, telemetry.Options{RecoverPanics: true})
+
+	rec := httptest.NewRecorder()
+	newRouter(tel).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Errorf("exported %d spans, want 1", got)
+	}
+}
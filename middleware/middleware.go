@@ -0,0 +1,212 @@
+// Package middleware provides the framework-independent request instrumentation
+// shared by the HTTP framework adapters (gin, echo, chi, fiber): a server span
+// per request, an access log record correlated with the span, and RED metrics
+// (rate, errors, duration) through the http.server.request.duration histogram.
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// Instrumenter instruments HTTP server requests using a Telemetry instance.
+// It is safe for concurrent use.
+type Instrumenter struct {
+	t       *telemetry.Telemetry
+	tracer  trace.Tracer
+	logger  otellog.Logger
+	metrics *telemetry.RequestMetrics
+}
+
+// New creates an Instrumenter that uses t's tracer, logger, and meter provider.
+// Signals that are disabled in t are noops.
+func New(t *telemetry.Telemetry) *Instrumenter {
+	return &Instrumenter{
+		t:       t,
+		tracer:  t.Tracer(),
+		logger:  t.Logger(),
+		metrics: telemetry.NewRequestMetrics(t, "http.server"),
+	}
+}
+
+// Request tracks one instrumented request from Start to End.
+type Request struct {
+	i     *Instrumenter
+	ctx   context.Context
+	span  trace.Span
+	start time.Time
+
+	method string
+	path   string
+	route  string
+
+	correlationID string
+}
+
+// Start extracts the trace context propagated in carrier (usually the request
+// headers) with t's propagator, starts a server span, and returns the context to pass down the
+// handler chain. route is the matched route pattern (e.g., "/users/{id}"); pass
+// "" if it is not known until the handler has run and call SetRoute later.
+// With Options.CorrelationID set, the context also carries the request's
+// correlation ID.
+func (i *Instrumenter) Start(ctx context.Context, carrier propagation.TextMapCarrier, method, path, route string) (context.Context, *Request) {
+	ctx = i.t.Propagator().Extract(ctx, carrier)
+	ctx, correlationID := i.t.ExtractCorrelationID(ctx, carrier)
+
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.URLPath(path),
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+
+	ctx, span := i.tracer.Start(ctx, spanName(method, route),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, &Request{
+		i:      i,
+		ctx:    ctx,
+		span:   span,
+		start:  time.Now(),
+		method: method,
+		path:   path,
+		route:  route,
+
+		correlationID: correlationID,
+	}
+}
+
+// CorrelationIDHeader returns the response header to echo the correlation ID
+// in, or "" if Options.CorrelationID is not set.
+func (i *Instrumenter) CorrelationIDHeader() string {
+	return i.t.CorrelationIDHeader()
+}
+
+// CorrelationID returns the correlation ID of the request, or "" if
+// Options.CorrelationID is not set.
+func (r *Request) CorrelationID() string {
+	return r.correlationID
+}
+
+// Span returns the server span of the request.
+func (r *Request) Span() trace.Span {
+	return r.span
+}
+
+// SetRoute sets the matched route pattern once routing has happened, and
+// renames the span after it so span names stay low-cardinality.
+func (r *Request) SetRoute(route string) {
+	if route == "" {
+		return
+	}
+	r.route = route
+	r.span.SetName(spanName(r.method, route))
+	r.span.SetAttributes(semconv.HTTPRoute(route))
+}
+
+// End records the response status and any handler error, ends the span, emits
+// the access log record, and records the request duration.
+func (r *Request) End(status int, err error) {
+	elapsed := time.Since(r.start)
+
+	r.span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+	if err != nil {
+		r.span.RecordError(err)
+	}
+	errorType := ""
+	switch {
+	case status >= 500:
+		errorType = strconv.Itoa(status)
+		r.span.SetStatus(codes.Error, "")
+	case err != nil:
+		errorType = fmt.Sprintf("%T", err)
+		r.span.SetStatus(codes.Error, err.Error())
+	}
+	r.span.End()
+
+	metricAttrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(r.method),
+		semconv.HTTPResponseStatusCode(status),
+	}
+	if r.route != "" {
+		metricAttrs = append(metricAttrs, semconv.HTTPRoute(r.route))
+	}
+	r.i.metrics.RecordErrorType(r.ctx, elapsed, errorType, metricAttrs...)
+
+	r.log(status, elapsed, err)
+}
+
+// Panic captures a panic raised by the handler chain with
+// Telemetry.CapturePanic and ends the request with status 500. It reports
+// whether the adapter should respond with 500 (Options.RecoverPanics) rather
+// than re-panic. http.ErrAbortHandler, which net/http uses to abort a
+// response, is not captured and is always re-raised.
+func (r *Request) Panic(recovered any, stack []byte) bool {
+	if recovered == http.ErrAbortHandler {
+		r.End(http.StatusInternalServerError, http.ErrAbortHandler)
+		return false
+	}
+	r.i.t.CapturePanic(r.ctx, recovered, stack)
+	r.End(http.StatusInternalServerError, nil)
+	return r.i.t.RecoversPanics()
+}
+
+// log emits the access log record in the request context, so it carries the
+// trace and span IDs of the server span.
+func (r *Request) log(status int, elapsed time.Duration, err error) {
+	severity, severityText := otellog.SeverityInfo, "INFO"
+	switch {
+	case status >= 500 || err != nil:
+		severity, severityText = otellog.SeverityError, "ERROR"
+	case status >= 400:
+		severity, severityText = otellog.SeverityWarn, "WARN"
+	}
+
+	if !r.i.logger.Enabled(r.ctx, otellog.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	var record otellog.Record
+	record.SetTimestamp(time.Now())
+	record.SetSeverity(severity)
+	record.SetSeverityText(severityText)
+	record.SetBody(otellog.StringValue(fmt.Sprintf("%s %s %d", r.method, r.path, status)))
+	record.AddAttributes(
+		otellog.String(string(semconv.HTTPRequestMethodKey), r.method),
+		otellog.String(string(semconv.URLPathKey), r.path),
+		otellog.Int(string(semconv.HTTPResponseStatusCodeKey), status),
+		otellog.Float64("http.server.request.duration", elapsed.Seconds()),
+	)
+	if r.route != "" {
+		record.AddAttributes(otellog.String(string(semconv.HTTPRouteKey), r.route))
+	}
+	if err != nil {
+		record.AddAttributes(otellog.String("error", err.Error()))
+	}
+
+	r.i.logger.Emit(r.ctx, record)
+}
+
+// spanName returns the span name for a request: "METHOD route", or just the
+// method when the route is unknown, to keep span names low-cardinality.
+func spanName(method, route string) string {
+	if route == "" {
+		return method
+	}
+	return method + " " + route
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/ekristen/go-telemetry/v2"
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestInstrumenter(t *testing.T) {
+	receiver := telemetrytest.NewOTLPReceiver(t)
+	receiver.SetEnv(t)
+
+	ctx := context.Background()
+
+	tel, err := telemetry.New(ctx, &telemetry.Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	i := New(tel)
+
+	_, req := i.Start(ctx, propagation.HeaderCarrier(http.Header{}), http.MethodGet, "/users/123", "")
+	req.SetRoute("/users/{id}")
+	req.End(http.StatusInternalServerError, errors.New("database unavailable"))
+
+	spans := receiver.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("received %d spans, want 1", len(spans))
+	}
+	if spans[0].GetName() != "GET /users/{id}" {
+		t.Errorf("span name = %q, want %q", spans[0].GetName(), "GET /users/{id}")
+	}
+
+	logs := receiver.LogRecords()
+	if len(logs) != 1 {
+		t.Fatalf("received %d logs, want 1", len(logs))
+	}
+	if got := logs[0].GetBody().GetStringValue(); got != "GET /users/123 500" {
+		t.Errorf("log body = %q, want %q", got, "GET /users/123 500")
+	}
+	if string(logs[0].GetTraceId()) != string(spans[0].GetTraceId()) {
+		t.Error("access log is not correlated with the server span")
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	telemetrytest.AssertHistogramCount(t, rm, "http.server.request.duration", 1)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			histogram, ok := m.Data.(metricdata.Histogram[float64])
+			if m.Name != "http.server.request.duration" || !ok {
+				continue
+			}
+			if v, _ := histogram.DataPoints[0].Attributes.Value("error.type"); v.AsString() != "500" {
+				t.Errorf("error.type = %q, want %q", v.AsString(), "500")
+			}
+		}
+	}
+}
+
+func TestInstrumenter_ExtractsWithTelemetryPropagator(t *testing.T) {
+	receiver := telemetrytest.NewOTLPReceiver(t)
+	receiver.SetEnv(t)
+
+	ctx := context.Background()
+
+	tel, err := telemetry.New(ctx, &telemetry.Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	i := New(tel)
+
+	// Replacing the global propagator after New must not affect extraction
+	prev := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(prev)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	header := http.Header{}
+	header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+	_, req := i.Start(ctx, propagation.HeaderCarrier(header), http.MethodGet, "/", "/")
+	req.End(http.StatusOK, nil)
+
+	spans := receiver.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("received %d spans, want 1", len(spans))
+	}
+	if got := fmt.Sprintf("%x", spans[0].GetParentSpanId()); got != "0102030405060708" {
+		t.Errorf("parent span ID = %s, want 0102030405060708", got)
+	}
+}
+
+func TestSpanName(t *testing.T) {
+	if got := spanName("GET", ""); got != "GET" {
+		t.Errorf("spanName() = %q, want %q", got, "GET")
+	}
+	if got := spanName("POST", "/users"); got != "POST /users" {
+		t.Errorf("spanName() = %q, want %q", got, "POST /users")
+	}
+}
@@ -0,0 +1,90 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// panicCounterName is the counter of panics captured by Recover, CapturePanic,
+// and the HTTP middleware.
+const panicCounterName = "process.panics"
+
+// PanicError describes a recovered panic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover recovers a panic and captures it with CapturePanic. It must be
+// deferred directly, so the goroutine keeps running after the panic:
+//
+//	defer t.Recover(ctx)
+func (t *Telemetry) Recover(ctx context.Context) {
+	if recovered := recover(); recovered != nil {
+		t.CapturePanic(ctx, recovered, debug.Stack())
+	}
+}
+
+// CapturePanic records a recovered panic in every signal: an escaped exception
+// event with the stack trace on the span in ctx (whose status is set to Error),
+// a FATAL log record with the exception attributes, and an increment of the
+// process.panics counter. It is for code that recovers panics itself; stack
+// should come from debug.Stack in the deferred function. It returns the panic
+// as a *PanicError.
+func (t *Telemetry) CapturePanic(ctx context.Context, recovered any, stack []byte) *PanicError {
+	err := &PanicError{Value: recovered, Stack: stack}
+
+	recordError(ctx, err, false, trace.WithAttributes(
+		semconv.ExceptionStacktrace(string(stack)),
+		semconv.ExceptionEscaped(true),
+	))
+
+	logger := t.Logger()
+	if logger.Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityFatal}) {
+		var record otellog.Record
+		record.SetTimestamp(time.Now())
+		record.SetSeverity(otellog.SeverityFatal)
+		record.SetSeverityText("FATAL")
+		record.SetBody(otellog.StringValue(err.Error()))
+		record.AddAttributes(
+			otellog.String(string(semconv.ExceptionTypeKey), fmt.Sprintf("%T", recovered)),
+			otellog.String(string(semconv.ExceptionMessageKey), fmt.Sprint(recovered)),
+			otellog.String(string(semconv.ExceptionStacktraceKey), string(stack)),
+		)
+		logger.Emit(ctx, record)
+	}
+
+	t.Counter(panicCounterName,
+		metric.WithDescription("Number of panics captured."),
+		metric.WithUnit("{panic}"),
+	).Add(ctx, 1)
+
+	return err
+}
+
+// RecoversPanics reports whether the HTTP middleware responds with 500 after
+// capturing a panic, as set by Options.RecoverPanics, instead of re-panicking.
+func (t *Telemetry) RecoversPanics() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.cfg != nil && t.cfg.RecoverPanics
+}
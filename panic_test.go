@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_Recover(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+		MetricsExporter:    "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	cause := errors.New("nil map")
+	func() {
+		spanCtx, span := tel.StartSpan(ctx, "job")
+		defer span.End()
+		defer tel.Recover(spanCtx)
+		panic(cause)
+	}()
+
+	got := spans.GetSpans()
+	if len(got) != 1 || len(got[0].Events) != 1 {
+		t.Fatalf("spans = %v, want one span with one exception event", got)
+	}
+	if got[0].Events[0].Name != semconv.ExceptionEventName {
+		t.Errorf("event name = %q, want %q", got[0].Events[0].Name, semconv.ExceptionEventName)
+	}
+
+	records := logs.Records()
+	if len(records) != 1 {
+		t.Fatalf("exported %d log records, want 1", len(records))
+	}
+	if records[0].Severity != otellog.SeverityFatal || records[0].Message() != "panic: nil map" {
+		t.Errorf("log record = %v %q, want FATAL \"panic: nil map\"", records[0].Severity, records[0].Message())
+	}
+	if records[0].Attributes[string(semconv.ExceptionStacktraceKey)].AsString() == "" {
+		t.Error("log record has no exception.stacktrace")
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	telemetrytest.AssertSum(t, rm, panicCounterName, 1)
+}
+
+func TestPanicError_Unwrap(t *testing.T) {
+	cause := errors.New("boom")
+	if err := (&PanicError{Value: cause}); !errors.Is(err, cause) {
+		t.Errorf("errors.Is(%v, cause) = false, want true", err)
+	}
+	if err := (&PanicError{Value: "boom"}); err.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v, want nil for a non-error value", err.Unwrap())
+	}
+}
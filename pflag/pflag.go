@@ -0,0 +1,91 @@
+// Package pflag provides pflag flags for the common telemetry settings, for
+// cobra and other pflag-based CLIs, and builds Options from them.
+package pflag
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// Flag names.
+const (
+	LogLevelFlag         = "log-level"
+	OTLPEndpointFlag     = "otlp-endpoint"
+	MetricsExporterFlag  = "metrics-exporter"
+	TraceSampleRatioFlag = "trace-sample-ratio"
+)
+
+// RegisterFlags adds the --log-level, --otlp-endpoint, --metrics-exporter,
+// and --trace-sample-ratio flags to fs.
+//
+//	cmd := &cobra.Command{Use: "mycli", RunE: run}
+//	telemetrypflag.RegisterFlags(cmd.PersistentFlags())
+func RegisterFlags(fs *pflag.FlagSet) {
+	fs.String(LogLevelFlag, "", "lowest log severity exported (trace, debug, info, warn, error, fatal)")
+	fs.String(OTLPEndpointFlag, "", "OTLP gRPC endpoint for traces, metrics, and logs (e.g., collector:4317)")
+	fs.String(MetricsExporterFlag, "", "metrics exporter (otlp, prometheus, none)")
+	fs.Float64(TraceSampleRatioFlag, 0, "fraction of new traces sampled (0 to 1); child spans follow their parent")
+}
+
+// OptionsFromFlags returns Options built from the flags registered by
+// RegisterFlags that are set on fs; the caller sets the service name and
+// version. Flags that aren't set leave their fields empty, so the defaults and
+// environment variables apply; the environment variables applied by
+// telemetry.New still override the flags.
+//
+//	opts, err := telemetrypflag.OptionsFromFlags(cmd.Flags())
+//	if err != nil {
+//		return err
+//	}
+//	opts.ServiceName = cmd.Root().Name()
+//	t, err := telemetry.New(cmd.Context(), opts)
+func OptionsFromFlags(fs *pflag.FlagSet) (*telemetry.Options, error) {
+	opts := &telemetry.Options{}
+
+	if fs.Changed(LogLevelFlag) {
+		level, err := fs.GetString(LogLevelFlag)
+		if err != nil {
+			return nil, err
+		}
+		severity, err := telemetry.ParseSeverity(level)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", LogLevelFlag, err)
+		}
+		opts.LogsMinSeverity = severity
+	}
+
+	if fs.Changed(OTLPEndpointFlag) {
+		endpoint, err := fs.GetString(OTLPEndpointFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts.TracesEndpoint = endpoint
+		opts.MetricsEndpoint = endpoint
+		opts.LogsEndpoint = endpoint
+	}
+
+	if fs.Changed(MetricsExporterFlag) {
+		exporter, err := fs.GetString(MetricsExporterFlag)
+		if err != nil {
+			return nil, err
+		}
+		opts.MetricsExporter = exporter
+	}
+
+	if fs.Changed(TraceSampleRatioFlag) {
+		ratio, err := fs.GetFloat64(TraceSampleRatioFlag)
+		if err != nil {
+			return nil, err
+		}
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("--%s: ratio %v is not between 0 and 1", TraceSampleRatioFlag, ratio)
+		}
+		opts.TracesSampler = "parentbased_traceidratio"
+		opts.TracesSamplerRatio = ratio
+	}
+
+	return opts, nil
+}
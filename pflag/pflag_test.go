@@ -0,0 +1,73 @@
+package pflag
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	otellog "go.opentelemetry.io/otel/log"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// parse registers the flags on a new FlagSet, parses args, and returns the
+// Options built from it.
+func parse(t *testing.T, args ...string) (*telemetry.Options, error) {
+	t.Helper()
+
+	fs := pflag.NewFlagSet("mycli", pflag.ContinueOnError)
+	RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return OptionsFromFlags(fs)
+}
+
+func TestOptionsFromFlags(t *testing.T) {
+	opts, err := parse(t,
+		"--log-level", "debug",
+		"--otlp-endpoint", "collector:4317",
+		"--metrics-exporter", "none",
+		"--trace-sample-ratio", "0.5",
+	)
+	if err != nil {
+		t.Fatalf("OptionsFromFlags() error = %v", err)
+	}
+
+	if opts.LogsMinSeverity != otellog.SeverityDebug {
+		t.Errorf("LogsMinSeverity = %v, want %v", opts.LogsMinSeverity, otellog.SeverityDebug)
+	}
+	for name, endpoint := range map[string]string{
+		"TracesEndpoint":  opts.TracesEndpoint,
+		"MetricsEndpoint": opts.MetricsEndpoint,
+		"LogsEndpoint":    opts.LogsEndpoint,
+	} {
+		if endpoint != "collector:4317" {
+			t.Errorf("%s = %q, want collector:4317", name, endpoint)
+		}
+	}
+	if opts.MetricsExporter != "none" {
+		t.Errorf("MetricsExporter = %q, want none", opts.MetricsExporter)
+	}
+	if opts.TracesSampler != "parentbased_traceidratio" || opts.TracesSamplerRatio != 0.5 {
+		t.Errorf("sampler = %s(%v), want parentbased_traceidratio(0.5)", opts.TracesSampler, opts.TracesSamplerRatio)
+	}
+}
+
+func TestOptionsFromFlags_Unset(t *testing.T) {
+	opts, err := parse(t)
+	if err != nil {
+		t.Fatalf("OptionsFromFlags() error = %v", err)
+	}
+	if opts.TracesEndpoint != "" || opts.MetricsExporter != "" || opts.TracesSampler != "" {
+		t.Errorf("OptionsFromFlags() = %+v, want empty", opts)
+	}
+}
+
+func TestOptionsFromFlags_Invalid(t *testing.T) {
+	if _, err := parse(t, "--log-level", "loud"); err == nil {
+		t.Error("OptionsFromFlags() with --log-level loud error = nil, want error")
+	}
+	if _, err := parse(t, "--trace-sample-ratio", "-1"); err == nil {
+		t.Error("OptionsFromFlags() with --trace-sample-ratio -1 error = nil, want error")
+	}
+}
@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newPropagator returns the W3C trace context and baggage propagator.
+func newPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+}
+
+// Propagator returns the W3C trace context and baggage propagator of t. Unlike
+// the OTel global propagator, which is only installed when traces are enabled,
+// it is always set, so a service with traces disabled still passes the trace
+// context of its callers on to the services it calls.
+func (t *Telemetry) Propagator() propagation.TextMapPropagator {
+	return t.propagator
+}
+
+// Inject writes the trace context (and baggage) from ctx into carrier using
+// Propagator, so it can travel with queue messages, webhooks, or custom
+// protocols. carrier must not be nil.
+func (t *Telemetry) Inject(ctx context.Context, carrier map[string]string) {
+	t.propagator.Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns a copy of ctx carrying the trace context (and baggage) read
+// from carrier using Propagator. Spans started from the returned context
+// continue the remote trace.
+func (t *Telemetry) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return t.propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// ContextFromRequest returns the request's context carrying the trace context
+// (and baggage) read from the request headers using Propagator.
+func (t *Telemetry) ContextFromRequest(r *http.Request) context.Context {
+	return t.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+}
+
+// StartServerSpan continues the trace propagated in the request headers and
+// starts a SERVER span for the request, for handlers that don't use the
+// middleware package. The span is named after the http.ServeMux pattern that
+// matched the request (e.g., "GET /users/{id}"), or the method if there is none.
+// The caller must end the span, after recording the response status if wanted:
+//
+//	ctx, span := t.StartServerSpan(r)
+//	defer span.End()
+func (t *Telemetry) StartServerSpan(r *http.Request, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(r.Method),
+		semconv.URLPath(r.URL.Path),
+	}
+
+	name := r.Method
+	if route := requestRoute(r); route != "" {
+		name += " " + route
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+
+	opts = append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	}, opts...)
+	return t.StartSpan(t.ContextFromRequest(r), name, opts...)
+}
+
+// requestRoute returns the path of the http.ServeMux pattern that matched r,
+// without the method and host, or "" if r wasn't routed by a ServeMux.
+func requestRoute(r *http.Request) string {
+	route := r.Pattern
+	if i := strings.IndexAny(route, " \t"); i >= 0 {
+		route = strings.TrimLeft(route[i:], " \t")
+	}
+	if i := strings.Index(route, "/"); i > 0 {
+		route = route[i:]
+	}
+	return route
+}
@@ -0,0 +1,137 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTelemetry_InjectExtract(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	// Traces are disabled, so nothing installs a global propagator; a noop
+	// global must not stop the trace context from being propagated
+	prev := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(prev)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	carrier := map[string]string{}
+	tel.Inject(trace.ContextWithSpanContext(ctx, sc), carrier)
+	if carrier["traceparent"] == "" {
+		t.Fatalf("Inject() carrier = %v, want traceparent", carrier)
+	}
+
+	extracted := trace.SpanContextFromContext(tel.Extract(ctx, carrier))
+	if extracted.TraceID() != sc.TraceID() || extracted.SpanID() != sc.SpanID() {
+		t.Errorf("Extract() span context = %v, want %v", extracted, sc)
+	}
+	if !extracted.IsRemote() {
+		t.Error("Extract() span context is not marked remote")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", carrier["traceparent"])
+	fromRequest := trace.SpanContextFromContext(tel.ContextFromRequest(req))
+	if fromRequest.TraceID() != sc.TraceID() {
+		t.Errorf("ContextFromRequest() trace ID = %v, want %v", fromRequest.TraceID(), sc.TraceID())
+	}
+}
+
+func TestTelemetry_StartServerSpan(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	prev := otel.GetTextMapPropagator()
+	defer otel.SetTextMapPropagator(prev)
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	var remote trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		remote = trace.SpanContextFromContext(tel.ContextFromRequest(r))
+		_, span := tel.StartServerSpan(r)
+		span.End()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	req.Header.Set("traceparent", traceparent)
+	mux.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !remote.IsRemote() ||
+		remote.TraceID().String() != "0af7651916cd43dd8448eb211c80319c" ||
+		remote.SpanID().String() != "b7ad6b7169203331" {
+		t.Errorf("ContextFromRequest() span context = %v, want the traceparent header", remote)
+	}
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	span := got[0]
+	if span.Name != "GET /users/{id}" {
+		t.Errorf("Name = %q, want %q", span.Name, "GET /users/{id}")
+	}
+	if span.SpanKind != trace.SpanKindServer {
+		t.Errorf("SpanKind = %v, want server", span.SpanKind)
+	}
+	if !span.Parent.Equal(remote) {
+		t.Errorf("Parent = %v, want the span context extracted from the request %v", span.Parent, remote)
+	}
+	if span.SpanContext.TraceID() != remote.TraceID() {
+		t.Errorf("TraceID = %v, want %v", span.SpanContext.TraceID(), remote.TraceID())
+	}
+}
+
+func TestRequestRoute(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", ""},
+		{"/users/{id}", "/users/{id}"},
+		{"GET /users/{id}", "/users/{id}"},
+		{"example.com/users/", "/users/"},
+		{"POST example.com/orders", "/orders"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Pattern = tt.pattern
+		if got := requestRoute(r); got != tt.want {
+			t.Errorf("requestRoute(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
-	"go.opentelemetry.io/otel/propagation"
+	otellog "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -23,43 +27,185 @@ import (
 
 // newLoggerProvider creates a new logger provider with the OTLP gRPC exporter.
 // Returns nil if logs are disabled via environment variables.
-func newLoggerProvider(ctx context.Context, res *resource.Resource, batchExport bool) (*log.LoggerProvider, error) {
-	if !shouldEnableLogs() {
+func newLoggerProvider(ctx context.Context, res *resource.Resource, opts *Options) (*log.LoggerProvider, error) {
+	if !shouldEnableLogs() && !opts.enabledByEndpoint("logs") {
 		return nil, nil
 	}
 
-	exporter, err := otlploggrpc.New(ctx)
+	providerOptions := append(enrichLogProcessors(opts), log.WithResource(res))
+	for _, endpointOpts := range opts.otlpEndpointOptions("logs") {
+		exporter, err := newOTLPLogExporter(ctx, endpointOpts)
+		if err != nil {
+			return nil, err
+		}
+		providerOptions = append(providerOptions, log.WithProcessor(newLogProcessor(exporter, opts)))
+	}
+	providerOptions = append(providerOptions, extraLogProcessors(opts)...)
+
+	return log.NewLoggerProvider(providerOptions...), nil
+}
+
+// newLoggerProviderWithExporters creates a logger provider for a comma-separated
+// list of log exporters (e.g., "otlp,fluentforward"), each with its own processor.
+// Returns nil if the list contains no exporters other than "none".
+func newLoggerProviderWithExporters(ctx context.Context, res *resource.Resource, opts *Options, exporters string) (*log.LoggerProvider, error) {
+	var providerOptions []log.LoggerProviderOption
+
+	for _, name := range strings.Split(exporters, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "none" {
+			continue
+		}
+
+		targets := []*Options{opts}
+		if name == "otlp" {
+			targets = opts.otlpEndpointOptions("logs")
+		}
+		for _, target := range targets {
+			exporter, err := newLogExporter(ctx, name, target)
+			if err != nil {
+				return nil, err
+			}
+			providerOptions = append(providerOptions, log.WithProcessor(newLogProcessor(exporter, opts)))
+		}
+	}
+
+	if len(providerOptions) == 0 {
+		return nil, nil
+	}
+	providerOptions = append(enrichLogProcessors(opts), providerOptions...)
+	providerOptions = append(providerOptions, extraLogProcessors(opts)...)
+
+	providerOptions = append(providerOptions, log.WithResource(res))
+	return log.NewLoggerProvider(providerOptions...), nil
+}
+
+// enrichLogProcessors returns the exception processor, the correlation ID
+// processor if CorrelationID is set, the processors from Options.LogProcessors,
+// and, with LogsAsSpanEvents, the span event processor as provider options, to
+// be registered ahead of the exporting processors.
+func enrichLogProcessors(opts *Options) []log.LoggerProviderOption {
+	providerOptions := []log.LoggerProviderOption{
+		log.WithProcessor(&exceptionProcessor{stackTraces: opts.ExceptionStackTraces}),
+	}
+	if opts.CorrelationID {
+		providerOptions = append(providerOptions, log.WithProcessor(&correlationLogProcessor{}))
+	}
+	if opts.LogProcessors != nil {
+		for _, processor := range opts.LogProcessors() {
+			providerOptions = append(providerOptions, log.WithProcessor(processor))
+		}
+	}
+	if opts.LogsAsSpanEvents {
+		// Span events follow the same severity thresholds as exported records
+		providerOptions = append(providerOptions, log.WithProcessor(&severityProcessor{
+			Processor: &spanEventProcessor{},
+			min:       opts.LogsMinSeverity,
+			levels:    opts.levels,
+		}))
+	}
+	return providerOptions
+}
+
+// extraLogProcessors returns the processors for Options.CustomLogExporter and
+// Sentry, which are used alongside the configured log exporters.
+func extraLogProcessors(opts *Options) []log.LoggerProviderOption {
+	var providerOptions []log.LoggerProviderOption
+	if opts.CustomLogExporter != nil {
+		providerOptions = append(providerOptions, log.WithProcessor(newLogProcessor(opts.CustomLogExporter, opts)))
+	}
+	if opts.sentry != nil {
+		providerOptions = append(providerOptions, log.WithProcessor(opts.sentry.logProcessor()))
+	}
+	return providerOptions
+}
+
+// newLogExporter creates the named log exporter.
+func newLogExporter(ctx context.Context, name string, opts *Options) (log.Exporter, error) {
+	switch name {
+	case "otlp":
+		return newOTLPLogExporter(ctx, opts)
+
+	case "fluentforward":
+		tag := opts.FluentForwardTag
+		if tag == "" {
+			tag = opts.ServiceName
+		}
+		return newFluentForwardExporter(opts.FluentForwardAddress, tag, opts.FluentForwardRequireAck), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported logs exporter: %s (supported: otlp, fluentforward, none)", name)
+	}
+}
+
+// newOTLPLogExporter creates the OTLP gRPC log exporter, using Options.GRPCConn
+// or KafkaProducer if set, or the spool if Options.SpoolDir is set.
+func newOTLPLogExporter(ctx context.Context, opts *Options) (log.Exporter, error) {
+	var exporterOptions []otlploggrpc.Option
+	if conn := opts.otlpConn(); conn != nil {
+		exporterOptions = append(exporterOptions, otlploggrpc.WithGRPCConn(conn))
+	} else if opts.spool != nil {
+		exporterOptions = append(exporterOptions, otlploggrpc.WithDialOption(opts.spool.dialOption("logs", opts.otlpTarget("logs"))))
+	}
+	if opts.otlpConn() == nil {
+		endpoint, insecure := opts.otlpSignalEndpoint("logs")
+		if strings.Contains(endpoint, "://") {
+			exporterOptions = append(exporterOptions, otlploggrpc.WithEndpointURL(endpoint))
+		} else if endpoint != "" {
+			exporterOptions = append(exporterOptions, otlploggrpc.WithEndpoint(endpoint))
+		}
+		if insecure {
+			exporterOptions = append(exporterOptions, otlploggrpc.WithInsecure())
+		}
+	}
+
+	exporter, err := otlploggrpc.New(ctx, exporterOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
 	}
+	opts.health.register("logs")
+	return &healthLogExporter{Exporter: exporter, health: opts.health}, nil
+}
 
-	// Choose processor based on batchExport option
+// newLogProcessor wraps the exporter in a processor based on the BatchExport
+// and LogsBatchExport options, in an asyncProcessor if AsyncLogs is set, in a spanEventFilter if
+// LogsAsSpanEvents is set, and in a severityProcessor if LogsMinSeverity is set.
+func newLogProcessor(exporter log.Exporter, opts *Options) log.Processor {
 	var processor log.Processor
-	if batchExport {
+	if opts.BatchExport || opts.LogsBatchExport {
 		// BatchProcessor for higher throughput, lower resource usage (with latency)
-		processor = log.NewBatchProcessor(exporter)
+		processor = log.NewBatchProcessor(exporter, batchLogOptions()...)
 	} else {
 		// SimpleProcessor for immediate export without delays
 		processor = log.NewSimpleProcessor(exporter)
 	}
 
-	lp := log.NewLoggerProvider(
-		log.WithProcessor(processor),
-		log.WithResource(res),
-	)
+	if opts.AsyncLogs {
+		// Keep a stalled exporter out of the logging hot path
+		processor = newAsyncProcessor(processor, opts.AsyncLogQueueSize, opts.meterProvider)
+	}
+
+	if opts.LogsAsSpanEvents {
+		// Records within a span were recorded as span events instead
+		processor = &spanEventFilter{Processor: processor}
+	}
 
-	return lp, nil
+	if opts.LogsMinSeverity != otellog.SeverityUndefined || opts.levels != nil {
+		// Outermost, so dropped records are never queued or copied
+		processor = &severityProcessor{Processor: processor, min: opts.LogsMinSeverity, levels: opts.levels}
+	}
+	return processor
 }
 
 // newMeterProvider creates a new meter provider with the OTLP gRPC exporter.
 // Returns nil if metrics are disabled via environment variables.
 // Deprecated: Use newOTLPReader instead for better composability.
-func newMeterProvider(ctx context.Context, res *resource.Resource, batchExport bool) (*metric.MeterProvider, error) {
+func newMeterProvider(ctx context.Context, res *resource.Resource, opts *Options) (*metric.MeterProvider, error) {
 	if !shouldEnableMetrics() {
 		return nil, nil
 	}
 
-	reader, err := newOTLPReader(ctx, batchExport)
+	reader, err := newOTLPReader(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -73,24 +219,44 @@ func newMeterProvider(ctx context.Context, res *resource.Resource, batchExport b
 	return mp, nil
 }
 
-// newOTLPReader creates an OTLP metric reader with the gRPC exporter.
+// newOTLPReader creates an OTLP metric reader with the gRPC exporter, using
+// Options.GRPCConn or KafkaProducer if set, or the spool if Options.SpoolDir is set.
 // Returns a Reader that can be used with a MeterProvider.
-func newOTLPReader(ctx context.Context, batchExport bool) (metric.Reader, error) {
-	exporter, err := otlpmetricgrpc.New(ctx)
+func newOTLPReader(ctx context.Context, opts *Options) (metric.Reader, error) {
+	var exporterOptions []otlpmetricgrpc.Option
+	if conn := opts.otlpConn(); conn != nil {
+		exporterOptions = append(exporterOptions, otlpmetricgrpc.WithGRPCConn(conn))
+	} else if opts.spool != nil {
+		exporterOptions = append(exporterOptions, otlpmetricgrpc.WithDialOption(opts.spool.dialOption("metrics", opts.otlpTarget("metrics"))))
+	}
+	if opts.otlpConn() == nil {
+		endpoint, insecure := opts.otlpSignalEndpoint("metrics")
+		if strings.Contains(endpoint, "://") {
+			exporterOptions = append(exporterOptions, otlpmetricgrpc.WithEndpointURL(endpoint))
+		} else if endpoint != "" {
+			exporterOptions = append(exporterOptions, otlpmetricgrpc.WithEndpoint(endpoint))
+		}
+		if insecure {
+			exporterOptions = append(exporterOptions, otlpmetricgrpc.WithInsecure())
+		}
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, exporterOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
 	}
+	opts.health.register("metrics")
 
 	// Note: Metrics use PeriodicReader by default which is always batched.
-	// The batchExport flag doesn't significantly affect metrics since they're
-	// inherently periodic/batched by design. We keep the parameter for consistency.
-	reader := metric.NewPeriodicReader(exporter)
+	// The BatchExport option doesn't significantly affect metrics since they're
+	// inherently periodic/batched by design.
+	reader := metric.NewPeriodicReader(&healthMetricExporter{Exporter: exporter, health: opts.health}, periodicReaderOptions()...)
 	return reader, nil
 }
 
 // newPrometheusReader creates a Prometheus metric reader and HTTP handler.
 // Returns the Reader and an HTTP handler for the /metrics endpoint.
-func newPrometheusReader(res *resource.Resource) (metric.Reader, http.Handler, error) {
+func newPrometheusReader(res *resource.Resource, opts *Options) (metric.Reader, http.Handler, error) {
 	// Create a Prometheus registry
 	registry := prometheus.NewRegistry()
 
@@ -103,57 +269,156 @@ func newPrometheusReader(res *resource.Resource) (metric.Reader, http.Handler, e
 	}
 
 	// Create HTTP handler from the registry
-	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics:   opts.PrometheusOpenMetrics || opts.PrometheusExemplars,
+		Timeout:             opts.PrometheusTimeout,
+		MaxRequestsInFlight: opts.PrometheusMaxRequestsInFlight,
+	})
 
 	return exporter, handler, nil
 }
 
-// newTracerProvider creates a new tracer provider with the OTLP gRPC exporter.
-// Returns nil if traces are disabled via environment variables.
-func newTracerProvider(ctx context.Context, res *resource.Resource, batchExport bool) (*trace.TracerProvider, error) {
-	if !shouldEnableTraces() {
+// newTracerProvider creates a new tracer provider with the OTLP gRPC exporter
+// and Options.CustomSpanExporter.
+// Returns nil if traces are disabled via environment variables and no custom
+// exporter is set.
+func newTracerProvider(ctx context.Context, res *resource.Resource, opts *Options) (*trace.TracerProvider, error) {
+	var exporters []trace.SpanExporter
+	if opts.otlpTracesEnabled() {
+		for _, endpointOpts := range opts.otlpEndpointOptions("traces") {
+			exporter, err := newOTLPSpanExporter(ctx, endpointOpts)
+			if err != nil {
+				return nil, err
+			}
+			exporters = append(exporters, exporter)
+		}
+	}
+	if opts.CustomSpanExporter != nil && !sdkDisabled() {
+		exporters = append(exporters, opts.CustomSpanExporter)
+	}
+	if len(exporters) == 0 {
 		return nil, nil
 	}
 
-	exporter, err := otlptracegrpc.New(ctx)
+	providerOptions := []trace.TracerProviderOption{trace.WithResource(res)}
+	guard, err := newSpanNameGuard(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		return nil, err
 	}
-
-	var tp *trace.TracerProvider
-	if batchExport {
-		// Use batcher for batched export (default OTel behavior)
-		tp = trace.NewTracerProvider(
-			trace.WithBatcher(exporter),
-			trace.WithResource(res),
-		)
-	} else {
-		// Use syncer for immediate export
-		tp = trace.NewTracerProvider(
-			trace.WithSyncer(exporter),
-			trace.WithResource(res),
-		)
+	if guard != nil {
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(guard))
+	}
+	if opts.CorrelationID {
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(&correlationSpanProcessor{}))
+	}
+	if opts.SpanProcessors != nil {
+		for _, processor := range opts.SpanProcessors() {
+			providerOptions = append(providerOptions, trace.WithSpanProcessor(processor))
+		}
+	}
+	for _, exporter := range exporters {
+		if opts.BatchExport || opts.TracesBatchExport {
+			// Use batcher for batched export (default OTel behavior)
+			providerOptions = append(providerOptions, trace.WithBatcher(exporter, batchSpanOptions()...))
+		} else {
+			// Use syncer for immediate export
+			providerOptions = append(providerOptions, trace.WithSyncer(exporter))
+		}
+	}
+	if opts.sentry != nil {
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(opts.sentry.spanProcessor()))
+	}
+	sampler, err := newSampler(opts)
+	if err != nil {
+		return nil, err
+	}
+	if sampler != nil {
+		providerOptions = append(providerOptions, trace.WithSampler(sampler))
 	}
+	tp := trace.NewTracerProvider(providerOptions...)
 
 	otel.SetTracerProvider(tp)
 
 	// Set up propagators to extract trace context from incoming requests
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-		propagation.TraceContext{},
-		propagation.Baggage{},
-	))
+	otel.SetTextMapPropagator(newPropagator())
 
 	return tp, nil
 }
 
-// newResource creates a new OTEL resource with the service name and version.
-func newResource(serviceName string, serviceVersion string) *resource.Resource {
+// newOTLPSpanExporter creates the OTLP gRPC span exporter, using Options.GRPCConn
+// or KafkaProducer if set, or the spool if Options.SpoolDir is set.
+func newOTLPSpanExporter(ctx context.Context, opts *Options) (trace.SpanExporter, error) {
+	var exporterOptions []otlptracegrpc.Option
+	if conn := opts.otlpConn(); conn != nil {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithGRPCConn(conn))
+	} else if opts.spool != nil {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithDialOption(opts.spool.dialOption("traces", opts.otlpTarget("traces"))))
+	}
+	if opts.otlpConn() == nil {
+		endpoint, insecure := opts.otlpSignalEndpoint("traces")
+		if strings.Contains(endpoint, "://") {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithEndpointURL(endpoint))
+		} else if endpoint != "" {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if insecure {
+			exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+		}
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	opts.health.register("traces")
+	return &healthSpanExporter{SpanExporter: exporter, health: opts.health}, nil
+}
+
+// processInstanceID is the service.instance.id used when Options.InstanceID is empty.
+// It is generated once, so it stays the same across Reconfigure calls.
+var processInstanceID = sync.OnceValue(uuid.NewString)
+
+// resourceAttributes returns the resource attributes set in Options beyond the
+// service name and version.
+func (o *Options) resourceAttributes() []attribute.KeyValue {
+	instanceID := o.InstanceID
+	if instanceID == "" {
+		instanceID = processInstanceID()
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceInstanceID(instanceID)}
+	if o.Namespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespace(o.Namespace))
+	}
+	if o.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironment(o.Environment))
+	}
+	if o.EnableBuildInfo {
+		attrs = append(attrs, readBuildInfo().attributes()...)
+	}
+	if o.DetectPlatform {
+		if id := detectContainerID(); id != "" {
+			attrs = append(attrs, semconv.ContainerID(id))
+		}
+		attrs = append(attrs, faasAttributes()...)
+	}
+	if o.DetectKubernetes {
+		attrs = append(attrs, kubernetesAttributes(o.KubernetesPodInfoDir)...)
+	}
+	return append(attrs, o.ResourceAttributes...)
+}
+
+// newResource creates a new OTEL resource with the service name and version,
+// and any additional attributes.
+func newResource(serviceName string, serviceVersion string, attrs ...attribute.KeyValue) *resource.Resource {
 	hostName, _ := os.Hostname()
 
 	return resource.NewWithAttributes(
 		semconv.SchemaURL,
-		semconv.ServiceName(serviceName),
-		semconv.ServiceVersion(serviceVersion),
-		semconv.HostName(hostName),
+		append([]attribute.KeyValue{
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(serviceVersion),
+			semconv.HostName(hostName),
+		}, attrs...)...,
 	)
 }
@@ -2,8 +2,16 @@ package telemetry
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
 )
 
 func TestNewResource(t *testing.T) {
@@ -52,6 +60,63 @@ func TestNewResource(t *testing.T) {
 	}
 }
 
+func TestOptions_resourceAttributes(t *testing.T) {
+	opts := &Options{Namespace: "shop", Environment: "production"}
+	res := newResource("test-service", "1.0.0", opts.resourceAttributes()...)
+
+	get := func(key string) string {
+		value, _ := res.Set().Value(attribute.Key(key))
+		return value.AsString()
+	}
+	if got := get("service.namespace"); got != "shop" {
+		t.Errorf("service.namespace = %q, want shop", got)
+	}
+	if got := get("deployment.environment"); got != "production" {
+		t.Errorf("deployment.environment = %q, want production", got)
+	}
+
+	// A generated instance ID is stable for the process
+	instanceID := get("service.instance.id")
+	if instanceID == "" {
+		t.Fatal("service.instance.id is empty, want a generated ID")
+	}
+	res = newResource("test-service", "1.0.0", (&Options{}).resourceAttributes()...)
+	if got := get("service.instance.id"); got != instanceID {
+		t.Errorf("service.instance.id = %q, want %q to be reused", got, instanceID)
+	}
+
+	res = newResource("test-service", "1.0.0", (&Options{InstanceID: "pod-1"}).resourceAttributes()...)
+	if got := get("service.instance.id"); got != "pod-1" {
+		t.Errorf("service.instance.id = %q, want pod-1", got)
+	}
+	if _, ok := res.Set().Value("service.namespace"); ok {
+		t.Error("service.namespace is set, want it omitted when Namespace is empty")
+	}
+	if _, ok := res.Set().Value("container.id"); ok {
+		t.Error("container.id is set, want it omitted unless DetectPlatform is set")
+	}
+
+	res = newResource("test-service", "1.0.0", (&Options{DetectPlatform: true}).resourceAttributes()...)
+	if got, want := get("container.id"), detectContainerID(); got != want {
+		t.Errorf("container.id with DetectPlatform = %q, want %q", got, want)
+	}
+}
+
+func TestOptions_resourceAttributes_FaaS(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "checkout")
+	t.Setenv("K_SERVICE", "")
+
+	res := newResource("test-service", "1.0.0", (&Options{}).resourceAttributes()...)
+	if _, ok := res.Set().Value("faas.name"); ok {
+		t.Error("faas.name is set, want it omitted unless DetectPlatform is set")
+	}
+
+	res = newResource("test-service", "1.0.0", (&Options{DetectPlatform: true}).resourceAttributes()...)
+	if got, _ := res.Set().Value("faas.name"); got.AsString() != "checkout" {
+		t.Errorf("faas.name with DetectPlatform = %q, want checkout", got.AsString())
+	}
+}
+
 func TestNewLoggerProvider(t *testing.T) {
 	ctx := context.Background()
 
@@ -86,7 +151,7 @@ func TestNewLoggerProvider(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			lp, err := newLoggerProvider(ctx, res, tt.batchExport)
+			lp, err := newLoggerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			if err != nil {
 				// Note: Error is expected when trying to connect to non-existent endpoint
@@ -135,7 +200,7 @@ func TestNewTracerProvider(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			tp, err := newTracerProvider(ctx, res, tt.batchExport)
+			tp, err := newTracerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			if err != nil {
 				// Note: Error is expected when trying to connect to non-existent endpoint
@@ -183,7 +248,7 @@ func TestNewMeterProvider(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			mp, err := newMeterProvider(ctx, res, tt.batchExport)
+			mp, err := newMeterProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			if err != nil {
 				// Note: Error is expected when trying to connect to non-existent endpoint
@@ -222,22 +287,47 @@ func TestProvidersBatchMode(t *testing.T) {
 
 			// Note: These will return errors because no endpoint is running,
 			// but we're testing that the functions accept the batchExport parameter
-			_, err := newLoggerProvider(ctx, res, tt.batchExport)
+			_, err := newLoggerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 			t.Logf("newLoggerProvider(batch=%v) error: %v", tt.batchExport, err)
 
-			_, err = newTracerProvider(ctx, res, tt.batchExport)
+			_, err = newTracerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 			t.Logf("newTracerProvider(batch=%v) error: %v", tt.batchExport, err)
 
-			_, err = newMeterProvider(ctx, res, tt.batchExport)
+			_, err = newMeterProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 			t.Logf("newMeterProvider(batch=%v) error: %v", tt.batchExport, err)
 		})
 	}
 }
 
+func TestNewLogProcessor_BatchPerSignal(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      *Options
+		wantBatch bool
+	}{
+		{name: "simple by default", opts: &Options{}},
+		{name: "BatchExport", opts: &Options{BatchExport: true}, wantBatch: true},
+		{name: "LogsBatchExport", opts: &Options{LogsBatchExport: true}, wantBatch: true},
+		{name: "TracesBatchExport only", opts: &Options{TracesBatchExport: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := newLogProcessor(&telemetrytest.LogRecorder{}, tt.opts)
+			defer processor.Shutdown(context.Background())
+
+			_, batch := processor.(*sdklog.BatchProcessor)
+			if batch != tt.wantBatch {
+				t.Errorf("newLogProcessor() = %T, want batch = %v", processor, tt.wantBatch)
+			}
+		})
+	}
+}
+
 func TestNewPrometheusReader(t *testing.T) {
 	res := newResource("test-service", "1.0.0")
 
-	reader, handler, err := newPrometheusReader(res)
+	reader, handler, err := newPrometheusReader(res, &Options{})
 	if err != nil {
 		t.Fatalf("newPrometheusReader() failed: %v", err)
 	}
@@ -256,6 +346,37 @@ func TestNewPrometheusReader(t *testing.T) {
 	}
 }
 
+func TestNewPrometheusReader_OpenMetrics(t *testing.T) {
+	res := newResource("test-service", "1.0.0")
+
+	tests := []struct {
+		name        string
+		openMetrics bool
+		wantType    string
+	}{
+		{name: "disabled", openMetrics: false, wantType: "text/plain"},
+		{name: "enabled", openMetrics: true, wantType: "application/openmetrics-text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handler, err := newPrometheusReader(res, &Options{PrometheusOpenMetrics: tt.openMetrics})
+			if err != nil {
+				t.Fatalf("newPrometheusReader() failed: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.Header.Set("Accept", "application/openmetrics-text;version=1.0.0")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantType) {
+				t.Errorf("Content-Type = %q, want %s", got, tt.wantType)
+			}
+		})
+	}
+}
+
 func TestNewOTLPReader(t *testing.T) {
 	ctx := context.Background()
 
@@ -277,7 +398,7 @@ func TestNewOTLPReader(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Note: This will likely fail because no OTLP endpoint is running
 			// but we're testing that the function creates a reader correctly
-			reader, err := newOTLPReader(ctx, tt.batchExport)
+			reader, err := newOTLPReader(ctx, &Options{BatchExport: tt.batchExport})
 
 			// Error is expected when no endpoint is available
 			if err != nil {
@@ -346,7 +467,7 @@ func TestNewLoggerProvider_WithOTelEnabled(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			lp, err := newLoggerProvider(ctx, res, tt.batchExport)
+			lp, err := newLoggerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			// Error is expected when trying to connect to non-existent endpoint
 			if err != nil {
@@ -415,7 +536,7 @@ func TestNewTracerProvider_WithOTelEnabled(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			tp, err := newTracerProvider(ctx, res, tt.batchExport)
+			tp, err := newTracerProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			// Error is expected when trying to connect to non-existent endpoint
 			if err != nil {
@@ -484,7 +605,7 @@ func TestNewMeterProvider_WithOTelEnabled(t *testing.T) {
 			}
 
 			res := newResource("test-service", "1.0.0")
-			mp, err := newMeterProvider(ctx, res, tt.batchExport)
+			mp, err := newMeterProvider(ctx, res, &Options{BatchExport: tt.batchExport})
 
 			// Error is expected when trying to connect to non-existent endpoint
 			if err != nil {
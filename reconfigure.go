@@ -0,0 +1,251 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	otellog "go.opentelemetry.io/otel/log"
+	logembedded "go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+	traceembedded "go.opentelemetry.io/otel/trace/embedded"
+)
+
+// Reconfigure rebuilds the providers from opts (e.g., a new endpoint, exporter,
+// or sampling setting) and swaps them in without restarting the process. The
+// previous providers are flushed and shut down once the new ones are in place.
+// If opts is nil, default options with environment variable overrides are used.
+//
+// Handles created before a Reconfigure keep working: the logger and tracer
+// returned by Logger() and Tracer(), spans started with StartSpan, the logger
+// provider returned by LoggerProvider() (and hooks created from it), the
+// instruments returned by Counter, Histogram, and the related shortcuts, and
+// the handler returned by PrometheusHandler() all forward to the current
+// configuration. The meter provider and tracer provider returned by
+// MeterProvider() and TracerProvider(), and meters, tracers, or instruments
+// created from them or from Meter(), belong to the configuration they were
+// created in.
+//
+// Concurrent calls are serialized.
+//
+// If building the new providers fails, the current configuration is kept and
+// the error is returned. A Telemetry derived with WithAttributes can't be
+// reconfigured.
+func (t *Telemetry) Reconfigure(ctx context.Context, opts *Options) error {
+	if t.owner != nil {
+		return errors.New("failed to reconfigure telemetry: a Telemetry derived with WithAttributes can't be reconfigured")
+	}
+	t.reconfigureMu.Lock()
+	defer t.reconfigureMu.Unlock()
+
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	cfg := *opts
+	opts = &cfg
+	if err := opts.applyOverrides(); err != nil {
+		return fmt.Errorf("failed to reconfigure telemetry: %w", err)
+	}
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("failed to reconfigure telemetry: %w", err)
+	}
+
+	// The built-in Prometheus server binds a fixed port, so a server on the same
+	// port is handed over to the new configuration instead of being restarted
+	t.mu.RLock()
+	running := t.promServer
+	t.mu.RUnlock()
+
+	next, err := newWithOptions(ctx, opts, running)
+	if err != nil {
+		return fmt.Errorf("failed to reconfigure telemetry: %w", err)
+	}
+
+	t.mu.Lock()
+	prev := &Telemetry{
+		cfg:        t.cfg,
+		lp:         t.lp,
+		mp:         t.mp,
+		tp:         t.tp,
+		promServer: t.promServer,
+		kafkaConn:  t.kafkaConn,
+		spool:      t.spool,
+	}
+	if running != nil && next.promServer == running {
+		// The server now belongs to the new configuration
+		running.Handler.(*promServerHandler).mux.Store(next.promMux)
+		prev.promServer = nil
+	}
+	t.cfg = next.cfg
+	t.lp = next.lp
+	t.mp = next.mp
+	t.tp = next.tp
+	t.logger = next.logger
+	t.tracer = next.tracer
+	t.promServer = next.promServer
+	t.promHandler = next.promHandler
+	t.promMux = next.promMux
+	t.manualReader = next.manualReader
+	t.health = next.health
+	t.levels = next.levels
+	t.kafkaConn = next.kafkaConn
+	t.spool = next.spool
+	t.endpoints = next.endpoints
+	t.mu.Unlock()
+
+	// Move the cached instruments to the new meter before the old one stops
+	t.instrumentCache().rebind(next.instruments.meter)
+
+	installContribBridges(t, opts)
+
+	return prev.Shutdown(ctx)
+}
+
+// currentLogger returns the logger of the current configuration.
+func (t *Telemetry) currentLogger() otellog.Logger {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.logger
+}
+
+// currentTracer returns the tracer of the current configuration.
+func (t *Telemetry) currentTracer() trace.Tracer {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tracer
+}
+
+// reconfigurableLogger is an OTel logger that forwards to the logger of the
+// current configuration, so it stays valid across Reconfigure.
+type reconfigurableLogger struct {
+	logembedded.Logger
+	t *Telemetry
+}
+
+func (l *reconfigurableLogger) Emit(ctx context.Context, record otellog.Record) {
+	l.t.currentLogger().Emit(ctx, record)
+}
+
+func (l *reconfigurableLogger) Enabled(ctx context.Context, param otellog.EnabledParameters) bool {
+	return l.t.currentLogger().Enabled(ctx, param)
+}
+
+// reconfigurableTracer is a tracer that forwards to the tracer of the current
+// configuration, so it stays valid across Reconfigure.
+type reconfigurableTracer struct {
+	traceembedded.Tracer
+	t *Telemetry
+}
+
+func (r *reconfigurableTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return r.t.currentTracer().Start(ctx, name, opts...)
+}
+
+// currentLoggerProvider returns the logger provider of the current
+// configuration, or nil if logs are disabled.
+func (t *Telemetry) currentLoggerProvider() *sdklog.LoggerProvider {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.lp
+}
+
+// newReconfigurableLoggerProvider returns the logger provider handed out by
+// LoggerProvider. Hooks need an SDK logger provider, so this one lives as long
+// as t and re-emits every record on the logger provider of the current
+// configuration, keeping hooks valid across Reconfigure.
+func newReconfigurableLoggerProvider(t *Telemetry) *sdklog.LoggerProvider {
+	return sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(&reconfigurableProcessor{t: t}),
+		// The current configuration applies its own limits
+		sdklog.WithAttributeCountLimit(-1),
+	)
+}
+
+// reconfigurableProcessor re-emits records on the logger with the same
+// instrumentation scope from the current logger provider, and drops them
+// while logs are disabled.
+type reconfigurableProcessor struct {
+	t *Telemetry
+}
+
+// current returns the logger for scope from the current logger provider, or
+// nil if logs are disabled. The SDK caches loggers per scope, so this is a
+// map lookup.
+func (p *reconfigurableProcessor) current(scope instrumentation.Scope) otellog.Logger {
+	lp := p.t.currentLoggerProvider()
+	if lp == nil {
+		return nil
+	}
+	return lp.Logger(scope.Name,
+		otellog.WithInstrumentationVersion(scope.Version),
+		otellog.WithSchemaURL(scope.SchemaURL),
+		otellog.WithInstrumentationAttributes(scope.Attributes.ToSlice()...),
+	)
+}
+
+func (p *reconfigurableProcessor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	logger := p.current(param.InstrumentationScope)
+	return logger != nil && logger.Enabled(ctx, otellog.EnabledParameters{
+		Severity:  param.Severity,
+		EventName: param.EventName,
+	})
+}
+
+func (p *reconfigurableProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	logger := p.current(record.InstrumentationScope())
+	if logger == nil {
+		return nil
+	}
+
+	var r otellog.Record
+	r.SetTimestamp(record.Timestamp())
+	r.SetObservedTimestamp(record.ObservedTimestamp())
+	r.SetSeverity(record.Severity())
+	r.SetSeverityText(record.SeverityText())
+	r.SetBody(record.Body())
+	r.SetEventName(record.EventName())
+	attrs := make([]otellog.KeyValue, 0, record.AttributesLen())
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs = append(attrs, kv)
+		return true
+	})
+	r.AddAttributes(attrs...)
+
+	logger.Emit(ctx, r)
+	return nil
+}
+
+// Shutdown is a no-op: the current logger provider is shut down with its
+// configuration.
+func (p *reconfigurableProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (p *reconfigurableProcessor) ForceFlush(ctx context.Context) error {
+	lp := p.t.currentLoggerProvider()
+	if lp == nil {
+		return nil
+	}
+	return lp.ForceFlush(ctx)
+}
+
+// reconfigurablePromHandler serves the Prometheus handler of the current
+// configuration, so the handler returned by PrometheusHandler stays valid
+// across Reconfigure.
+type reconfigurablePromHandler struct {
+	t *Telemetry
+}
+
+func (h *reconfigurablePromHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.t.mu.RLock()
+	handler := h.t.promHandler
+	h.t.mu.RUnlock()
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
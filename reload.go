@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSIGHUP starts watching for SIGHUP and, on each signal, re-reads the
+// environment variables and applies them with Reconfigure. This suits
+// long-running daemons whose configuration is pushed by an external system
+// that updates the environment (log level, sampling ratio, endpoints) and
+// signals the process.
+//
+// opts is the base configuration for every reload; environment variables are
+// applied on top of a copy of it, so it is never modified. If opts is nil,
+// default options are used. Reload errors are passed to onError, or written
+// to stderr if onError is nil; the current configuration is kept on error.
+//
+// Watching stops when ctx is done or the returned stop function is called.
+func (t *Telemetry) ReloadOnSIGHUP(ctx context.Context, opts *Options, onError func(error)) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	stop = t.watchReload(ctx, signals, opts, onError)
+	return func() {
+		signal.Stop(signals)
+		stop()
+	}
+}
+
+// watchReload reconfigures t from opts and the environment each time a value
+// is received on signals, until ctx is done or the returned function is called.
+func (t *Telemetry) watchReload(ctx context.Context, signals <-chan os.Signal, opts *Options, onError func(error)) func() {
+	if onError == nil {
+		onError = func(err error) {
+			fmt.Fprintf(os.Stderr, "telemetry reload error: %v\n", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				next := DefaultOptions()
+				if opts != nil {
+					*next = *opts
+				}
+				if err := t.Reconfigure(ctx, next); err != nil {
+					onError(err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,103 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_watchReload(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	opts := &Options{ServiceName: "reload-service", LogsExporter: "fluentforward"}
+
+	tel, err := New(ctx, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	info := otellog.EnabledParameters{Severity: otellog.SeverityInfo}
+	if !tel.Logger().Enabled(ctx, info) {
+		t.Fatal("Logger().Enabled(info) = false before reload, want true")
+	}
+
+	signals := make(chan os.Signal, 1)
+	stop := tel.watchReload(ctx, signals, opts, func(err error) {
+		t.Errorf("reload error = %v", err)
+	})
+	defer stop()
+
+	os.Setenv("LOGS_MIN_SEVERITY", "error")
+	signals <- syscall.SIGHUP
+
+	deadline := time.Now().Add(5 * time.Second)
+	for tel.Logger().Enabled(ctx, info) {
+		if time.Now().After(deadline) {
+			t.Fatal("Logger().Enabled(info) = true after reload, want false")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := tel.Diagnostics().Options.LogsMinSeverity; got != otellog.SeverityError {
+		t.Errorf("LogsMinSeverity after reload = %v, want %v", got, otellog.SeverityError)
+	}
+	if opts.LogsMinSeverity != otellog.SeverityUndefined {
+		t.Errorf("base opts.LogsMinSeverity = %v, want unchanged", opts.LogsMinSeverity)
+	}
+}
+
+func TestTelemetry_watchReload_KeepsHooks(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	recorder := &telemetrytest.LogRecorder{}
+	opts := &Options{ServiceName: "reload-service", CustomLogExporter: recorder}
+
+	tel, err := New(ctx, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	// A hook keeps the logger provider it was created with
+	hook := tel.LoggerProvider().Logger("hook")
+	emit := func(msg string, severity otellog.Severity) {
+		var record otellog.Record
+		record.SetBody(otellog.StringValue(msg))
+		record.SetSeverity(severity)
+		hook.Emit(ctx, record)
+	}
+
+	signals := make(chan os.Signal, 1)
+	stop := tel.watchReload(ctx, signals, opts, func(err error) {
+		t.Errorf("reload error = %v", err)
+	})
+	defer stop()
+
+	os.Setenv("LOGS_MIN_SEVERITY", "warn")
+	signals <- syscall.SIGHUP
+
+	info := otellog.EnabledParameters{Severity: otellog.SeverityInfo}
+	deadline := time.Now().Add(5 * time.Second)
+	for hook.Enabled(ctx, info) {
+		if time.Now().After(deadline) {
+			t.Fatal("hook Enabled(info) = true after reload, want false")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	emit("after reload", otellog.SeverityWarn)
+	emit("filtered after reload", otellog.SeverityInfo)
+	recorder.AssertLogged(t, telemetrytest.WithMessage("after reload"), telemetrytest.WithScope("hook"))
+	recorder.AssertNotLogged(t, telemetrytest.WithMessage("filtered after reload"))
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// requestMetricsMeterName is the instrumentation scope of the request metrics,
+// kept apart from the pipeline's own metrics.
+const requestMetricsMeterName = "github.com/ekristen/go-telemetry/v2/requestmetrics"
+
+// RequestMetrics records RED metrics (rate, errors, duration) for requests
+// handled by a component with a single histogram, <prefix>.request.duration
+// (s), following the semantic conventions for http.server.request.duration:
+// the rate is the histogram count, and failed requests are the ones with
+// error.type set.
+//
+// The histogram is created on first use and recreated after Reconfigure.
+// It is safe for concurrent use.
+//
+//	rm := telemetry.NewRequestMetrics(t, "rpc.server")
+//	start := time.Now()
+//	err := handle(ctx, req)
+//	rm.Record(ctx, time.Since(start), err, attribute.String("rpc.method", "Checkout"))
+type RequestMetrics struct {
+	t      *Telemetry
+	prefix string
+
+	mu       sync.Mutex
+	provider metric.MeterProvider
+	duration metric.Float64Histogram
+}
+
+// NewRequestMetrics returns RequestMetrics recording with t's meter provider,
+// with the histogram name starting with prefix (e.g., "http.server" or "rpc.server").
+func NewRequestMetrics(t *Telemetry, prefix string) *RequestMetrics {
+	return &RequestMetrics{t: t, prefix: prefix}
+}
+
+// Record records one request that took duration and failed with err, if not
+// nil. attrs (e.g., the route or method) are attached to the measurement; a
+// failed request also gets error.type set to the type of err.
+func (m *RequestMetrics) Record(ctx context.Context, duration time.Duration, err error, attrs ...attribute.KeyValue) {
+	errorType := ""
+	if err != nil {
+		errorType = fmt.Sprintf("%T", err)
+	}
+	m.RecordErrorType(ctx, duration, errorType, attrs...)
+}
+
+// RecordErrorType records one request that took duration and failed with
+// errorType, if not empty, as error.type. Use it when the failure is not a Go
+// error, e.g. the status code "500" of an HTTP response.
+func (m *RequestMetrics) RecordErrorType(ctx context.Context, duration time.Duration, errorType string, attrs ...attribute.KeyValue) {
+	if errorType != "" {
+		attrs = append(attrs[:len(attrs):len(attrs)], semconv.ErrorTypeKey.String(errorType))
+	}
+	m.histogram().Record(ctx, duration.Seconds(), metric.WithAttributeSet(attribute.NewSet(attrs...)))
+}
+
+// histogram returns the histogram for the current meter provider, creating it if needed.
+func (m *RequestMetrics) histogram() metric.Float64Histogram {
+	var provider metric.MeterProvider = metricnoop.NewMeterProvider()
+	if mp := m.t.MeterProvider(); mp != nil {
+		provider = mp
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.duration != nil && m.provider == provider {
+		return m.duration
+	}
+
+	duration, err := provider.Meter(requestMetricsMeterName).Float64Histogram(m.prefix+".request.duration",
+		metric.WithDescription("Duration of requests."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m.provider, m.duration = provider, duration
+	return duration
+}
@@ -0,0 +1,115 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	rm := NewRequestMetrics(tel, "rpc.server")
+	route := attribute.String("rpc.method", "Checkout")
+	rm.Record(ctx, 100*time.Millisecond, nil, route)
+	rm.Record(ctx, 300*time.Millisecond, errors.New("boom"), route)
+
+	collected, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range collected.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+
+	for _, name := range []string{"rpc.server.requests", "rpc.server.errors"} {
+		if _, ok := metrics[name]; ok {
+			t.Errorf("%s was recorded, want only the duration histogram", name)
+		}
+	}
+
+	duration, ok := metrics["rpc.server.request.duration"].Data.(metricdata.Histogram[float64])
+	if !ok {
+		t.Fatalf("rpc.server.request.duration = %+v, want a histogram", metrics["rpc.server.request.duration"].Data)
+	}
+	if unit := metrics["rpc.server.request.duration"].Unit; unit != "s" {
+		t.Errorf("duration unit = %q, want s", unit)
+	}
+	var count, failed uint64
+	for _, dp := range duration.DataPoints {
+		count += dp.Count
+		if v, _ := dp.Attributes.Value("rpc.method"); v.AsString() != "Checkout" {
+			t.Errorf("duration rpc.method = %q, want Checkout", v.AsString())
+		}
+		if v, ok := dp.Attributes.Value("error.type"); ok {
+			failed += dp.Count
+			if v.AsString() != "*errors.errorString" {
+				t.Errorf("error.type = %q, want *errors.errorString", v.AsString())
+			}
+		}
+	}
+	if count != 2 {
+		t.Errorf("duration count = %d, want 2", count)
+	}
+	if failed != 1 {
+		t.Errorf("duration count with error.type = %d, want 1", failed)
+	}
+	if scope := scopeOf(collected, "rpc.server.request.duration"); scope != requestMetricsMeterName {
+		t.Errorf("scope = %q, want %q", scope, requestMetricsMeterName)
+	}
+}
+
+// scopeOf returns the instrumentation scope name of the named metric.
+func scopeOf(rm metricdata.ResourceMetrics, name string) string {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return sm.Scope.Name
+			}
+		}
+	}
+	return ""
+}
+
+func TestRequestMetrics_MetricsDisabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	// Records to noop instruments without panicking
+	NewRequestMetrics(tel, "http.server").Record(ctx, time.Second, errors.New("boom"))
+}
+
+func sumInt64(sum metricdata.Sum[int64]) int64 {
+	var total int64
+	for _, dp := range sum.DataPoints {
+		total += dp.Value
+	}
+	return total
+}
@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// newSampler returns the sampler configured by Options.TracesSampler and
+// Options.TracesSamplingRules, or nil to keep the SDK default (which honors
+// OTEL_TRACES_SAMPLER). The environment variable takes precedence over the options.
+func newSampler(opts *Options) (sdktrace.Sampler, error) {
+	if !opts.declarative && os.Getenv("OTEL_TRACES_SAMPLER") != "" {
+		return nil, nil
+	}
+
+	sampler, err := newBaseSampler(opts)
+	if err != nil || len(opts.TracesSamplingRules) == 0 {
+		return sampler, err
+	}
+	if sampler == nil {
+		sampler = sdktrace.AlwaysSample()
+	}
+	return newRuleSampler(opts.TracesSamplingRules, sampler), nil
+}
+
+// newBaseSampler returns the sampler configured by Options.TracesSampler, or
+// nil if it is empty.
+func newBaseSampler(opts *Options) (sdktrace.Sampler, error) {
+	ratio := opts.TracesSamplerRatio
+	switch opts.TracesSampler {
+	case "":
+		return nil, nil
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("unsupported traces sampler: %s (supported: always_on, always_off, traceidratio, parentbased_always_on, parentbased_always_off, parentbased_traceidratio)", opts.TracesSampler)
+	}
+}
+
+// samplerDescription describes the trace sampler: OTEL_TRACES_SAMPLER if set,
+// otherwise Options.TracesSampler (or the SDK default) and the number of
+// sampling rules.
+func samplerDescription(opts *Options) string {
+	if sampler := os.Getenv("OTEL_TRACES_SAMPLER"); sampler != "" && (opts == nil || !opts.declarative) {
+		if arg := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); arg != "" {
+			return fmt.Sprintf("%s(%s)", sampler, arg)
+		}
+		return sampler
+	}
+	desc := "parentbased_always_on"
+	if opts == nil {
+		return desc
+	}
+
+	switch opts.TracesSampler {
+	case "":
+	case "traceidratio", "parentbased_traceidratio":
+		desc = fmt.Sprintf("%s(%s)", opts.TracesSampler, strconv.FormatFloat(opts.TracesSamplerRatio, 'g', -1, 64))
+	default:
+		desc = opts.TracesSampler
+	}
+	if len(opts.TracesSamplingRules) > 0 {
+		desc = fmt.Sprintf("rules(%d)+%s", len(opts.TracesSamplingRules), desc)
+	}
+	return desc
+}
+
+// SamplingRule sets the sampling ratio of root spans that match it; see
+// Options.TracesSamplingRules.
+type SamplingRule struct {
+	// Match is compared with the span name and with the http.route and
+	// url.path attributes set when the span starts. It matches exactly, or as a
+	// prefix when it ends with "*" (e.g., "/internal/*").
+	Match string
+	// Ratio is the fraction of matching traces sampled (0 to 1).
+	Ratio float64
+}
+
+// matches reports whether the rule matches value.
+func (r SamplingRule) matches(value string) bool {
+	if prefix, ok := strings.CutSuffix(r.Match, "*"); ok {
+		return strings.HasPrefix(value, prefix)
+	}
+	return value == r.Match
+}
+
+// ruleSampler samples root spans with the ratio of the first matching rule,
+// and with the fallback sampler if no rule matches.
+type ruleSampler struct {
+	rules    []SamplingRule
+	samplers []sdktrace.Sampler
+	fallback sdktrace.Sampler
+}
+
+// newRuleSampler returns a sampler that applies rules to root spans and
+// follows the parent's decision for child spans.
+func newRuleSampler(rules []SamplingRule, fallback sdktrace.Sampler) sdktrace.Sampler {
+	samplers := make([]sdktrace.Sampler, len(rules))
+	for i, rule := range rules {
+		samplers[i] = sdktrace.TraceIDRatioBased(rule.Ratio)
+	}
+	return sdktrace.ParentBased(&ruleSampler{rules: rules, samplers: samplers, fallback: fallback})
+}
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	values := []string{p.Name}
+	for _, attr := range p.Attributes {
+		if attr.Key == semconv.HTTPRouteKey || attr.Key == semconv.URLPathKey {
+			values = append(values, attr.Value.AsString())
+		}
+	}
+
+	for i, rule := range s.rules {
+		for _, value := range values {
+			if rule.matches(value) {
+				return s.samplers[i].ShouldSample(p)
+			}
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
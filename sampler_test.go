@@ -0,0 +1,131 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	tests := []struct {
+		sampler     string
+		ratio       float64
+		wantDesc    string
+		wantErr     bool
+		wantDefault bool
+	}{
+		{sampler: "", wantDefault: true},
+		{sampler: "always_off", wantDesc: "AlwaysOffSampler"},
+		{sampler: "traceidratio", ratio: 0.5, wantDesc: "TraceIDRatioBased{0.5}"},
+		{sampler: "parentbased_traceidratio", ratio: 0.1, wantDesc: "ParentBased{root:TraceIDRatioBased{0.1}"},
+		{sampler: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sampler, func(t *testing.T) {
+			sampler, err := newSampler(&Options{TracesSampler: tt.sampler, TracesSamplerRatio: tt.ratio})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newSampler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantDefault {
+				if sampler != nil {
+					t.Errorf("newSampler() = %v, want nil for the SDK default", sampler.Description())
+				}
+				return
+			}
+			if got := sampler.Description(); !strings.HasPrefix(got, tt.wantDesc) {
+				t.Errorf("newSampler().Description() = %q, want prefix %q", got, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestNewSampler_EnvOverride(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	t.Setenv("OTEL_TRACES_SAMPLER", "always_on")
+
+	sampler, err := newSampler(&Options{TracesSampler: "always_off"})
+	if err != nil {
+		t.Fatalf("newSampler() error = %v", err)
+	}
+	if sampler != nil {
+		t.Errorf("newSampler() = %v, want nil so OTEL_TRACES_SAMPLER applies", sampler.Description())
+	}
+	if got := samplerDescription(&Options{TracesSampler: "always_off"}); got != "always_on" {
+		t.Errorf("samplerDescription() = %q, want %q", got, "always_on")
+	}
+}
+
+func TestNewSampler_Rules(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	opts := &Options{
+		TracesSampler:      "traceidratio",
+		TracesSamplerRatio: 1,
+		TracesSamplingRules: []SamplingRule{
+			{Match: "/healthz", Ratio: 0},
+			{Match: "/checkout*", Ratio: 1},
+			{Match: "GET", Ratio: 0},
+		},
+	}
+	sampler, err := newSampler(opts)
+	if err != nil {
+		t.Fatalf("newSampler() error = %v", err)
+	}
+
+	traceID := trace.TraceID{0x01}
+	tests := []struct {
+		name   string
+		span   string
+		attrs  []attribute.KeyValue
+		parent trace.SpanContext
+		want   sdktrace.SamplingDecision
+	}{
+		{name: "route rule drops", span: "GET /healthz", attrs: []attribute.KeyValue{semconv.HTTPRoute("/healthz")}, want: sdktrace.Drop},
+		{name: "path prefix rule samples", span: "POST", attrs: []attribute.KeyValue{semconv.URLPath("/checkout/confirm")}, want: sdktrace.RecordAndSample},
+		{name: "span name rule", span: "GET", want: sdktrace.Drop},
+		{name: "fallback", span: "process-order", want: sdktrace.RecordAndSample},
+		{
+			name:  "child follows parent",
+			span:  "GET /healthz",
+			attrs: []attribute.KeyValue{semconv.HTTPRoute("/healthz")},
+			parent: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID, SpanID: trace.SpanID{0x02}, TraceFlags: trace.FlagsSampled,
+			}),
+			want: sdktrace.RecordAndSample,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(context.Background(), tt.parent)
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: ctx,
+				TraceID:       traceID,
+				Name:          tt.span,
+				Attributes:    tt.attrs,
+			})
+			if result.Decision != tt.want {
+				t.Errorf("ShouldSample() decision = %v, want %v", result.Decision, tt.want)
+			}
+		})
+	}
+
+	if got := samplerDescription(opts); got != "rules(3)+traceidratio(1)" {
+		t.Errorf("samplerDescription() = %q, want %q", got, "rules(3)+traceidratio(1)")
+	}
+}
@@ -0,0 +1,105 @@
+package telemetry
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// selfMeterName is the instrumentation scope of the pipeline's own metrics.
+const selfMeterName = "github.com/ekristen/go-telemetry/v2"
+
+// pipelineMetrics are the metrics the telemetry pipeline reports about itself,
+// under the telemetry.* namespace, so an unhealthy pipeline can be alerted on.
+type pipelineMetrics struct {
+	provider metric.MeterProvider
+	items    metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// selfMetrics caches the pipeline metrics for the current global meter provider.
+var selfMetrics atomic.Pointer[pipelineMetrics]
+
+// getPipelineMetrics returns the pipeline metrics for the global meter provider,
+// which New sets to the telemetry meter provider. The instruments are recreated
+// when the global provider changes, e.g. after Reconfigure.
+func getPipelineMetrics() *pipelineMetrics {
+	mp := otel.GetMeterProvider()
+	if m := selfMetrics.Load(); m != nil && m.provider == mp {
+		return m
+	}
+
+	meter := mp.Meter(selfMeterName)
+
+	items, err := meter.Int64Counter("telemetry.exporter.items",
+		metric.WithDescription("Spans, log records, or metric data points handed to an exporter, by outcome. Failed items are dropped."),
+		metric.WithUnit("{item}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	duration, err := meter.Float64Histogram("telemetry.exporter.duration",
+		metric.WithDescription("Duration of export calls."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	m := &pipelineMetrics{provider: mp, items: items, duration: duration}
+	selfMetrics.Store(m)
+	return m
+}
+
+// recordExport records the outcome of exporting n items of the given signal.
+func (m *pipelineMetrics) recordExport(ctx context.Context, signal string, n int, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("signal", signal),
+		attribute.String("outcome", outcome),
+	)
+
+	// The export context may already be canceled; the measurement must still be recorded
+	ctx = context.WithoutCancel(ctx)
+	m.items.Add(ctx, int64(n), attrs)
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
+// countDataPoints returns the number of data points in rm.
+func countDataPoints(rm *metricdata.ResourceMetrics) int {
+	n := 0
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				n += len(data.DataPoints)
+			case metricdata.Sum[float64]:
+				n += len(data.DataPoints)
+			case metricdata.Gauge[int64]:
+				n += len(data.DataPoints)
+			case metricdata.Gauge[float64]:
+				n += len(data.DataPoints)
+			case metricdata.Histogram[int64]:
+				n += len(data.DataPoints)
+			case metricdata.Histogram[float64]:
+				n += len(data.DataPoints)
+			case metricdata.ExponentialHistogram[int64]:
+				n += len(data.DataPoints)
+			case metricdata.ExponentialHistogram[float64]:
+				n += len(data.DataPoints)
+			case metricdata.Summary:
+				n += len(data.DataPoints)
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,399 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// sentryTimeout bounds each event submission when the context has no deadline.
+const sentryTimeout = 10 * time.Second
+
+// sentryClient submits events to Sentry using the envelope endpoint, so the
+// integration doesn't need the Sentry SDK.
+type sentryClient struct {
+	dsn         string
+	endpoint    string
+	auth        string
+	environment string
+	client      *http.Client
+}
+
+// newSentryClient parses a Sentry DSN ("https://<key>@<host>/<project>").
+func newSentryClient(dsn, environment string) (*sentryClient, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, errors.New("invalid Sentry DSN: missing public key")
+	}
+
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	projectID := path[i+1:]
+	if projectID == "" {
+		return nil, errors.New("invalid Sentry DSN: missing project ID")
+	}
+
+	endpoint := url.URL{Scheme: u.Scheme, Host: u.Host, Path: path[:i] + "/api/" + projectID + "/envelope/"}
+
+	return &sentryClient{
+		dsn:         dsn,
+		endpoint:    endpoint.String(),
+		auth:        "Sentry sentry_version=7, sentry_client=go-telemetry/2, sentry_key=" + u.User.Username(),
+		environment: environment,
+		client:      &http.Client{Timeout: sentryTimeout},
+	}, nil
+}
+
+// sentryEvent is a Sentry error event.
+type sentryEvent struct {
+	EventID     string                    `json:"event_id"`
+	Timestamp   float64                   `json:"timestamp"`
+	Level       string                    `json:"level"`
+	Platform    string                    `json:"platform"`
+	Logger      string                    `json:"logger,omitempty"`
+	Release     string                    `json:"release,omitempty"`
+	Environment string                    `json:"environment,omitempty"`
+	Message     *sentryMessage            `json:"message,omitempty"`
+	Exception   []sentryException         `json:"exception,omitempty"`
+	Contexts    map[string]map[string]any `json:"contexts,omitempty"`
+	Tags        map[string]string         `json:"tags,omitempty"`
+	Extra       map[string]any            `json:"extra,omitempty"`
+}
+
+type sentryMessage struct {
+	Formatted string `json:"formatted"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+}
+
+// newSentryEvent returns an event with a new ID and the service details from res.
+func (c *sentryClient) newSentryEvent(t time.Time, level string, res *resource.Resource) *sentryEvent {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+
+	event := &sentryEvent{
+		EventID:     hex.EncodeToString(id),
+		Timestamp:   float64(t.UnixNano()) / 1e9,
+		Level:       level,
+		Platform:    "go",
+		Environment: c.environment,
+		Tags:        map[string]string{},
+	}
+	if res != nil {
+		if v, ok := res.Set().Value("service.name"); ok {
+			event.Tags["service.name"] = v.Emit()
+		}
+		if v, ok := res.Set().Value("service.version"); ok {
+			event.Release = v.Emit()
+		}
+	}
+	return event
+}
+
+// send submits an event in an envelope.
+func (c *sentryClient) send(ctx context.Context, event *sentryEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode Sentry event: %w", err)
+	}
+	header, _ := json.Marshal(map[string]string{"event_id": event.EventID, "dsn": c.dsn})
+	itemHeader, _ := json.Marshal(map[string]any{"type": "event", "length": len(payload)})
+
+	var body bytes.Buffer
+	body.Write(header)
+	body.WriteByte('\n')
+	body.Write(itemHeader)
+	body.WriteByte('\n')
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("failed to create Sentry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", c.auth)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send event to Sentry: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to send event to Sentry: %s", resp.Status)
+	}
+	return nil
+}
+
+// logProcessor returns a processor that sends Error and Fatal records to Sentry
+// in the background.
+func (c *sentryClient) logProcessor() sdklog.Processor {
+	return &severityProcessor{
+		Processor: sdklog.NewBatchProcessor(&sentryLogExporter{client: c}),
+		min:       otellog.SeverityError,
+	}
+}
+
+// spanProcessor returns a processor that sends spans ended with an error
+// status to Sentry in the background.
+func (c *sentryClient) spanProcessor() sdktrace.SpanProcessor {
+	return &errorSpanProcessor{SpanProcessor: sdktrace.NewBatchSpanProcessor(&sentrySpanExporter{client: c})}
+}
+
+// errorSpanProcessor passes only the spans ended with an error status to the
+// wrapped processor, so other spans are never queued.
+type errorSpanProcessor struct {
+	sdktrace.SpanProcessor
+}
+
+func (p *errorSpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (p *errorSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	if span.Status().Code == codes.Error {
+		p.SpanProcessor.OnEnd(span)
+	}
+}
+
+// sentryLogExporter converts log records into Sentry events.
+type sentryLogExporter struct {
+	client *sentryClient
+}
+
+// Export sends one event per Error or Fatal record and ignores the rest.
+func (e *sentryLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	var errs []error
+	for i := range records {
+		if records[i].Severity() < otellog.SeverityError {
+			continue
+		}
+		if err := e.client.send(ctx, e.client.logEvent(&records[i])); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown closes idle connections to Sentry.
+func (e *sentryLogExporter) Shutdown(ctx context.Context) error {
+	e.client.client.CloseIdleConnections()
+	return nil
+}
+
+// ForceFlush is a no-op; events are sent synchronously by Export.
+func (e *sentryLogExporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// logEvent converts a log record into a Sentry event. The exception value is
+// the "error", "err", "error.message", or "exception.message" attribute if
+// present, and its type the "error.type" or "exception.type" attribute. A
+// "stacktrace", "stack", or "exception.stacktrace" attribute (e.g., from zap)
+// becomes the exception stack trace, and the "error.causes" the hooks record
+// for wrapped errors become chained exceptions.
+func (c *sentryClient) logEvent(record *sdklog.Record) *sentryEvent {
+	timestamp := record.Timestamp()
+	if timestamp.IsZero() {
+		timestamp = record.ObservedTimestamp()
+	}
+
+	level := "error"
+	if record.Severity() >= otellog.SeverityFatal {
+		level = "fatal"
+	}
+
+	event := c.newSentryEvent(timestamp, level, record.Resource())
+	event.Logger = record.InstrumentationScope().Name
+	message := record.Body().String()
+	event.Message = &sentryMessage{Formatted: message}
+
+	exception := sentryException{Type: "error", Value: message}
+	var causes []otellog.Value
+	extra := map[string]any{}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		switch kv.Key {
+		case "error", "err", "error.message", "exception.message":
+			exception.Value = kv.Value.String()
+		case "error.type", "exception.type":
+			exception.Type = kv.Value.String()
+		case "stacktrace", "stack", "exception.stacktrace":
+			exception.Stacktrace = parseGoStacktrace(kv.Value.String())
+		case "error.causes":
+			causes = kv.Value.AsSlice()
+		default:
+			extra[kv.Key] = logValueToAny(kv.Value)
+		}
+		return true
+	})
+
+	// Sentry lists chained exceptions innermost cause first; causes are
+	// recorded outermost first
+	for i := len(causes) - 1; i >= 0; i-- {
+		event.Exception = append(event.Exception, sentryException{Type: "error", Value: causes[i].String()})
+	}
+	event.Exception = append(event.Exception, exception)
+	if len(extra) > 0 {
+		event.Extra = extra
+	}
+
+	if traceID := record.TraceID(); traceID.IsValid() {
+		event.Contexts = map[string]map[string]any{"trace": {
+			"trace_id": traceID.String(),
+			"span_id":  record.SpanID().String(),
+		}}
+	}
+
+	return event
+}
+
+// sentrySpanExporter converts spans ended with an error status into Sentry events.
+type sentrySpanExporter struct {
+	client *sentryClient
+}
+
+// ExportSpans sends one event per span with an error status and ignores the rest.
+func (e *sentrySpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	var errs []error
+	for _, span := range spans {
+		if span.Status().Code != codes.Error {
+			continue
+		}
+		if err := e.client.send(ctx, e.client.spanEvent(span)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown closes idle connections to Sentry.
+func (e *sentrySpanExporter) Shutdown(ctx context.Context) error {
+	e.client.client.CloseIdleConnections()
+	return nil
+}
+
+// spanEvent converts an error span into a Sentry event. The last recorded
+// exception (span.RecordError) provides the type, message, and stack trace.
+func (c *sentryClient) spanEvent(span sdktrace.ReadOnlySpan) *sentryEvent {
+	event := c.newSentryEvent(span.EndTime(), "error", span.Resource())
+	event.Logger = span.InstrumentationScope().Name
+	event.Message = &sentryMessage{Formatted: span.Name()}
+	event.Tags["span.name"] = span.Name()
+
+	exception := sentryException{Type: "error", Value: span.Status().Description}
+	if exception.Value == "" {
+		exception.Value = span.Name()
+	}
+	for _, ev := range span.Events() {
+		if ev.Name != "exception" {
+			continue
+		}
+		for _, attr := range ev.Attributes {
+			switch attr.Key {
+			case "exception.type":
+				exception.Type = attr.Value.Emit()
+			case "exception.message":
+				exception.Value = attr.Value.Emit()
+			case "exception.stacktrace":
+				exception.Stacktrace = parseGoStacktrace(attr.Value.Emit())
+			}
+		}
+	}
+	event.Exception = []sentryException{exception}
+
+	if attrs := span.Attributes(); len(attrs) > 0 {
+		event.Extra = make(map[string]any, len(attrs))
+		for _, attr := range attrs {
+			event.Extra[string(attr.Key)] = attr.Value.AsInterface()
+		}
+	}
+
+	event.Contexts = map[string]map[string]any{"trace": {
+		"trace_id": span.SpanContext().TraceID().String(),
+		"span_id":  span.SpanContext().SpanID().String(),
+		"op":       span.Name(),
+	}}
+
+	return event
+}
+
+// parseGoStacktrace parses a Go stack trace, as produced by runtime/debug.Stack
+// or zap, into Sentry frames ordered oldest call first. It returns nil if no
+// frames are found.
+func parseGoStacktrace(stack string) *sentryStacktrace {
+	var frames []sentryFrame
+	var function string
+
+	for _, line := range strings.Split(stack, "\n") {
+		if line == "" || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "\t") {
+			// Function line, e.g. "main.handler(0x1, ...)" or "main.handler"
+			function = line
+			if i := strings.LastIndex(function, "("); i > 0 && strings.HasSuffix(function, ")") {
+				function = function[:i]
+			}
+			continue
+		}
+
+		// Location line, e.g. "\t/app/main.go:42 +0x1d"
+		location := strings.TrimSpace(line)
+		if i := strings.Index(location, " "); i > 0 {
+			location = location[:i]
+		}
+		frame := sentryFrame{Function: function, AbsPath: location}
+		if i := strings.LastIndex(location, ":"); i > 0 {
+			if lineno, err := strconv.Atoi(location[i+1:]); err == nil {
+				frame.AbsPath = location[:i]
+				frame.Lineno = lineno
+			}
+		}
+		frames = append(frames, frame)
+		function = ""
+	}
+
+	if len(frames) == 0 {
+		return nil
+	}
+
+	// Sentry expects the most recent call last
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return &sentryStacktrace{Frames: frames}
+}
@@ -0,0 +1,270 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewSentryClient(t *testing.T) {
+	client, err := newSentryClient("https://abc123@o1.ingest.sentry.io/42", "production")
+	if err != nil {
+		t.Fatalf("newSentryClient() error = %v", err)
+	}
+
+	if want := "https://o1.ingest.sentry.io/api/42/envelope/"; client.endpoint != want {
+		t.Errorf("endpoint = %s, want %s", client.endpoint, want)
+	}
+	if !strings.Contains(client.auth, "sentry_key=abc123") {
+		t.Errorf("auth = %s, want sentry_key=abc123", client.auth)
+	}
+
+	for _, dsn := range []string{"https://o1.ingest.sentry.io/42", "https://abc123@o1.ingest.sentry.io/"} {
+		if _, err := newSentryClient(dsn, ""); err == nil {
+			t.Errorf("newSentryClient(%q) error = nil, want error", dsn)
+		}
+	}
+}
+
+func TestParseGoStacktrace(t *testing.T) {
+	stack := "goroutine 1 [running]:\n" +
+		"main.handler(0x1)\n\t/app/handler.go:42 +0x1d\n" +
+		"main.main()\n\t/app/main.go:10 +0x25\n"
+
+	st := parseGoStacktrace(stack)
+	if st == nil || len(st.Frames) != 2 {
+		t.Fatalf("parseGoStacktrace() = %+v, want 2 frames", st)
+	}
+
+	// Most recent call last
+	want := []sentryFrame{
+		{Function: "main.main", AbsPath: "/app/main.go", Lineno: 10},
+		{Function: "main.handler", AbsPath: "/app/handler.go", Lineno: 42},
+	}
+	for i, frame := range want {
+		if st.Frames[i] != frame {
+			t.Errorf("frame %d = %+v, want %+v", i, st.Frames[i], frame)
+		}
+	}
+
+	if parseGoStacktrace("no stack here") != nil {
+		t.Error("parseGoStacktrace() without frames != nil")
+	}
+}
+
+func TestTelemetry_SentryErrorLogs(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	var mu sync.Mutex
+	var events []sentryEvent
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/42/envelope/" {
+			t.Errorf("request path = %s, want /api/42/envelope/", r.URL.Path)
+		}
+
+		// Envelope: header, item header, event payload
+		scanner := bufio.NewScanner(r.Body)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if len(lines) != 3 {
+			t.Errorf("envelope has %d lines, want 3", len(lines))
+			return
+		}
+
+		var event sentryEvent
+		if err := json.Unmarshal([]byte(lines[2]), &event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://key@", 1) + "/42"
+
+	ctx := context.Background()
+	tel, err := New(ctx, &Options{ServiceName: "sentry-service", ServiceVersion: "1.2.3", SentryDSN: dsn})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if tel.LoggerProvider() == nil {
+		t.Fatal("LoggerProvider() = nil, want a provider for Sentry")
+	}
+
+	emit := func(severity otellog.Severity, msg string) {
+		var record otellog.Record
+		record.SetSeverity(severity)
+		record.SetBody(otellog.StringValue(msg))
+		record.AddAttributes(otellog.String("error", "connection refused"))
+		tel.Logger().Emit(ctx, record)
+	}
+	emit(otellog.SeverityInfo, "all good")
+	emit(otellog.SeverityError, "query failed")
+
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 {
+		t.Fatalf("sent %d events, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.Level != "error" || event.Message == nil || event.Message.Formatted != "query failed" {
+		t.Errorf("event = %+v, want error event for %q", event, "query failed")
+	}
+	if event.Release != "1.2.3" {
+		t.Errorf("event.Release = %q, want %q", event.Release, "1.2.3")
+	}
+	if len(event.Exception) != 1 || event.Exception[0].Value != "connection refused" {
+		t.Errorf("event.Exception = %+v, want value %q", event.Exception, "connection refused")
+	}
+}
+
+func TestSentryClient_LogEventHookError(t *testing.T) {
+	client, err := newSentryClient("https://abc123@o1.ingest.sentry.io/42", "")
+	if err != nil {
+		t.Fatalf("newSentryClient() error = %v", err)
+	}
+
+	// The attributes the hooks record for an error wrapping two causes
+	record := newSDKRecord(t)
+	record.SetSeverity(otellog.SeverityError)
+	record.SetBody(otellog.StringValue("query failed"))
+	record.AddAttributes(
+		otellog.String("error.type", "*fmt.wrapError"),
+		otellog.String("error.message", "query users: dial tcp: connection refused"),
+		otellog.Slice("error.causes",
+			otellog.StringValue("dial tcp: connection refused"),
+			otellog.StringValue("connection refused"),
+		),
+		otellog.String("db.system", "postgresql"),
+	)
+
+	event := client.logEvent(&record)
+
+	want := []sentryException{
+		{Type: "error", Value: "connection refused"},
+		{Type: "error", Value: "dial tcp: connection refused"},
+		{Type: "*fmt.wrapError", Value: "query users: dial tcp: connection refused"},
+	}
+	if len(event.Exception) != len(want) {
+		t.Fatalf("event.Exception = %+v, want %+v", event.Exception, want)
+	}
+	for i := range want {
+		if event.Exception[i].Type != want[i].Type || event.Exception[i].Value != want[i].Value {
+			t.Errorf("event.Exception[%d] = %+v, want %+v", i, event.Exception[i], want[i])
+		}
+	}
+	if _, ok := event.Extra["error.causes"]; ok {
+		t.Error("event.Extra has error.causes, want it mapped to the exceptions")
+	}
+	if event.Extra["db.system"] != "postgresql" {
+		t.Errorf("event.Extra[db.system] = %v, want postgresql", event.Extra["db.system"])
+	}
+}
+
+func TestSentryClient_LogEventException(t *testing.T) {
+	client, err := newSentryClient("https://abc123@o1.ingest.sentry.io/42", "")
+	if err != nil {
+		t.Fatalf("newSentryClient() error = %v", err)
+	}
+
+	record := newSDKRecord(t)
+	record.SetSeverity(otellog.SeverityError)
+	record.SetBody(otellog.StringValue("request failed"))
+	record.AddAttributes(
+		otellog.String("exception.type", "*net.OpError"),
+		otellog.String("exception.message", "connection reset"),
+		otellog.String("exception.stacktrace", "goroutine 1 [running]:\nmain.main()\n\t/app/main.go:10 +0x1d\n"),
+	)
+
+	event := client.logEvent(&record)
+
+	if len(event.Exception) != 1 {
+		t.Fatalf("event.Exception = %+v, want one exception", event.Exception)
+	}
+	exception := event.Exception[0]
+	if exception.Type != "*net.OpError" || exception.Value != "connection reset" {
+		t.Errorf("exception = %+v, want *net.OpError: connection reset", exception)
+	}
+	if exception.Stacktrace == nil || len(exception.Stacktrace.Frames) != 1 {
+		t.Errorf("exception.Stacktrace = %+v, want one frame", exception.Stacktrace)
+	}
+	if len(event.Extra) != 0 {
+		t.Errorf("event.Extra = %v, want the exception attributes mapped", event.Extra)
+	}
+}
+
+// endedSpanProcessor records the names of the spans it sees end.
+type endedSpanProcessor struct {
+	sdktrace.SpanProcessor
+	ended []string
+}
+
+func (p *endedSpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	p.ended = append(p.ended, span.Name())
+}
+
+func TestErrorSpanProcessor(t *testing.T) {
+	recorder := &endedSpanProcessor{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(&errorSpanProcessor{SpanProcessor: recorder}))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	span.End()
+	_, span = tracer.Start(context.Background(), "failed")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+
+	if len(recorder.ended) != 1 || recorder.ended[0] != "failed" {
+		t.Errorf("queued spans = %v, want only the error span", recorder.ended)
+	}
+}
+
+// recordCapture is a log processor that keeps a copy of each emitted record.
+type recordCapture struct {
+	records []sdklog.Record
+}
+
+func (p *recordCapture) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.records = append(p.records, record.Clone())
+	return nil
+}
+
+func (p *recordCapture) Enabled(context.Context, sdklog.EnabledParameters) bool { return true }
+func (p *recordCapture) Shutdown(context.Context) error                         { return nil }
+func (p *recordCapture) ForceFlush(context.Context) error                       { return nil }
+
+// newSDKRecord returns an empty record emitted through a logger provider, so
+// unlike a zero sdklog.Record it keeps attribute values untruncated.
+func newSDKRecord(t testing.TB) sdklog.Record {
+	t.Helper()
+
+	capture := &recordCapture{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(capture))
+	defer lp.Shutdown(context.Background())
+
+	lp.Logger("test").Emit(context.Background(), otellog.Record{})
+	if len(capture.records) != 1 {
+		t.Fatalf("captured %d records, want 1", len(capture.records))
+	}
+	return capture.records[0]
+}
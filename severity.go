@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"context"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// severityProcessor is a log processor that drops records below a minimum
+// severity, which levels may override per instrumentation scope. It reports
+// them as disabled too, so hooks that check the OTel logger's Enabled skip
+// building those records at all. Records without a severity are always forwarded.
+type severityProcessor struct {
+	sdklog.Processor
+	min    otellog.Severity
+	levels *componentLevels
+}
+
+var _ sdklog.Processor = (*severityProcessor)(nil)
+
+// OnEmit forwards the record if it meets the minimum severity.
+func (p *severityProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if !p.allowed(record.InstrumentationScope().Name, record.Severity()) {
+		return nil
+	}
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// Enabled reports whether a record with the given parameters would be forwarded.
+func (p *severityProcessor) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	if !p.allowed(param.InstrumentationScope.Name, param.Severity) {
+		return false
+	}
+	return p.Processor.Enabled(ctx, param)
+}
+
+// allowed reports whether a record with the given scope and severity meets
+// the minimum.
+func (p *severityProcessor) allowed(scope string, severity otellog.Severity) bool {
+	if severity == otellog.SeverityUndefined {
+		return true
+	}
+	if level, ok := p.levels.get(scope); ok {
+		return severity >= level
+	}
+	return severity >= p.min
+}
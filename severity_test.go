@@ -0,0 +1,85 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestSeverityProcessor(t *testing.T) {
+	ctx := context.Background()
+
+	recorder := &telemetrytest.LogRecorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(
+		newLogProcessor(recorder, &Options{LogsMinSeverity: otellog.SeverityInfo}),
+	))
+	defer lp.Shutdown(ctx)
+
+	logger := lp.Logger("test")
+	if logger.Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityDebug}) {
+		t.Error("Enabled(debug) = true, want false below the minimum severity")
+	}
+	if !logger.Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityWarn}) {
+		t.Error("Enabled(warn) = false, want true")
+	}
+
+	for _, severity := range []otellog.Severity{otellog.SeverityDebug, otellog.SeverityInfo, otellog.SeverityError, otellog.SeverityUndefined} {
+		var record otellog.Record
+		record.SetSeverity(severity)
+		logger.Emit(ctx, record)
+	}
+
+	if got := len(recorder.Records()); got != 3 {
+		t.Errorf("exported %d records, want 3 (info, error, and undefined)", got)
+	}
+	recorder.AssertNotLogged(t, telemetrytest.WithSeverity(otellog.SeverityDebug))
+}
+
+func TestTelemetry_ComponentLevels(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	recorder := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:       "test-service",
+		CustomLogExporter: recorder,
+		LogsMinSeverity:   otellog.SeverityInfo,
+		ComponentLevels:   map[string]otellog.Severity{"database": otellog.SeverityDebug},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	debug := func(component string) {
+		var record otellog.Record
+		record.SetSeverity(otellog.SeverityDebug)
+		record.SetBody(otellog.StringValue(component))
+		tel.LoggerFor(component).Emit(ctx, record)
+	}
+
+	debug("database")
+	debug("http")
+	recorder.AssertLogged(t, telemetrytest.WithMessage("database"))
+	recorder.AssertNotLogged(t, telemetrytest.WithMessage("http"))
+
+	// Overrides can be changed at runtime
+	tel.SetComponentLevel("database", otellog.SeverityUndefined)
+	tel.SetComponentLevel("http", otellog.SeverityDebug)
+	recorder.Reset()
+
+	debug("database")
+	debug("http")
+	recorder.AssertNotLogged(t, telemetrytest.WithMessage("database"))
+	recorder.AssertLogged(t, telemetrytest.WithMessage("http"))
+
+	if levels := tel.ComponentLevels(); len(levels) != 1 || levels["http"] != otellog.SeverityDebug {
+		t.Errorf("ComponentLevels() = %v, want map[http:debug]", levels)
+	}
+}
@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanEventProcessor is a log processor that records logs emitted within a
+// recording span as events on that span, for Options.LogsAsSpanEvents. The
+// event is named after the log message and carries the severity and the
+// record attributes. It must be registered after the enriching processors and
+// ahead of the exporting processors, which skip those records through
+// spanEventFilter.
+type spanEventProcessor struct{}
+
+var _ sdklog.Processor = (*spanEventProcessor)(nil)
+
+// OnEmit adds the record as an event to the span in ctx, if it is recording.
+func (p *spanEventProcessor) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+
+	attrs := make([]attribute.KeyValue, 0, record.AttributesLen()+1)
+	if text := record.SeverityText(); text != "" {
+		attrs = append(attrs, attribute.String("log.severity", text))
+	}
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs = append(attrs, logValueToAttribute(kv.Key, kv.Value))
+		return true
+	})
+
+	span.AddEvent(record.Body().String(), trace.WithTimestamp(record.Timestamp()), trace.WithAttributes(attrs...))
+	return nil
+}
+
+// Enabled reports whether ctx holds a recording span to add the event to.
+func (p *spanEventProcessor) Enabled(ctx context.Context, _ sdklog.EnabledParameters) bool {
+	return trace.SpanFromContext(ctx).IsRecording()
+}
+
+// Shutdown does nothing.
+func (p *spanEventProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (p *spanEventProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// spanEventFilter is a log processor wrapper that keeps records emitted within
+// a recording span away from the exporters, since spanEventProcessor already
+// recorded them as span events. Records outside a span are forwarded.
+type spanEventFilter struct {
+	sdklog.Processor
+}
+
+var _ sdklog.Processor = (*spanEventFilter)(nil)
+
+// OnEmit forwards the record unless ctx holds a recording span.
+func (p *spanEventFilter) OnEmit(ctx context.Context, record *sdklog.Record) error {
+	if trace.SpanFromContext(ctx).IsRecording() {
+		return nil
+	}
+	return p.Processor.OnEmit(ctx, record)
+}
+
+// Enabled reports whether a record with the given parameters would be forwarded.
+func (p *spanEventFilter) Enabled(ctx context.Context, param sdklog.EnabledParameters) bool {
+	if trace.SpanFromContext(ctx).IsRecording() {
+		return false
+	}
+	return p.Processor.Enabled(ctx, param)
+}
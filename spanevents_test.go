@@ -0,0 +1,69 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_LogsAsSpanEvents(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+		LogsAsSpanEvents:   true,
+		LogsMinSeverity:    otellog.SeverityInfo,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	emit := func(ctx context.Context, severity otellog.Severity, text, msg string) {
+		var record otellog.Record
+		record.SetBody(otellog.StringValue(msg))
+		record.SetSeverity(severity)
+		record.SetSeverityText(text)
+		record.AddAttributes(otellog.String("user", "alice"))
+		tel.Logger().Emit(ctx, record)
+	}
+
+	spanCtx, span := tel.StartSpan(ctx, "request")
+	emit(spanCtx, otellog.SeverityInfo, "INFO", "in span")
+	emit(spanCtx, otellog.SeverityDebug, "DEBUG", "below min severity")
+	span.End()
+	emit(ctx, otellog.SeverityInfo, "INFO", "outside span")
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	events := got[0].Events
+	if len(events) != 1 || events[0].Name != "in span" {
+		t.Fatalf("span events = %v, want one \"in span\" event", events)
+	}
+	attrs := attribute.NewSet(events[0].Attributes...)
+	if v, _ := attrs.Value("log.severity"); v.AsString() != "INFO" {
+		t.Errorf("log.severity = %q, want INFO", v.AsString())
+	}
+	if v, _ := attrs.Value("user"); v.AsString() != "alice" {
+		t.Errorf("user = %q, want alice", v.AsString())
+	}
+
+	records := logs.Records()
+	if len(records) != 1 || records[0].Message() != "outside span" {
+		t.Errorf("exported log records = %v, want only \"outside span\"", records)
+	}
+}
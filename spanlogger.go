@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+
+	otellog "go.opentelemetry.io/otel/log"
+	logembedded "go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanLogger is an OTel logger scoped to one span. Every record it emits
+// carries the trace_id, span_id, and span_name attributes, and is correlated
+// with the span even when emitted with a context that doesn't hold it.
+type spanLogger struct {
+	logembedded.Logger
+	logger otellog.Logger
+	ctx    context.Context
+	attrs  []otellog.KeyValue
+}
+
+// newSpanLogger returns a logger scoped to the span in ctx.
+func newSpanLogger(ctx context.Context, logger otellog.Logger, name string) *spanLogger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	return &spanLogger{
+		logger: logger,
+		ctx:    ctx,
+		attrs: []otellog.KeyValue{
+			otellog.String("trace_id", spanContext.TraceID().String()),
+			otellog.String("span_id", spanContext.SpanID().String()),
+			otellog.String("span_name", name),
+		},
+	}
+}
+
+func (l *spanLogger) Emit(ctx context.Context, record otellog.Record) {
+	record.AddAttributes(l.attrs...)
+	l.logger.Emit(l.context(ctx), record)
+}
+
+func (l *spanLogger) Enabled(ctx context.Context, param otellog.EnabledParameters) bool {
+	return l.logger.Enabled(l.context(ctx), param)
+}
+
+// context returns ctx if it holds a span, or the logger's span context otherwise.
+func (l *spanLogger) context(ctx context.Context) context.Context {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return l.ctx
+	}
+	return ctx
+}
+
+// StartSpanWithLogger starts a new span like StartSpan and returns an OTel
+// logger scoped to it. Records emitted through the logger carry trace_id,
+// span_id, and span_name attributes and are correlated with the span even
+// when emitted with a context that doesn't hold it, e.g. from a goroutine
+// started with context.Background(). Logger integrations correlate with the
+// span through the returned context instead. The returned context also carries
+// the logger for LoggerFromContext.
+func (t *Telemetry) StartSpanWithLogger(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span, otellog.Logger) {
+	ctx, span := t.StartSpan(ctx, name, opts...)
+	logger := newSpanLogger(ctx, t.Logger(), name)
+	return ContextWithLogger(ctx, logger), span, logger
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_StartSpanWithLogger(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	logs := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: tracetest.NewInMemoryExporter(),
+		CustomLogExporter:  logs,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	spanCtx, span, logger := tel.StartSpanWithLogger(ctx, "checkout")
+	defer span.End()
+
+	// Emitted without the span context, as from a detached goroutine
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("charging card"))
+	logger.Emit(context.Background(), record)
+
+	records := logs.Records()
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+	got := records[0]
+	spanContext := span.SpanContext()
+	if got.TraceID != spanContext.TraceID() || got.SpanID != spanContext.SpanID() {
+		t.Errorf("record trace = %v/%v, want %v/%v", got.TraceID, got.SpanID, spanContext.TraceID(), spanContext.SpanID())
+	}
+	want := map[string]string{
+		"trace_id":  spanContext.TraceID().String(),
+		"span_id":   spanContext.SpanID().String(),
+		"span_name": "checkout",
+	}
+	for key, value := range want {
+		if got.Attributes[key].AsString() != value {
+			t.Errorf("attribute %s = %q, want %q", key, got.Attributes[key].AsString(), value)
+		}
+	}
+	if spanCtx == ctx {
+		t.Error("StartSpanWithLogger() returned the parent context, want one holding the span")
+	}
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+	SetDefault(nil)
+
+	ctx := context.Background()
+	if LoggerFromContext(ctx) == nil {
+		t.Fatal("LoggerFromContext() returned nil without a logger, want noop logger")
+	}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: tracetest.NewInMemoryExporter(),
+		CustomLogExporter:  &telemetrytest.LogRecorder{},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	component := tel.LoggerFor("database")
+	if got := LoggerFromContext(ContextWithLogger(ctx, component)); got != component {
+		t.Errorf("LoggerFromContext() = %v, want the stored logger", got)
+	}
+
+	spanCtx, span, logger := tel.StartSpanWithLogger(ctx, "query")
+	defer span.End()
+	if got := LoggerFromContext(spanCtx); got != logger {
+		t.Errorf("LoggerFromContext(span context) = %v, want the span logger", got)
+	}
+}
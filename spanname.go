@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// overflowSpanName is the name given to spans beyond Options.MaxSpanNames.
+const overflowSpanName = "other"
+
+// SpanNameRewrite rewrites span names matching a regular expression, e.g.
+// {Pattern: `/users/\d+`, Replacement: "/users/{id}"}; see Options.SpanNameRewrites.
+type SpanNameRewrite struct {
+	// Pattern is a regular expression (RE2 syntax) matched against the span name.
+	Pattern string
+	// Replacement replaces each match, as in regexp.Regexp.ReplaceAllString,
+	// so it can refer to capture groups with $1 or ${name}.
+	Replacement string
+}
+
+// spanNameGuard is a span processor that rewrites span names and caps the
+// number of distinct names, so code that puts IDs in span names can't blow
+// up the backends. Names are only guarded when the span starts; a name set
+// later with SetName is exported as is.
+type spanNameGuard struct {
+	rewrites     []*regexp.Regexp
+	replacements []string
+	limit        int
+
+	mu    sync.Mutex
+	names map[string]struct{}
+}
+
+// newSpanNameGuard returns the span name guard configured by opts, or nil if
+// neither SpanNameRewrites nor MaxSpanNames is set.
+func newSpanNameGuard(opts *Options) (*spanNameGuard, error) {
+	if len(opts.SpanNameRewrites) == 0 && opts.MaxSpanNames <= 0 {
+		return nil, nil
+	}
+
+	g := &spanNameGuard{limit: opts.MaxSpanNames, names: make(map[string]struct{})}
+	for _, rewrite := range opts.SpanNameRewrites {
+		re, err := regexp.Compile(rewrite.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid span name rewrite pattern %q: %w", rewrite.Pattern, err)
+		}
+		g.rewrites = append(g.rewrites, re)
+		g.replacements = append(g.replacements, rewrite.Replacement)
+	}
+	return g, nil
+}
+
+// name returns the guarded span name for name.
+func (g *spanNameGuard) name(name string) string {
+	for i, re := range g.rewrites {
+		name = re.ReplaceAllString(name, g.replacements[i])
+	}
+	if g.limit <= 0 {
+		return name
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.names[name]; !ok {
+		if len(g.names) >= g.limit {
+			return overflowSpanName
+		}
+		g.names[name] = struct{}{}
+	}
+	return name
+}
+
+func (g *spanNameGuard) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	if name := g.name(s.Name()); name != s.Name() {
+		s.SetName(name)
+	}
+}
+
+func (g *spanNameGuard) OnEnd(sdktrace.ReadOnlySpan) {}
+
+func (g *spanNameGuard) Shutdown(context.Context) error { return nil }
+
+func (g *spanNameGuard) ForceFlush(context.Context) error { return nil }
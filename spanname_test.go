@@ -0,0 +1,56 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSpanNameGuard(t *testing.T) {
+	guard, err := newSpanNameGuard(&Options{
+		SpanNameRewrites: []SpanNameRewrite{
+			{Pattern: `/users/\d+`, Replacement: "/users/{id}"},
+			{Pattern: `order-([a-z]+)-\d+`, Replacement: "order-$1"},
+		},
+		MaxSpanNames: 3,
+	})
+	if err != nil {
+		t.Fatalf("newSpanNameGuard() error = %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(guard))
+	defer tp.Shutdown(context.Background())
+	tracer := tp.Tracer("test")
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"GET /users/123", "GET /users/{id}"},
+		{"GET /users/456", "GET /users/{id}"},
+		{"order-retail-99", "order-retail"},
+		{"checkout", "checkout"},
+		{"session-abc", "other"},
+		{"checkout", "checkout"},
+	}
+
+	for _, tt := range tests {
+		_, span := tracer.Start(context.Background(), tt.name)
+		span.End()
+		if got := span.(sdktrace.ReadOnlySpan).Name(); got != tt.want {
+			t.Errorf("span %q name = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSpanNameGuard_Disabled(t *testing.T) {
+	guard, err := newSpanNameGuard(&Options{})
+	if err != nil || guard != nil {
+		t.Errorf("newSpanNameGuard() = (%v, %v), want (nil, nil)", guard, err)
+	}
+
+	if _, err := newSpanNameGuard(&Options{SpanNameRewrites: []SpanNameRewrite{{Pattern: "("}}}); err == nil {
+		t.Error("newSpanNameGuard() error = nil, want error for invalid pattern")
+	}
+}
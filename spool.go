@@ -0,0 +1,480 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultSpoolMaxBytes is the default cap on the size of the spool directory.
+const defaultSpoolMaxBytes = 64 << 20
+
+// spoolReplayTimeout bounds each replayed export.
+const spoolReplayTimeout = 10 * time.Second
+
+// spoolReplayInterval is how often queues are replayed when no export
+// succeeds to trigger a replay, e.g. while the application is idle.
+const spoolReplayInterval = 30 * time.Second
+
+// spoolFileExt is the extension of spooled export requests.
+const spoolFileExt = ".pb"
+
+// otlpExportMethod describes an OTLP gRPC export RPC and its message types.
+type otlpExportMethod struct {
+	signal     string
+	newRequest func() proto.Message
+	newReply   func() proto.Message
+}
+
+// otlpExportMethods maps the OTLP gRPC export methods to their message types.
+var otlpExportMethods = map[string]otlpExportMethod{
+	"/opentelemetry.proto.collector.logs.v1.LogsService/Export": {
+		signal:     "logs",
+		newRequest: func() proto.Message { return &collogspb.ExportLogsServiceRequest{} },
+		newReply:   func() proto.Message { return &collogspb.ExportLogsServiceResponse{} },
+	},
+	"/opentelemetry.proto.collector.trace.v1.TraceService/Export": {
+		signal:     "traces",
+		newRequest: func() proto.Message { return &coltracepb.ExportTraceServiceRequest{} },
+		newReply:   func() proto.Message { return &coltracepb.ExportTraceServiceResponse{} },
+	},
+	"/opentelemetry.proto.collector.metrics.v1.MetricsService/Export": {
+		signal:     "metrics",
+		newRequest: func() proto.Message { return &colmetricspb.ExportMetricsServiceRequest{} },
+		newReply:   func() proto.Message { return &colmetricspb.ExportMetricsServiceResponse{} },
+	},
+}
+
+// spool persists OTLP export requests that failed because the collector was
+// unreachable, and replays them once an export succeeds again, or every
+// spoolReplayInterval. It hooks into the exporters as a gRPC interceptor, so
+// the requests are spooled in their serialized wire form and replayed
+// unchanged. Each exporter connection has its own spoolQueue in a
+// subdirectory keyed by signal and endpoint, so requests are only replayed to
+// the collector they were meant for; the size cap applies to the spool as a
+// whole.
+type spool struct {
+	dir      string
+	maxBytes int64
+	health   *exportHealth
+
+	mu    sync.Mutex // serializes writes and size enforcement
+	seq   uint64
+	files []spoolFile // spooled requests of all queues, oldest first
+	size  int64       // total size of files
+
+	queuesMu sync.Mutex
+	queues   map[string]*spoolQueue
+	closed   bool
+
+	// ctx is canceled by close, which waits for the replays in wg
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// spoolFile is a spooled request and its size.
+type spoolFile struct {
+	path string
+	size int64
+}
+
+// spoolQueue holds the spooled requests of one exporter connection.
+type spoolQueue struct {
+	spool *spool
+	dir   string
+
+	// conn is the connection the exporter last exported on, used to replay
+	// the queue on the interval
+	conn      atomic.Pointer[spoolConn]
+	replaying atomic.Bool
+}
+
+// spoolConn is an exporter connection and the invoker to export on it.
+type spoolConn struct {
+	cc      *grpc.ClientConn
+	invoker grpc.UnaryInvoker
+}
+
+// newSpool creates a spool in dir, creating the directory if needed, and
+// starts replaying it on the interval until close is called. Exports that
+// are spooled are recorded as failed in health.
+func newSpool(dir string, maxBytes int64, health *exportHealth) (*spool, error) {
+	if maxBytes <= 0 {
+		maxBytes = defaultSpoolMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &spool{
+		dir:      dir,
+		maxBytes: maxBytes,
+		health:   health,
+		queues:   map[string]*spoolQueue{},
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	s.load()
+
+	s.wg.Add(1)
+	go s.replayOnInterval()
+	return s, nil
+}
+
+// load indexes the requests left in the spool by a previous run.
+func (s *spool) load() {
+	dirs, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	var files []string
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		queued, err := spoolFiles(filepath.Join(s.dir, dir.Name()))
+		if err != nil {
+			continue
+		}
+		files = append(files, queued...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.track(files)
+}
+
+// track adds the files not yet in the index, keeping it sorted oldest first.
+// The caller must hold s.mu.
+func (s *spool) track(files []string) {
+	known := make(map[string]bool, len(s.files))
+	for _, f := range s.files {
+		known[f.path] = true
+	}
+	added := false
+	for _, file := range files {
+		if known[file] {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		s.files = append(s.files, spoolFile{path: file, size: info.Size()})
+		s.size += info.Size()
+		added = true
+	}
+	if added {
+		// File names start with the spool time, so they sort oldest first across queues
+		sort.Slice(s.files, func(i, j int) bool {
+			return filepath.Base(s.files[i].path) < filepath.Base(s.files[j].path)
+		})
+	}
+}
+
+// remove deletes a spooled request and drops it from the index.
+func (s *spool) remove(file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	os.Remove(file)
+	for i, f := range s.files {
+		if f.path == file {
+			s.size -= f.size
+			s.files = append(s.files[:i], s.files[i+1:]...)
+			return
+		}
+	}
+}
+
+// replayOnInterval replays every queue with a known connection on the
+// interval, until the spool is closed.
+func (s *spool) replayOnInterval() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(spoolReplayInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.queuesMu.Lock()
+			queues := make([]*spoolQueue, 0, len(s.queues))
+			for _, q := range s.queues {
+				queues = append(queues, q)
+			}
+			s.queuesMu.Unlock()
+
+			for _, q := range queues {
+				if conn := q.conn.Load(); conn != nil {
+					q.startReplay(conn.cc, conn.invoker)
+				}
+			}
+		}
+	}
+}
+
+// close stops the replays and waits for them to return. The spooled requests
+// stay on disk for the next run.
+func (s *spool) close() {
+	s.queuesMu.Lock()
+	s.closed = true
+	s.queuesMu.Unlock()
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+// queue returns the queue of the exporter sending signal to endpoint.
+func (s *spool) queue(signal, endpoint string) *spoolQueue {
+	name := spoolQueueName(signal, endpoint)
+
+	s.queuesMu.Lock()
+	defer s.queuesMu.Unlock()
+	q, ok := s.queues[name]
+	if !ok {
+		q = &spoolQueue{spool: s, dir: filepath.Join(s.dir, name)}
+		s.queues[name] = q
+	}
+	return q
+}
+
+// spoolQueueName returns the subdirectory name for a signal and endpoint: the
+// signal and the endpoint with unsafe characters replaced, followed by a hash
+// of the endpoint so different endpoints never share a directory.
+func spoolQueueName(signal, endpoint string) string {
+	safe := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, endpoint)
+	h := fnv.New32a()
+	h.Write([]byte(endpoint))
+	return fmt.Sprintf("%s-%s-%08x", signal, safe, h.Sum32())
+}
+
+// dialOption returns the dial option that installs the interceptor of the
+// exporter sending signal to endpoint.
+func (s *spool) dialOption(signal, endpoint string) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(s.queue(signal, endpoint).intercept)
+}
+
+// intercept spools export requests that fail with a transient error, and
+// starts a replay after each successful export. A spooled request is reported
+// to the exporter as delivered, so it isn't retried or dropped, and recorded
+// as failed for ExporterState.
+func (q *spoolQueue) intercept(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	q.conn.Store(&spoolConn{cc: cc, invoker: invoker})
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		q.startReplay(cc, invoker)
+		return nil
+	}
+
+	m, ok := otlpExportMethods[method]
+	msg, isProto := req.(proto.Message)
+	if !ok || !isProto || !spoolable(err) {
+		return err
+	}
+	if werr := q.write(m.signal, msg); werr != nil {
+		return err
+	}
+	q.spool.health.spooled(m.signal, err)
+	return nil
+}
+
+// spoolable reports whether err means the collector was unreachable, as
+// opposed to rejecting the request.
+func spoolable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// rejected reports whether err means the collector will never accept the
+// request, so a spooled copy can be dropped. Other errors, such as failed
+// authentication, may go away and keep the request spooled.
+func rejected(err error) bool {
+	return status.Code(err) == codes.InvalidArgument
+}
+
+// write stores msg in the queue and drops the oldest requests if the spool
+// exceeds its size cap.
+func (q *spoolQueue) write(signal string, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s := q.spool
+	if int64(len(data)) > s.maxBytes {
+		return fmt.Errorf("export request of %d bytes exceeds the spool size cap", len(data))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(q.dir, 0o700); err != nil {
+		return err
+	}
+
+	s.seq++
+	name := fmt.Sprintf("%020d-%06d.%s%s", time.Now().UnixNano(), s.seq%1000000, signal, spoolFileExt)
+	path := filepath.Join(q.dir, name)
+
+	// Write to a temporary file first so replay never reads a partial request
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	s.files = append(s.files, spoolFile{path: path, size: int64(len(data))})
+	s.size += int64(len(data))
+
+	s.enforceCap()
+	return nil
+}
+
+// enforceCap removes the oldest spooled requests of all queues until the spool
+// fits in maxBytes. The caller must hold s.mu.
+func (s *spool) enforceCap() {
+	dropped := 0
+	for ; s.size > s.maxBytes && dropped < len(s.files); dropped++ {
+		if err := os.Remove(s.files[dropped].path); err != nil && !os.IsNotExist(err) {
+			break
+		}
+		s.size -= s.files[dropped].size
+	}
+	s.files = s.files[dropped:]
+}
+
+// files returns the requests spooled in the queue, oldest first.
+func (q *spoolQueue) files() ([]string, error) {
+	files, err := spoolFiles(q.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return files, err
+}
+
+// spoolFiles returns the spooled requests in dir, oldest first.
+func spoolFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), spoolFileExt) {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// startReplay replays the queue in the background, unless a replay is already
+// running or the spool is closed.
+func (q *spoolQueue) startReplay(cc *grpc.ClientConn, invoker grpc.UnaryInvoker) {
+	s := q.spool
+	s.queuesMu.Lock()
+	defer s.queuesMu.Unlock()
+	if s.closed || !q.replaying.CompareAndSwap(false, true) {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer q.replaying.Store(false)
+		q.replay(cc, invoker)
+	}()
+}
+
+// replay sends the queued requests oldest first, removing each once it is
+// delivered or rejected. It stops at the first other failure, or when the
+// spool is closed, and leaves the rest for the next replay.
+func (q *spoolQueue) replay(cc *grpc.ClientConn, invoker grpc.UnaryInvoker) {
+	files, err := q.files()
+	if err != nil {
+		return
+	}
+
+	// Index requests spooled by another spool on the same directory, such as
+	// the one of the configuration a Reconfigure replaced
+	q.spool.mu.Lock()
+	q.spool.track(files)
+	q.spool.mu.Unlock()
+
+	for _, file := range files {
+		method, m, ok := spoolMethodForFile(file)
+		if !ok {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if os.IsNotExist(err) {
+			// Dropped by the size cap since it was listed
+			continue
+		}
+		if err != nil {
+			return
+		}
+
+		req := m.newRequest()
+		if err := proto.Unmarshal(data, req); err != nil {
+			// A corrupt request can never be delivered
+			q.spool.remove(file)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(q.spool.ctx, spoolReplayTimeout)
+		err = invoker(ctx, method, req, m.newReply(), cc)
+		cancel()
+		if err != nil && !rejected(err) {
+			return
+		}
+
+		// Delivered, or rejected by the collector and not worth retrying
+		q.spool.remove(file)
+	}
+}
+
+// spoolMethodForFile returns the export method of a spooled request from its
+// file name, which ends in ".<signal>.pb".
+func spoolMethodForFile(file string) (string, otlpExportMethod, bool) {
+	signal := strings.TrimPrefix(filepath.Ext(strings.TrimSuffix(file, spoolFileExt)), ".")
+	for name, m := range otlpExportMethods {
+		if m.signal == signal {
+			return name, m, true
+		}
+	}
+	return "", otlpExportMethod{}, false
+}
@@ -0,0 +1,274 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const logsExportMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+
+// newTestSpoolQueue returns the logs queue for collector:4317 of a new spool.
+func newTestSpoolQueue(t *testing.T, maxBytes int64) (*spool, *spoolQueue) {
+	t.Helper()
+	s, err := newSpool(t.TempDir(), maxBytes, newExportHealth())
+	if err != nil {
+		t.Fatalf("newSpool() error = %v", err)
+	}
+	t.Cleanup(s.close)
+	return s, s.queue("logs", "collector:4317")
+}
+
+// fakeCollector is a grpc.UnaryInvoker that fails while down and records delivered requests.
+type fakeCollector struct {
+	mu        sync.Mutex
+	down      bool
+	delivered []proto.Message
+}
+
+func (c *fakeCollector) setDown(down bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.down = down
+}
+
+func (c *fakeCollector) deliveredCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.delivered)
+}
+
+func (c *fakeCollector) invoke(_ context.Context, _ string, req, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		return status.Error(codes.Unavailable, "collector down")
+	}
+	c.delivered = append(c.delivered, req.(proto.Message))
+	return nil
+}
+
+func exportLogsRequest(n int) *collogspb.ExportLogsServiceRequest {
+	records := make([]*logspb.LogRecord, n)
+	for i := range records {
+		records[i] = &logspb.LogRecord{SeverityText: "INFO"}
+	}
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{ScopeLogs: []*logspb.ScopeLogs{{LogRecords: records}}}},
+	}
+}
+
+func TestSpool_SpoolsAndReplays(t *testing.T) {
+	_, q := newTestSpoolQueue(t, 0)
+
+	ctx := context.Background()
+	collector := &fakeCollector{down: true}
+
+	for i := 0; i < 3; i++ {
+		err := q.intercept(ctx, logsExportMethod, exportLogsRequest(1), &collogspb.ExportLogsServiceResponse{}, nil, collector.invoke)
+		if err != nil {
+			t.Fatalf("intercept() error = %v while collector down, want spooled", err)
+		}
+	}
+
+	files, _ := q.files()
+	if len(files) != 3 {
+		t.Fatalf("spool holds %d requests, want 3", len(files))
+	}
+
+	collector.setDown(false)
+	if err := q.intercept(ctx, logsExportMethod, exportLogsRequest(1), &collogspb.ExportLogsServiceResponse{}, nil, collector.invoke); err != nil {
+		t.Fatalf("intercept() error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for collector.deliveredCount() < 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %d requests, want 4", collector.deliveredCount())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for q.replaying.Load() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if files, _ := q.files(); len(files) != 0 {
+		t.Errorf("spool holds %d requests after replay, want 0", len(files))
+	}
+}
+
+func TestSpool_RejectedRequestsAreNotSpooled(t *testing.T) {
+	_, q := newTestSpoolQueue(t, 0)
+
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.InvalidArgument, "bad request")
+	}
+	err := q.intercept(context.Background(), logsExportMethod, exportLogsRequest(1), &collogspb.ExportLogsServiceResponse{}, nil, invoker)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("intercept() error = %v, want InvalidArgument", err)
+	}
+	if files, _ := q.files(); len(files) != 0 {
+		t.Errorf("spool holds %d requests, want 0", len(files))
+	}
+}
+
+func TestSpool_SizeCapDropsOldest(t *testing.T) {
+	size := int64(proto.Size(exportLogsRequest(10)))
+	s, q := newTestSpoolQueue(t, 2*size)
+	other := s.queue("logs", "other:4317")
+
+	for i := 0; i < 5; i++ {
+		if err := q.write("logs", exportLogsRequest(10)); err != nil {
+			t.Fatalf("write() error = %v", err)
+		}
+		if err := other.write("logs", exportLogsRequest(10)); err != nil {
+			t.Fatalf("write() error = %v", err)
+		}
+	}
+
+	files, _ := q.files()
+	otherFiles, _ := other.files()
+	if len(files)+len(otherFiles) != 2 {
+		t.Errorf("spool holds %d requests, want 2", len(files)+len(otherFiles))
+	}
+	if s.size != 2*size || len(s.files) != 2 {
+		t.Errorf("spool index = %d requests of %d bytes, want 2 of %d", len(s.files), s.size, 2*size)
+	}
+}
+
+func TestSpool_ReplaysOnlyOwnQueue(t *testing.T) {
+	s, q := newTestSpoolQueue(t, 0)
+	other := s.queue("logs", "other:4317")
+
+	if err := other.write("logs", exportLogsRequest(1)); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	collector := &fakeCollector{}
+	q.replay(nil, collector.invoke)
+
+	if got := collector.deliveredCount(); got != 0 {
+		t.Errorf("delivered %d requests spooled for another endpoint, want 0", got)
+	}
+	if files, _ := other.files(); len(files) != 1 {
+		t.Errorf("other queue holds %d requests, want 1", len(files))
+	}
+}
+
+func TestSpool_ReplayKeepsRequestsOnOtherErrors(t *testing.T) {
+	_, q := newTestSpoolQueue(t, 0)
+
+	if err := q.write("logs", exportLogsRequest(1)); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	tests := []struct {
+		code      codes.Code
+		wantFiles int
+	}{
+		{codes.Unauthenticated, 1},
+		{codes.PermissionDenied, 1},
+		{codes.InvalidArgument, 0},
+	}
+	for _, tt := range tests {
+		q.replay(nil, func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(tt.code, "replay failed")
+		})
+		if files, _ := q.files(); len(files) != tt.wantFiles {
+			t.Errorf("replay with %v: spool holds %d requests, want %d", tt.code, len(files), tt.wantFiles)
+		}
+	}
+}
+
+func TestSpool_SpooledExportIsDegraded(t *testing.T) {
+	s, q := newTestSpoolQueue(t, 0)
+	s.health.register("logs")
+
+	ctx := context.Background()
+	collector := &fakeCollector{down: true}
+
+	// The exporter sees a delivered request, and reports it as such
+	err := q.intercept(ctx, logsExportMethod, exportLogsRequest(1), &collogspb.ExportLogsServiceResponse{}, nil, collector.invoke)
+	s.health.record("logs", err)
+	if got := s.health.signals["logs"]; got.State != ExportStateDegraded || status.Code(errors.Unwrap(got.LastError)) != codes.Unavailable {
+		t.Errorf("state after spooled export = %v (%v), want degraded with the export error", got.State, got.LastError)
+	}
+
+	collector.setDown(false)
+	err = q.intercept(ctx, logsExportMethod, exportLogsRequest(1), &collogspb.ExportLogsServiceResponse{}, nil, collector.invoke)
+	s.health.record("logs", err)
+	if got := s.health.signals["logs"]; got.State != ExportStateReady {
+		t.Errorf("state after delivered export = %v (%v), want ready", got.State, got.LastError)
+	}
+}
+
+func TestSpool_CloseStopsReplay(t *testing.T) {
+	s, q := newTestSpoolQueue(t, 0)
+
+	if err := q.write("logs", exportLogsRequest(1)); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	started := make(chan struct{})
+	q.startReplay(nil, func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		close(started)
+		<-ctx.Done()
+		return status.FromContextError(ctx.Err()).Err()
+	})
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		s.close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close() did not stop the running replay")
+	}
+
+	if q.replaying.Load() {
+		t.Error("replay still running after close()")
+	}
+	if files, _ := q.files(); len(files) != 1 {
+		t.Errorf("spool holds %d requests after close(), want 1 kept for the next run", len(files))
+	}
+
+	// No replay starts once the spool is closed
+	collector := &fakeCollector{}
+	q.startReplay(nil, collector.invoke)
+	if q.replaying.Load() || collector.deliveredCount() != 0 {
+		t.Error("startReplay() replayed after close()")
+	}
+}
+
+func TestSpool_LoadsPreviousRun(t *testing.T) {
+	dir := t.TempDir()
+	first, err := newSpool(dir, 0, nil)
+	if err != nil {
+		t.Fatalf("newSpool() error = %v", err)
+	}
+	if err := first.queue("logs", "collector:4317").write("logs", exportLogsRequest(1)); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	first.close()
+
+	second, err := newSpool(dir, 0, nil)
+	if err != nil {
+		t.Fatalf("newSpool() error = %v", err)
+	}
+	defer second.close()
+	if len(second.files) != 1 || second.size != int64(proto.Size(exportLogsRequest(1))) {
+		t.Errorf("spool index = %d requests of %d bytes, want the request of the previous run", len(second.files), second.size)
+	}
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultOTLPEndpoint is the endpoint the OTLP gRPC exporters use when no
+// endpoint environment variable is set.
+const defaultOTLPEndpoint = "localhost:4317"
+
+// ExporterEndpoint describes an active exporter and where it sends telemetry.
+type ExporterEndpoint struct {
+	// Signal is the signal being exported: "traces", "metrics", or "logs".
+	Signal string
+	// Exporter is the exporter name, e.g. "otlp", "prometheus", or "fluentforward".
+	Exporter string
+	// Endpoint is the address the exporter sends to (or, for the built-in
+	// Prometheus server, listens on). It is empty for a Prometheus exporter
+	// served through PrometheusHandler.
+	Endpoint string
+	// Err is set if the exporter failed to start. When several exporters are
+	// configured for a signal, the others keep running without it.
+	Err error
+}
+
+// TracesEnabled reports whether traces are being exported.
+func (t *Telemetry) TracesEnabled() bool {
+	return t.TracerProvider() != nil
+}
+
+// MetricsEnabled reports whether metrics are being exported.
+func (t *Telemetry) MetricsEnabled() bool {
+	return t.MeterProvider() != nil
+}
+
+// LogsEnabled reports whether logs are being exported.
+func (t *Telemetry) LogsEnabled() bool {
+	return t.LoggerProvider() != nil
+}
+
+// ExporterEndpoints returns the active exporters and their endpoints, so
+// applications can report at startup which signals are enabled and where
+// they are going. Returns an empty slice if all signals are disabled.
+func (t *Telemetry) ExporterEndpoints() []ExporterEndpoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]ExporterEndpoint(nil), t.endpoints...)
+}
+
+// newExporterEndpoint describes the named exporter for the given signal.
+func newExporterEndpoint(signal, exporter string, opts *Options) ExporterEndpoint {
+	e := ExporterEndpoint{Signal: signal, Exporter: exporter}
+
+	switch exporter {
+	case "otlp":
+		e.Endpoint = opts.otlpTarget(signal)
+		if opts.kafkaConn != nil {
+			e.Endpoint = "kafka:" + kafkaTopics(opts)[signal]
+		}
+	case "prometheus":
+		if opts.PrometheusServer {
+			e.Endpoint = ":" + strconv.Itoa(opts.PrometheusPort) + opts.prometheusPath()
+		}
+	case "sentry":
+		if opts.sentry != nil {
+			e.Endpoint = opts.sentry.endpoint
+		}
+	case "fluentforward":
+		e.Endpoint = opts.FluentForwardAddress
+	}
+
+	return e
+}
+
+// otlpTarget returns the endpoint the signal's OTLP exporter sends to: the one
+// set in Options, otherwise the one resolved by otlpEndpoint.
+func (o *Options) otlpTarget(signal string) string {
+	if endpoint, _ := o.otlpSignalEndpoint(signal); endpoint != "" {
+		return endpoint
+	}
+	return otlpEndpoint(signal)
+}
+
+// otlpEndpoint resolves the OTLP endpoint for a signal the same way the OTLP
+// exporters do: the signal-specific variable, then OTEL_EXPORTER_OTLP_ENDPOINT,
+// then the default.
+func otlpEndpoint(signal string) string {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_" + strings.ToUpper(signal) + "_ENDPOINT"); v != "" {
+		return v
+	}
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); v != "" {
+		return v
+	}
+	return defaultOTLPEndpoint
+}
@@ -0,0 +1,265 @@
+package telemetry
+
+import (
+	"context"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestTelemetry_SignalStatus_Disabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if tel.TracesEnabled() || tel.MetricsEnabled() || tel.LogsEnabled() {
+		t.Errorf("signals enabled = (%v, %v, %v), want all false",
+			tel.TracesEnabled(), tel.MetricsEnabled(), tel.LogsEnabled())
+	}
+	if endpoints := tel.ExporterEndpoints(); len(endpoints) != 0 {
+		t.Errorf("ExporterEndpoints() = %v, want empty", endpoints)
+	}
+}
+
+func TestTelemetry_ExporterEndpoints(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+	t.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "http://logs:4317")
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:          "test-service",
+		LogsExporter:         "otlp,fluentforward",
+		FluentForwardAddress: "fluentd:24224",
+		MetricsExporter:      "prometheus",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if !tel.TracesEnabled() || !tel.MetricsEnabled() || !tel.LogsEnabled() {
+		t.Errorf("signals enabled = (%v, %v, %v), want all true",
+			tel.TracesEnabled(), tel.MetricsEnabled(), tel.LogsEnabled())
+	}
+
+	want := []ExporterEndpoint{
+		{Signal: "logs", Exporter: "otlp", Endpoint: "http://logs:4317"},
+		{Signal: "logs", Exporter: "fluentforward", Endpoint: "fluentd:24224"},
+		{Signal: "traces", Exporter: "otlp", Endpoint: "http://collector:4317"},
+		{Signal: "metrics", Exporter: "prometheus", Endpoint: ""},
+	}
+	if got := tel.ExporterEndpoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExporterEndpoints() = %+v, want %+v", got, want)
+	}
+}
+
+func TestTelemetry_SignalEndpointOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:    "test-service",
+		TracesEndpoint: "https://traces.example.com:4317",
+		LogsEndpoint:   "logs.internal:4317",
+		LogsInsecure:   true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if !tel.TracesEnabled() || tel.MetricsEnabled() || !tel.LogsEnabled() {
+		t.Errorf("signals enabled = (%v, %v, %v), want traces and logs",
+			tel.TracesEnabled(), tel.MetricsEnabled(), tel.LogsEnabled())
+	}
+
+	want := []ExporterEndpoint{
+		{Signal: "logs", Exporter: "otlp", Endpoint: "logs.internal:4317"},
+		{Signal: "traces", Exporter: "otlp", Endpoint: "https://traces.example.com:4317"},
+	}
+	if got := tel.ExporterEndpoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExporterEndpoints() = %+v, want %+v", got, want)
+	}
+}
+
+func TestOptions_otlpSignalEndpoint_EnvOverride(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	opts := &Options{TracesEndpoint: "traces:4317", TracesInsecure: true}
+	if endpoint, insecure := opts.otlpSignalEndpoint("traces"); endpoint != "traces:4317" || !insecure {
+		t.Errorf("otlpSignalEndpoint() = (%q, %v), want (%q, true)", endpoint, insecure, "traces:4317")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "http://env:4317")
+	if endpoint, _ := opts.otlpSignalEndpoint("traces"); endpoint != "" {
+		t.Errorf("otlpSignalEndpoint() = %q with env var set, want empty", endpoint)
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	if opts.enabledByEndpoint("traces") {
+		t.Error("enabledByEndpoint() = true with OTEL_TRACES_EXPORTER=none, want false")
+	}
+}
+
+func TestOptions_otlpSignalEndpoint_Insecure(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	opts := &Options{ExporterInsecure: true}
+	for _, signal := range []string{"traces", "metrics", "logs"} {
+		if _, insecure := opts.otlpSignalEndpoint(signal); !insecure {
+			t.Errorf("otlpSignalEndpoint(%q) insecure = false, want true from ExporterInsecure", signal)
+		}
+	}
+
+	opts = &Options{MetricsInsecure: true}
+	if _, insecure := opts.otlpSignalEndpoint("metrics"); !insecure {
+		t.Error("otlpSignalEndpoint(\"metrics\") insecure = false, want true")
+	}
+	if _, insecure := opts.otlpSignalEndpoint("traces"); insecure {
+		t.Error("otlpSignalEndpoint(\"traces\") insecure = true, want false")
+	}
+
+	// The toggle also applies to endpoints from environment variables
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "collector:4317")
+	if endpoint, insecure := opts.otlpSignalEndpoint("metrics"); endpoint != "" || !insecure {
+		t.Errorf("otlpSignalEndpoint(\"metrics\") = (%q, %v), want (\"\", true)", endpoint, insecure)
+	}
+}
+
+func TestOptions_otlpEndpointOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	opts := &Options{TracesEndpoint: "collector:4317, https://otlp.vendor.com:4317", MetricsEndpoint: "collector:4317"}
+
+	list := opts.otlpEndpointOptions("traces")
+	if len(list) != 2 {
+		t.Fatalf("otlpEndpointOptions(\"traces\") returned %d options, want 2", len(list))
+	}
+	for i, want := range []string{"collector:4317", "https://otlp.vendor.com:4317"} {
+		if endpoint, _ := list[i].otlpSignalEndpoint("traces"); endpoint != want {
+			t.Errorf("endpoint %d = %q, want %q", i, endpoint, want)
+		}
+	}
+	if opts.TracesEndpoint != "collector:4317, https://otlp.vendor.com:4317" {
+		t.Errorf("TracesEndpoint = %q, want the original list to be kept", opts.TracesEndpoint)
+	}
+
+	if list := opts.otlpEndpointOptions("metrics"); len(list) != 1 || list[0] != opts {
+		t.Errorf("otlpEndpointOptions(\"metrics\") = %v, want the options themselves for a single endpoint", list)
+	}
+}
+
+func TestOptions_otlpEndpointOptions_Env(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "collector:4317, https://otlp.vendor.com:4317")
+	os.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "collector:4317")
+
+	opts := &Options{TracesEndpoint: "ignored:4317"}
+
+	list := opts.otlpEndpointOptions("traces")
+	if len(list) != 2 {
+		t.Fatalf("otlpEndpointOptions(\"traces\") returned %d options, want 2", len(list))
+	}
+	for i, want := range []string{"collector:4317", "https://otlp.vendor.com:4317"} {
+		if endpoint, _ := list[i].otlpSignalEndpoint("traces"); endpoint != want {
+			t.Errorf("endpoint %d = %q, want %q", i, endpoint, want)
+		}
+	}
+
+	// A single endpoint in the environment is left to the exporter
+	if list := opts.otlpEndpointOptions("metrics"); len(list) != 1 || list[0] != opts {
+		t.Errorf("otlpEndpointOptions(\"metrics\") = %v, want the options themselves for a single endpoint", list)
+	}
+	if endpoint, _ := opts.otlpSignalEndpoint("metrics"); endpoint != "" {
+		t.Errorf("otlpSignalEndpoint(\"metrics\") = %q, want \"\" for the exporter to read the environment", endpoint)
+	}
+}
+
+func TestTelemetry_MultipleEndpoints(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:      "test-service",
+		TracesEndpoint:   "localhost:4317,localhost:14317",
+		LogsEndpoint:     "localhost:4317,localhost:14317",
+		ExporterInsecure: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if !tel.TracesEnabled() || !tel.LogsEnabled() {
+		t.Errorf("signals enabled = (traces %v, logs %v), want both", tel.TracesEnabled(), tel.LogsEnabled())
+	}
+}
+
+func TestTelemetry_MetricsExporterIsolation(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	// Occupy the port so the built-in Prometheus server can't start
+	busy, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	defer busy.Close()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:      "test-service",
+		MetricsExporter:  "prometheus,manual",
+		PrometheusServer: true,
+		PrometheusPort:   busy.Addr().(*net.TCPAddr).Port,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v, want degraded startup", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if !tel.MetricsEnabled() {
+		t.Error("MetricsEnabled() = false, want the remaining exporters running")
+	}
+
+	errs := map[string]error{}
+	for _, e := range tel.ExporterEndpoints() {
+		errs[e.Exporter] = e.Err
+	}
+	if errs["prometheus"] == nil {
+		t.Error("prometheus Err = nil, want the failed server to be reported")
+	}
+	if errs["manual"] != nil {
+		t.Errorf("manual Err = %v, want nil", errs["manual"])
+	}
+	if state := tel.ExporterState().Signals["metrics"]; state.State != ExportStateDegraded {
+		t.Errorf("metrics state = %v, want degraded", state.State)
+	}
+
+	if _, err := tel.CollectMetrics(ctx); err != nil {
+		t.Errorf("CollectMetrics() error = %v, want the manual reader to keep working", err)
+	}
+}
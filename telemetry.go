@@ -2,24 +2,36 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	otellog "go.opentelemetry.io/otel/log"
 	lognoop "go.opentelemetry.io/otel/log/noop"
+	"go.opentelemetry.io/otel/propagation"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
 )
 
 type Telemetry struct {
+	// mu guards the fields below, which Reconfigure swaps
+	mu sync.RWMutex
+
 	cfg *Options
 
 	lp *sdklog.LoggerProvider
@@ -29,124 +41,298 @@ type Telemetry struct {
 	logger otellog.Logger
 	tracer trace.Tracer
 
+	// propagator reads and writes the trace context and baggage, whether or
+	// not traces are enabled; it never changes
+	propagator propagation.TextMapPropagator
+
 	// Prometheus-specific fields
 	promServer  *http.Server
 	promHandler http.Handler
+	// promMux is the handler the built-in Prometheus server serves for this
+	// configuration; Reconfigure installs it on a server handed over to it
+	promMux *http.ServeMux
+
+	// manualReader is set when MetricsExporter includes "manual"
+	manualReader *sdkmetric.ManualReader
+
+	// health tracks OTLP export outcomes, reported by ExporterState
+	health *exportHealth
+
+	// levels holds the ComponentLevels overrides, changed by SetComponentLevel
+	levels *componentLevels
+
+	// kafkaConn is the Kafka transport created for KafkaProducer, closed by Shutdown
+	kafkaConn *grpc.ClientConn
+
+	// spool is the spool created for SpoolDir, closed by Shutdown
+	spool *spool
+
+	// endpoints lists the active exporters, reported by ExporterEndpoints
+	endpoints []ExporterEndpoint
+
+	// instruments caches the instruments created by Counter, Histogram, and
+	// the related shortcuts
+	instruments *instrumentCache
+
+	// Handles returned by Logger and Tracer; they forward to the current
+	// logger and tracer so they survive Reconfigure
+	loggerHandle otellog.Logger
+	tracerHandle trace.Tracer
+
+	// Handles returned by LoggerProvider and PrometheusHandler; they forward
+	// to the current logger provider and Prometheus handler
+	lpHandle   *sdklog.LoggerProvider
+	promHandle http.Handler
+
+	// reconfigureMu serializes Reconfigure
+	reconfigureMu sync.Mutex
+
+	// tracers caches the tracers returned by TracerFor
+	tracers sync.Map
+
+	// owner and scopeAttrs are set on a Telemetry derived with WithAttributes:
+	// owner is the Telemetry that owns the shared providers, and scopeAttrs
+	// are added to the instrumentation scope of its loggers, tracers, and meter
+	owner      *Telemetry
+	scopeAttrs []attribute.KeyValue
+}
+
+// ShutdownError reports a failure to flush or shut down one telemetry component.
+// Shutdown and Flush return these joined with errors.Join, so callers can inspect each
+// failure with errors.As or by unwrapping the joined error.
+type ShutdownError struct {
+	// Component is the component that failed: "prometheus server", "logs", "metrics", "traces", or "kafka".
+	Component string
+	// Op is the operation that failed: "flush", "shutdown", or "close".
+	Op string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("failed to %s %s: %v", e.Op, e.Component, e.Err)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
 }
 
 // Shutdown shuts down the logger, meter, and tracer.
 // It forces a flush of all pending telemetry data before shutting down.
+// If Options.ShutdownTimeout is set, it bounds the whole shutdown.
+// Every component is shut down even if an earlier one fails; failures are
+// returned as *ShutdownError values joined with errors.Join.
+// On a Telemetry derived with WithAttributes, Shutdown does nothing.
 func (t *Telemetry) Shutdown(ctx context.Context) error {
-	var err error
+	if t.owner != nil {
+		// The providers belong to the Telemetry this one was derived from
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.cfg != nil && t.cfg.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.cfg.ShutdownTimeout)
+		defer cancel()
+	}
+
+	var errs []error
+	record := func(component, op string, err error) {
+		if err != nil {
+			errs = append(errs, &ShutdownError{Component: component, Op: op, Err: err})
+		}
+	}
 
 	// Shutdown Prometheus HTTP server first
 	if t.promServer != nil {
-		if shutdownErr := t.promServer.Shutdown(ctx); shutdownErr != nil {
-			err = fmt.Errorf("failed to shutdown Prometheus server: %w", shutdownErr)
-		}
+		record("prometheus server", "shutdown", t.promServer.Shutdown(ctx))
 	}
 
 	// Force flush and shutdown logger provider
+	if t.lpHandle != nil {
+		record("logs", "shutdown", t.lpHandle.Shutdown(ctx))
+	}
 	if t.lp != nil {
-		if flushErr := t.lp.ForceFlush(ctx); flushErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to flush logs: %w", err, flushErr)
-			} else {
-				err = fmt.Errorf("failed to flush logs: %w", flushErr)
-			}
-		}
-		if shutdownErr := t.lp.Shutdown(ctx); shutdownErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to shutdown logs: %w", err, shutdownErr)
-			} else {
-				err = fmt.Errorf("failed to shutdown logs: %w", shutdownErr)
-			}
-		}
+		record("logs", "flush", t.lp.ForceFlush(ctx))
+		record("logs", "shutdown", t.lp.Shutdown(ctx))
 	}
 
 	// Force flush and shutdown meter provider
 	if t.mp != nil {
-		if flushErr := t.mp.ForceFlush(ctx); flushErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to flush metrics: %w", err, flushErr)
-			} else {
-				err = fmt.Errorf("failed to flush metrics: %w", flushErr)
-			}
-		}
-		if shutdownErr := t.mp.Shutdown(ctx); shutdownErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to shutdown metrics: %w", err, shutdownErr)
-			} else {
-				err = fmt.Errorf("failed to shutdown metrics: %w", shutdownErr)
-			}
-		}
+		record("metrics", "flush", t.mp.ForceFlush(ctx))
+		record("metrics", "shutdown", t.mp.Shutdown(ctx))
 	}
 
 	// Force flush and shutdown tracer provider
 	if t.tp != nil {
-		if flushErr := t.tp.ForceFlush(ctx); flushErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to flush traces: %w", err, flushErr)
-			} else {
-				err = fmt.Errorf("failed to flush traces: %w", flushErr)
-			}
-		}
-		if shutdownErr := t.tp.Shutdown(ctx); shutdownErr != nil {
-			if err != nil {
-				err = fmt.Errorf("%w; failed to shutdown traces: %w", err, shutdownErr)
-			} else {
-				err = fmt.Errorf("failed to shutdown traces: %w", shutdownErr)
-			}
+		record("traces", "flush", t.tp.ForceFlush(ctx))
+		record("traces", "shutdown", t.tp.Shutdown(ctx))
+	}
+
+	// Stop the spool replays and close the Kafka transport once the exporters
+	// are done with them
+	if t.spool != nil {
+		t.spool.close()
+	}
+	if t.kafkaConn != nil {
+		record("kafka", "close", t.kafkaConn.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Flush exports all pending telemetry data without shutting anything down.
+// Call it before the process exits abruptly, such as from a logger's Fatal
+// exit handler, so the final log lines and finished spans are not lost.
+// If Options.ShutdownTimeout is set, it also bounds the flush.
+func (t *Telemetry) Flush(ctx context.Context) error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.cfg != nil && t.cfg.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.cfg.ShutdownTimeout)
+		defer cancel()
+	}
+
+	var errs []error
+	record := func(component string, err error) {
+		if err != nil {
+			errs = append(errs, &ShutdownError{Component: component, Op: "flush", Err: err})
 		}
 	}
 
-	return err
+	if t.lp != nil {
+		record("logs", t.lp.ForceFlush(ctx))
+	}
+	if t.tp != nil {
+		record("traces", t.tp.ForceFlush(ctx))
+	}
+	if t.mp != nil {
+		record("metrics", t.mp.ForceFlush(ctx))
+	}
+
+	return errors.Join(errs...)
 }
 
 // Logger returns the OTel logger.
 func (t *Telemetry) Logger() otellog.Logger {
-	return t.logger
+	return t.loggerHandle
+}
+
+// LoggerFor returns an OTel logger for the named component.
+// The logger uses the component name as its instrumentation scope and carries a
+// "component" scope attribute, so logs from different subsystems can be filtered
+// in the backend. Returns a noop logger if OTel logs are disabled.
+func (t *Telemetry) LoggerFor(component string) otellog.Logger {
+	lp := t.LoggerProvider()
+	if lp == nil {
+		return lognoop.NewLoggerProvider().Logger(component)
+	}
+	attrs := append([]attribute.KeyValue{attribute.String("component", component)}, t.scopeAttrs...)
+	return lp.Logger(component,
+		otellog.WithInstrumentationVersion(t.ServiceVersion()),
+		otellog.WithInstrumentationAttributes(attrs...),
+	)
 }
 
 // Tracer returns the tracer.
 func (t *Telemetry) Tracer() trace.Tracer {
-	return t.tracer
+	return t.tracerHandle
 }
 
 // LoggerProvider returns the logger otel logger provider.
-// Returns nil if OTel logs are disabled.
+// It forwards to the current configuration, so hooks created from it keep
+// working across Reconfigure. Returns nil if OTel logs are disabled.
 func (t *Telemetry) LoggerProvider() *sdklog.LoggerProvider {
-	return t.lp
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.lp == nil {
+		return nil
+	}
+	return t.lpHandle
 }
 
 // MeterProvider returns the meter otel meter provider.
 // Returns nil if OTel metrics are disabled.
 func (t *Telemetry) MeterProvider() *sdkmetric.MeterProvider {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.mp
 }
 
 // TracerProvider returns the tracer otel tracer provider.
 // Returns nil if OTel traces are disabled.
 func (t *Telemetry) TracerProvider() *sdktrace.TracerProvider {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.tp
 }
 
 // StartSpan starts a new span with the given name. The span must be ended by calling End.
 // The returned context contains the span information which will be automatically extracted
 // by the logger's OTel integration (for supported loggers like Zap, Zerolog, Logrus, Slog).
-func (t *Telemetry) StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
-	return t.tracer.Start(ctx, name)
+// Options such as trace.WithSpanKind, trace.WithAttributes, and trace.WithLinks
+// configure the span.
+func (t *Telemetry) StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return t.currentTracer().Start(ctx, name, opts...)
+}
+
+// StartSpanWithAttributes starts a new span with the given name and attributes.
+// It is shorthand for StartSpan with trace.WithAttributes.
+func (t *Telemetry) StartSpanWithAttributes(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.StartSpan(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// SpanFromContext returns the current span in ctx, or a noop span if there is
+// none, so callers can add events or attributes without importing the trace API.
+func (t *Telemetry) SpanFromContext(ctx context.Context) trace.Span {
+	return trace.SpanFromContext(ctx)
+}
+
+// IsRecording reports whether the current span in ctx is recording, so
+// callers can skip computing expensive attributes for unsampled requests.
+func (t *Telemetry) IsRecording(ctx context.Context) bool {
+	return trace.SpanFromContext(ctx).IsRecording()
 }
 
 // PrometheusHandler returns the Prometheus HTTP handler for metrics.
 // Returns nil if Prometheus metrics are not enabled.
 // Use this to integrate Prometheus metrics into your own HTTP server.
+// The handler serves the current configuration's metrics across Reconfigure.
 func (t *Telemetry) PrometheusHandler() http.Handler {
-	return t.promHandler
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.promHandler == nil {
+		return nil
+	}
+	return t.promHandle
+}
+
+// CollectMetrics collects the current metrics from the manual reader.
+// Returns an error unless MetricsExporter includes "manual"; see the
+// telemetrytest package for helpers to find and assert on the result.
+func (t *Telemetry) CollectMetrics(ctx context.Context) (metricdata.ResourceMetrics, error) {
+	t.mu.RLock()
+	reader := t.manualReader
+	t.mu.RUnlock()
+
+	var rm metricdata.ResourceMetrics
+	if reader == nil {
+		return rm, errors.New("metrics are not collected manually (set MetricsExporter to \"manual\")")
+	}
+	if err := reader.Collect(ctx, &rm); err != nil {
+		return rm, fmt.Errorf("failed to collect metrics: %w", err)
+	}
+	return rm, nil
 }
 
 // ServiceName returns the configured service name.
 func (t *Telemetry) ServiceName() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	if t.cfg == nil {
 		return ""
 	}
@@ -155,6 +341,8 @@ func (t *Telemetry) ServiceName() string {
 
 // ServiceVersion returns the configured service version.
 func (t *Telemetry) ServiceVersion() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	if t.cfg == nil {
 		return ""
 	}
@@ -169,14 +357,42 @@ func New(ctx context.Context, opts *Options) (*Telemetry, error) {
 		opts = DefaultOptions()
 	}
 
-	// Apply environment variable overrides
-	opts.applyEnvVars()
+	// Work on a copy, so the caller's Options are never modified and can be
+	// passed to New or Reconfigure again
+	cfg := *opts
+	opts = &cfg
 
-	return newWithOptions(ctx, opts)
+	// Apply the declarative config file or environment variable overrides
+	if err := opts.applyOverrides(); err != nil {
+		return nil, err
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	t, err := newWithOptions(ctx, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	installContribBridges(t, opts)
+	return t, nil
+}
+
+// promServerHandler is the handler of the built-in Prometheus server. It serves
+// the mux of the current configuration, so Reconfigure can keep the server
+// (and its port) and swap only the mux.
+type promServerHandler struct {
+	mux atomic.Pointer[http.ServeMux]
+}
+
+func (h *promServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.Load().ServeHTTP(w, r)
 }
 
 // newWithOptions creates a new Telemetry instance with the given options.
-func newWithOptions(ctx context.Context, opts *Options) (*Telemetry, error) {
+// running is the built-in Prometheus server of the configuration being
+// replaced, if any; it is reused instead of binding its port again.
+func newWithOptions(ctx context.Context, opts *Options, running *http.Server) (_ *Telemetry, err error) {
 	var lp *sdklog.LoggerProvider
 	var mp *sdkmetric.MeterProvider
 	var tp *sdktrace.TracerProvider
@@ -184,39 +400,77 @@ func newWithOptions(ctx context.Context, opts *Options) (*Telemetry, error) {
 	var tracer trace.Tracer
 	var promServer *http.Server
 	var promHandler http.Handler
-	var err error
+	var promMux *http.ServeMux
+	var manualReader *sdkmetric.ManualReader
+	var endpoints, metricEndpoints []ExporterEndpoint
+
+	// Track OTLP export outcomes for ExporterState
+	health := newExportHealth()
+	opts.health = health
+
+	// Per-component severity overrides, adjustable with SetComponentLevel
+	opts.levels = newComponentLevels(opts.ComponentLevels)
+
+	// The async log queue reports into the meter provider built below
+	opts.meterProvider = nil
+
+	// Release the Kafka transport and the spool if a later step fails
+	opts.kafkaConn = nil
+	opts.spool = nil
+	defer func() {
+		if err == nil {
+			return
+		}
+		if opts.spool != nil {
+			opts.spool.close()
+		}
+		if opts.kafkaConn != nil {
+			_ = opts.kafkaConn.Close()
+		}
+	}()
 
-	// Create resource if OTel is enabled (auto-detected from environment)
-	// or if metrics exporter is explicitly configured
-	var res *resource.Resource
-	metricsExporterSet := opts.MetricsExporter != "" || os.Getenv("OTEL_METRICS_EXPORTER") != ""
-	if shouldEnableOTel() || metricsExporterSet {
-		res = newResource(opts.ServiceName, opts.ServiceVersion)
+	// Publish OTLP exports to Kafka instead of a collector
+	if opts.KafkaProducer != nil {
+		if opts.kafkaConn, err = newKafkaConn(opts.KafkaProducer, opts); err != nil {
+			return nil, err
+		}
 	}
 
-	// Initialize providers conditionally based on environment variables
-	lp, err = newLoggerProvider(ctx, res, opts.BatchExport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create logger provider: %w", err)
+	// Spool failed OTLP exports to disk for replay when the collector recovers
+	if opts.SpoolDir != "" && opts.otlpConn() == nil {
+		if opts.spool, err = newSpool(opts.SpoolDir, opts.SpoolMaxBytes, health); err != nil {
+			return nil, err
+		}
 	}
 
-	if lp != nil {
-		logger = lp.Logger(opts.ServiceName)
-	} else {
-		// Use noop logger if logs are disabled (default OTel behavior)
-		logger = lognoop.NewLoggerProvider().Logger(opts.ServiceName)
+	// Send error logs and spans to Sentry alongside the normal export
+	opts.sentry = nil
+	if opts.SentryDSN != "" {
+		if opts.sentry, err = newSentryClient(opts.SentryDSN, opts.SentryEnvironment); err != nil {
+			return nil, err
+		}
 	}
 
-	tp, err = newTracerProvider(ctx, res, opts.BatchExport)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
+	// Create resource if OTel is enabled (auto-detected from environment)
+	// or if metrics exporter is explicitly configured
+	var res *resource.Resource
+	metricsExporterSet := opts.MetricsExporter != "" || os.Getenv("OTEL_METRICS_EXPORTER") != ""
+	logsExporterSet := opts.LogsExporter != "" || os.Getenv("OTEL_LOGS_EXPORTER") != ""
+	endpointSet := opts.enabledByEndpoint("traces") || opts.enabledByEndpoint("metrics") || opts.enabledByEndpoint("logs")
+	customSet := opts.CustomSpanExporter != nil || opts.CustomLogExporter != nil || opts.CustomMetricReader != nil
+	if shouldEnableOTel() || metricsExporterSet || logsExporterSet || endpointSet || customSet || opts.sentry != nil {
+		res = newResource(opts.ServiceName, opts.ServiceVersion, opts.resourceAttributes()...)
 	}
 
-	if tp != nil {
-		tracer = tp.Tracer(opts.ServiceName)
-	} else {
-		// Use noop tracer if traces are disabled (default OTel behavior)
-		tracer = tracenoop.NewTracerProvider().Tracer(opts.ServiceName)
+	// Release the metrics pipeline if a later step fails; a server handed over
+	// by Reconfigure keeps serving the previous configuration
+	shutdownMetrics := func() {
+		if promServer != nil && promServer != running {
+			_ = promServer.Shutdown(ctx)
+		}
+		if mp != nil {
+			_ = mp.Shutdown(ctx)
+		}
 	}
 
 	// Initialize meter provider based on exporter type
@@ -231,16 +485,18 @@ func newWithOptions(ctx context.Context, opts *Options) (*Telemetry, error) {
 	if exporter != "" && exporter != "none" {
 		// Explicitly configured via options or env var
 		enableMetrics = true
-	} else if shouldEnableMetrics() {
-		// Auto-enabled via OTel environment variables
+	} else if shouldEnableMetrics() || (exporter == "" && opts.enabledByEndpoint("metrics")) {
+		// Auto-enabled via OTel environment variables or a MetricsEndpoint option
 		enableMetrics = true
 		exporter = "otlp" // Default to OTLP
 	}
 
-	if enableMetrics {
+	customMetrics := opts.CustomMetricReader != nil && !sdkDisabled()
+	if enableMetrics || customMetrics {
 		// Support multiple exporters via comma-separated list (e.g., "prometheus,otlp")
 		exportersList := strings.Split(exporter, ",")
 		var readers []sdkmetric.Reader
+		var startErrs []error
 
 		for _, exp := range exportersList {
 			exp = strings.TrimSpace(exp)
@@ -248,13 +504,13 @@ func newWithOptions(ctx context.Context, opts *Options) (*Telemetry, error) {
 				continue
 			}
 
+			var expErr error
 			switch exp {
 			case "prometheus":
-				var handler http.Handler
-				var promReader sdkmetric.Reader
-				promReader, handler, err = newPrometheusReader(res)
+				promReader, handler, err := newPrometheusReader(res, opts)
 				if err != nil {
-					return nil, fmt.Errorf("failed to create Prometheus reader: %w", err)
+					expErr = fmt.Errorf("failed to create Prometheus reader: %w", err)
+					break
 				}
 				readers = append(readers, promReader)
 
@@ -265,54 +521,220 @@ func newWithOptions(ctx context.Context, opts *Options) (*Telemetry, error) {
 
 				// Only start built-in server if explicitly enabled and not already started
 				if opts.PrometheusServer && promServer == nil {
+					promMux = http.NewServeMux()
+					promMux.Handle(opts.prometheusPath(), handler)
+					addr := ":" + strconv.Itoa(opts.PrometheusPort)
+
+					// Keep the previous configuration's server on the same port;
+					// Reconfigure switches it to promMux once the swap succeeds
+					if running != nil && running.Addr == addr {
+						promServer = running
+						break
+					}
+
 					// Start Prometheus HTTP server
-					mux := http.NewServeMux()
-					mux.Handle(opts.PrometheusPath, handler)
+					serverHandler := &promServerHandler{}
+					serverHandler.mux.Store(promMux)
+					server := &http.Server{
+						Addr:    addr,
+						Handler: serverHandler,
+					}
 
-					promServer = &http.Server{
-						Addr:    ":" + strconv.Itoa(opts.PrometheusPort),
-						Handler: mux,
+					// Bind before returning, so a port in use is reported as a failed exporter
+					listener, err := net.Listen("tcp", server.Addr)
+					if err != nil {
+						expErr = fmt.Errorf("failed to start Prometheus server: %w", err)
+						break
 					}
+					promServer = server
 
-					// Start server in background
+					// Serve in background
 					go func() {
-						if err := promServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
 							fmt.Fprintf(os.Stderr, "Prometheus server error: %v\n", err)
 						}
 					}()
 				}
 
 			case "otlp":
-				otlpReader, err := newOTLPReader(ctx, opts.BatchExport)
-				if err != nil {
-					return nil, fmt.Errorf("failed to create OTLP reader: %w", err)
+				for _, endpointOpts := range opts.otlpEndpointOptions("metrics") {
+					otlpReader, err := newOTLPReader(ctx, endpointOpts)
+					if err != nil {
+						expErr = errors.Join(expErr, fmt.Errorf("failed to create OTLP reader: %w", err))
+						continue
+					}
+					readers = append(readers, otlpReader)
+				}
+
+			case "manual":
+				// Collected on demand with CollectMetrics, for tests
+				if manualReader == nil {
+					manualReader = sdkmetric.NewManualReader()
+					readers = append(readers, manualReader)
 				}
-				readers = append(readers, otlpReader)
 
 			default:
-				return nil, fmt.Errorf("unsupported metrics exporter: %s (supported: otlp, prometheus, none)", exp)
+				shutdownMetrics()
+				return nil, fmt.Errorf("unsupported metrics exporter: %s (supported: otlp, prometheus, manual, none)", exp)
 			}
+
+			endpoint := newExporterEndpoint("metrics", exp, opts)
+			if expErr != nil {
+				// Report the failed exporter and keep the others running
+				otel.Handle(expErr)
+				health.record("metrics", expErr)
+				endpoint.Err = expErr
+				startErrs = append(startErrs, expErr)
+			}
+			metricEndpoints = append(metricEndpoints, endpoint)
+		}
+
+		if customMetrics {
+			readers = append(readers, opts.CustomMetricReader)
+			metricEndpoints = append(metricEndpoints, newExporterEndpoint("metrics", "custom", opts))
+		}
+
+		// Fail only if no metrics exporter could be started
+		if len(readers) == 0 && len(startErrs) > 0 {
+			shutdownMetrics()
+			return nil, errors.Join(startErrs...)
 		}
 
 		// Create meter provider with all readers
 		if len(readers) > 0 {
 			meterProviderOptions := []sdkmetric.Option{sdkmetric.WithResource(res)}
+			if opts.MetricCardinalityLimit > 0 {
+				meterProviderOptions = append(meterProviderOptions, sdkmetric.WithCardinalityLimit(opts.MetricCardinalityLimit))
+			}
+			if views := bucketPresetViews(opts.HistogramBucketPresets); len(views) > 0 {
+				meterProviderOptions = append(meterProviderOptions, sdkmetric.WithView(views...))
+			}
+			if opts.PrometheusExemplars {
+				// Pin the default filter so OTEL_METRICS_EXEMPLAR_FILTER can't turn exemplars off
+				meterProviderOptions = append(meterProviderOptions, sdkmetric.WithExemplarFilter(exemplar.TraceBasedFilter))
+			}
 			for _, reader := range readers {
 				meterProviderOptions = append(meterProviderOptions, sdkmetric.WithReader(reader))
 			}
 			mp = sdkmetric.NewMeterProvider(meterProviderOptions...)
 			otel.SetMeterProvider(mp)
+			opts.meterProvider = mp
+			if opts.EnableBuildInfo {
+				registerBuildInfo(mp)
+			}
+			if opts.UptimeMetrics {
+				registerUptimeMetrics(mp)
+			}
 		}
 	}
 
-	return &Telemetry{
+	// Initialize logger provider based on exporter type
+	// Check if logs exporter is explicitly set in options or environment
+	logsExporter := opts.LogsExporter
+	if logsExporter == "" {
+		logsExporter = os.Getenv("OTEL_LOGS_EXPORTER")
+	}
+
+	switch {
+	case logsExporter == "none" || sdkDisabled():
+		// Logs explicitly disabled
+	case logsExporter == "":
+		// Auto-enabled via OTel environment variables
+		lp, err = newLoggerProvider(ctx, res, opts)
+	default:
+		lp, err = newLoggerProviderWithExporters(ctx, res, opts, logsExporter)
+	}
+	if err != nil {
+		shutdownMetrics()
+		return nil, fmt.Errorf("failed to create logger provider: %w", err)
+	}
+	logsExported := lp != nil
+	if lp == nil && !sdkDisabled() {
+		if extra := extraLogProcessors(opts); len(extra) > 0 {
+			// Logs are only sent to the custom exporter or Sentry
+			providerOptions := append(enrichLogProcessors(opts), extra...)
+			lp = sdklog.NewLoggerProvider(append(providerOptions, sdklog.WithResource(res))...)
+		}
+	}
+
+	if lp != nil {
+		logger = lp.Logger(opts.ServiceName)
+
+		if logsExported && logsExporter == "" {
+			endpoints = append(endpoints, newExporterEndpoint("logs", "otlp", opts))
+		}
+		for _, name := range strings.Split(logsExporter, ",") {
+			if name = strings.TrimSpace(name); logsExported && name != "" && name != "none" {
+				endpoints = append(endpoints, newExporterEndpoint("logs", name, opts))
+			}
+		}
+		if opts.CustomLogExporter != nil {
+			endpoints = append(endpoints, newExporterEndpoint("logs", "custom", opts))
+		}
+		if opts.sentry != nil {
+			endpoints = append(endpoints, newExporterEndpoint("logs", "sentry", opts))
+		}
+	} else {
+		// Use noop logger if logs are disabled (default OTel behavior)
+		logger = lognoop.NewLoggerProvider().Logger(opts.ServiceName)
+	}
+
+	tp, err = newTracerProvider(ctx, res, opts)
+	if err != nil {
+		if lp != nil {
+			_ = lp.Shutdown(ctx)
+		}
+		shutdownMetrics()
+		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
+	}
+
+	if tp != nil {
+		tracer = tp.Tracer(opts.ServiceName)
+		if opts.otlpTracesEnabled() {
+			endpoints = append(endpoints, newExporterEndpoint("traces", "otlp", opts))
+		}
+		if opts.CustomSpanExporter != nil {
+			endpoints = append(endpoints, newExporterEndpoint("traces", "custom", opts))
+		}
+		if opts.sentry != nil {
+			endpoints = append(endpoints, newExporterEndpoint("traces", "sentry", opts))
+		}
+	} else {
+		// Use noop tracer if traces are disabled (default OTel behavior)
+		tracer = tracenoop.NewTracerProvider().Tracer(opts.ServiceName)
+	}
+
+	// Report the metrics exporters after the logs and traces exporters
+	endpoints = append(endpoints, metricEndpoints...)
+
+	t := &Telemetry{
 		cfg:         opts,
 		lp:          lp,
 		mp:          mp,
 		tp:          tp,
 		logger:      logger,
 		tracer:      tracer,
+		propagator:  newPropagator(),
 		promServer:  promServer,
 		promHandler: promHandler,
-	}, nil
+		promMux:     promMux,
+
+		manualReader: manualReader,
+		health:       health,
+		levels:       opts.levels,
+		kafkaConn:    opts.kafkaConn,
+		spool:        opts.spool,
+		endpoints:    endpoints,
+		instruments:  newInstrumentCache(mp, opts.ServiceName, opts.ServiceVersion),
+	}
+	t.loggerHandle = &reconfigurableLogger{t: t}
+	t.tracerHandle = &reconfigurableTracer{t: t}
+	t.lpHandle = newReconfigurableLoggerProvider(t)
+	t.promHandle = &reconfigurablePromHandler{t: t}
+
+	if opts.LogDiagnostics {
+		fmt.Fprintf(os.Stderr, "telemetry: %s\n", t.Diagnostics())
+	}
+
+	return t, nil
 }
@@ -0,0 +1,1005 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ekristen/go-telemetry/v2/telemetrytest"
+)
+
+func TestTelemetry_LoggerFor(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	recorder := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:       "test-service",
+		ServiceVersion:    "1.0.0",
+		CustomLogExporter: recorder,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("connected"))
+	record.SetSeverity(otellog.SeverityInfo)
+	tel.LoggerFor("database").Emit(ctx, record)
+
+	if err := tel.LoggerProvider().ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush() error = %v", err)
+	}
+
+	records := recorder.Records()
+	if len(records) != 1 {
+		t.Fatalf("exported %d records, want 1", len(records))
+	}
+	if records[0].Scope != "database" {
+		t.Errorf("Scope = %q, want %q", records[0].Scope, "database")
+	}
+	if records[0].ScopeVersion != "1.0.0" {
+		t.Errorf("ScopeVersion = %q, want %q", records[0].ScopeVersion, "1.0.0")
+	}
+}
+
+func TestTelemetry_LoggerFor_Disabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	logger := tel.LoggerFor("database")
+	if logger == nil {
+		t.Fatal("LoggerFor() returned nil with OTel disabled, want noop logger")
+	}
+}
+
+func TestTelemetry_LoggerFor_WithOTelEnabled(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317")
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if tel.LoggerProvider() == nil {
+		t.Fatal("LoggerProvider() = nil, want provider with OTLP endpoint set")
+	}
+
+	if logger := tel.LoggerFor("database"); logger == nil {
+		t.Fatal("LoggerFor() returned nil")
+	}
+}
+
+func TestNew_LogsExporterFluentForward(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:  "test-service",
+		LogsExporter: "fluentforward",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if tel.LoggerProvider() == nil {
+		t.Error("LoggerProvider() = nil, want provider for fluentforward exporter")
+	}
+	if tel.TracerProvider() != nil {
+		t.Error("TracerProvider() != nil, want nil without OTel environment variables")
+	}
+}
+
+func TestNew_LogsExporterUnsupported(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	_, err := New(context.Background(), &Options{
+		ServiceName:  "test-service",
+		LogsExporter: "carrier-pigeon",
+	})
+	if err == nil {
+		t.Error("New() error = nil, want unsupported exporter error")
+	}
+}
+
+// blockingExporter is a log exporter whose flush blocks until the context is
+// done and whose shutdown fails.
+type blockingExporter struct{}
+
+func (blockingExporter) Export(ctx context.Context, records []sdklog.Record) error { return nil }
+
+func (blockingExporter) ForceFlush(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (blockingExporter) Shutdown(ctx context.Context) error {
+	return errors.New("exporter unavailable")
+}
+
+func TestTelemetry_Shutdown_Errors(t *testing.T) {
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(blockingExporter{})))
+	tel := &Telemetry{
+		cfg: &Options{ShutdownTimeout: 10 * time.Millisecond},
+		lp:  lp,
+	}
+
+	start := time.Now()
+	err := tel.Shutdown(context.Background())
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Shutdown() took %v, want ShutdownTimeout to apply", elapsed)
+	}
+	if err == nil {
+		t.Fatal("Shutdown() error = nil, want flush and shutdown errors")
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown() error = %v, want context.DeadlineExceeded in chain", err)
+	}
+
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("Shutdown() error = %v, want *ShutdownError", err)
+	}
+	if shutdownErr.Component != "logs" || shutdownErr.Op != "flush" {
+		t.Errorf("first ShutdownError = %s/%s, want logs/flush", shutdownErr.Component, shutdownErr.Op)
+	}
+
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Shutdown() error = %T, want joined error", err)
+	}
+	if got := len(joined.Unwrap()); got != 2 {
+		t.Errorf("Shutdown() returned %d errors, want 2 (flush and shutdown)", got)
+	}
+}
+
+func TestTelemetry_Shutdown_NoProviders(t *testing.T) {
+	tel := &Telemetry{cfg: DefaultOptions()}
+
+	if err := tel.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error = %v, want nil", err)
+	}
+}
+
+func TestTelemetry_Flush(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	spans := tracetest.NewInMemoryExporter()
+	logs := &recordingLogExporter{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		BatchExport:        true,
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	_, span := tel.StartSpan(ctx, "before-exit")
+	span.End()
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("fatal"))
+	tel.Logger().Emit(ctx, record)
+
+	if err := tel.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := spans.GetSpans(); len(got) != 1 || got[0].Name != "before-exit" {
+		t.Errorf("spans after Flush() = %v, want before-exit", got)
+	}
+	if len(logs.bodies) != 1 || logs.bodies[0] != "fatal" {
+		t.Errorf("logs after Flush() = %v, want fatal", logs.bodies)
+	}
+}
+
+func TestTelemetry_Reconfigure(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:  "test-service",
+		LogsExporter: "none",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	logger := tel.Logger()
+	if logger.Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityInfo}) {
+		t.Error("Logger().Enabled() = true with logs disabled, want false")
+	}
+
+	err = tel.Reconfigure(ctx, &Options{
+		ServiceName:  "reconfigured-service",
+		LogsExporter: "fluentforward",
+	})
+	if err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	if tel.LoggerProvider() == nil {
+		t.Error("LoggerProvider() = nil after Reconfigure, want provider")
+	}
+	if tel.ServiceName() != "reconfigured-service" {
+		t.Errorf("ServiceName() = %v, want 'reconfigured-service'", tel.ServiceName())
+	}
+	if !logger.Enabled(ctx, otellog.EnabledParameters{Severity: otellog.SeverityInfo}) {
+		t.Error("Logger() handle from before Reconfigure is not using the new provider")
+	}
+}
+
+func TestTelemetry_Reconfigure_KeepsHandles(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	first := &telemetrytest.LogRecorder{}
+
+	tel, err := New(ctx, &Options{
+		ServiceName:       "test-service",
+		CustomLogExporter: first,
+		MetricsExporter:   "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	// A hook keeps the logger provider it was created with
+	hook := tel.LoggerProvider().Logger("hook", otellog.WithInstrumentationVersion("1.2.3"))
+	counter := tel.Counter("orders.placed")
+
+	emit := func(msg string) {
+		var record otellog.Record
+		record.SetBody(otellog.StringValue(msg))
+		record.SetSeverity(otellog.SeverityInfo)
+		hook.Emit(ctx, record)
+	}
+	emit("before reconfigure")
+	counter.Add(ctx, 2)
+	first.AssertLogged(t, telemetrytest.WithMessage("before reconfigure"))
+
+	second := &telemetrytest.LogRecorder{}
+	if err := tel.Reconfigure(ctx, &Options{
+		ServiceName:       "reconfigured-service",
+		CustomLogExporter: second,
+		MetricsExporter:   "manual",
+	}); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	emit("after reconfigure")
+	second.AssertLogged(t, telemetrytest.WithMessage("after reconfigure"), telemetrytest.WithScope("hook"))
+	first.AssertNotLogged(t, telemetrytest.WithMessage("after reconfigure"))
+
+	counter.Add(ctx, 3)
+	collected, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	var placed int64
+	for _, sm := range collected.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && m.Name == "orders.placed" {
+				placed += sumInt64(sum)
+			}
+		}
+	}
+	if placed != 3 {
+		t.Errorf("orders.placed after Reconfigure = %d, want 3", placed)
+	}
+	if counter != tel.Counter("orders.placed") {
+		t.Error("Counter() returned a different instrument after Reconfigure")
+	}
+}
+
+func TestTelemetry_Reconfigure_KeepsConfigOnError(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	err = tel.Reconfigure(ctx, &Options{
+		ServiceName:  "reconfigured-service",
+		LogsExporter: "carrier-pigeon",
+	})
+	if err == nil {
+		t.Fatal("Reconfigure() error = nil, want unsupported exporter error")
+	}
+	if tel.ServiceName() != "test-service" {
+		t.Errorf("ServiceName() = %v, want 'test-service' to be kept", tel.ServiceName())
+	}
+}
+
+func TestTelemetry_Reconfigure_PrometheusServer(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	opts := func(path, logsExporter string) *Options {
+		return &Options{
+			ServiceName:      "test-service",
+			MetricsExporter:  "prometheus",
+			LogsExporter:     logsExporter,
+			PrometheusServer: true,
+			PrometheusPort:   port,
+			PrometheusPath:   path,
+		}
+	}
+	status := func(path string) int {
+		t.Helper()
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, path))
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	tel, err := New(ctx, opts("/metrics", "none"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+	handler := tel.PrometheusHandler()
+
+	if err := tel.Reconfigure(ctx, opts("/metrics", "carrier-pigeon")); err == nil {
+		t.Fatal("Reconfigure() error = nil, want unsupported exporter error")
+	}
+	if got := status("/metrics"); got != http.StatusOK {
+		t.Errorf("GET /metrics after failed Reconfigure = %d, want %d", got, http.StatusOK)
+	}
+
+	if err := tel.Reconfigure(ctx, opts("/prom", "none")); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	if got := status("/prom"); got != http.StatusOK {
+		t.Errorf("GET /prom after Reconfigure = %d, want %d", got, http.StatusOK)
+	}
+	if got := status("/metrics"); got != http.StatusNotFound {
+		t.Errorf("GET /metrics after Reconfigure = %d, want %d", got, http.StatusNotFound)
+	}
+
+	// The handler from before Reconfigure serves the new configuration
+	tel.Counter("orders.placed").Add(ctx, 1)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "orders_placed") {
+		t.Errorf("PrometheusHandler() from before Reconfigure body = %q, want orders_placed", rec.Body.String())
+	}
+
+	// Concurrent calls are serialized, so they don't race on the listener
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- tel.Reconfigure(ctx, opts("/prom", "none"))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent Reconfigure() error = %v", err)
+		}
+	}
+	if got := status("/prom"); got != http.StatusOK {
+		t.Errorf("GET /prom after concurrent Reconfigure = %d, want %d", got, http.StatusOK)
+	}
+}
+
+func TestTelemetry_CollectMetrics(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	counter, err := tel.MeterProvider().Meter("test").Int64Counter("requests")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+	counter.Add(ctx, 3)
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if len(rm.ScopeMetrics) != 1 || len(rm.ScopeMetrics[0].Metrics) != 1 {
+		t.Fatalf("CollectMetrics() = %+v, want one metric", rm.ScopeMetrics)
+	}
+	if name := rm.ScopeMetrics[0].Metrics[0].Name; name != "requests" {
+		t.Errorf("metric name = %v, want 'requests'", name)
+	}
+}
+
+func TestTelemetry_CollectMetrics_NotManual(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if _, err := tel.CollectMetrics(ctx); err == nil {
+		t.Error("CollectMetrics() error = nil without the manual exporter, want error")
+	}
+}
+
+func TestTelemetry_MetricCardinalityLimit(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:            "test-service",
+		MetricsExporter:        "manual",
+		MetricCardinalityLimit: 3,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	counter, err := tel.MeterProvider().Meter("test").Int64Counter("requests")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		counter.Add(ctx, 1, metric.WithAttributes(attribute.Int("user", i)))
+	}
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("metric data = %T, want metricdata.Sum[int64]", rm.ScopeMetrics[0].Metrics[0].Data)
+	}
+	if len(sum.DataPoints) != 3 {
+		t.Errorf("got %d series, want 3 with the limit applied", len(sum.DataPoints))
+	}
+
+	var total int64
+	for _, dp := range sum.DataPoints {
+		total += dp.Value
+	}
+	if total != 10 {
+		t.Errorf("total = %d, want 10 (overflow must not lose measurements)", total)
+	}
+}
+
+func TestTelemetry_StartSpanOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	linked := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	_, span := tel.StartSpan(ctx, "with-options",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("route", "/checkout")),
+		trace.WithLinks(trace.Link{
+			SpanContext: linked,
+			Attributes:  []attribute.KeyValue{attribute.String("link.reason", "batch")},
+		}),
+	)
+	span.End()
+
+	_, span = tel.StartSpanWithAttributes(ctx, "with-attributes", attribute.Int("items", 3))
+	span.End()
+
+	got := spans.GetSpans()
+	if len(got) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(got))
+	}
+
+	withOptions := got[0]
+	if withOptions.Name != "with-options" {
+		t.Errorf("Name = %q, want with-options", withOptions.Name)
+	}
+	if withOptions.SpanKind != trace.SpanKindServer {
+		t.Errorf("SpanKind = %v, want server", withOptions.SpanKind)
+	}
+	if attrs := withOptions.Attributes; len(attrs) != 1 || attrs[0] != attribute.String("route", "/checkout") {
+		t.Errorf("Attributes = %v, want route=/checkout", attrs)
+	}
+	if links := withOptions.Links; len(links) != 1 ||
+		!links[0].SpanContext.Equal(linked) ||
+		len(links[0].Attributes) != 1 || links[0].Attributes[0] != attribute.String("link.reason", "batch") {
+		t.Errorf("Links = %+v, want one link to %v with link.reason=batch", links, linked)
+	}
+
+	withAttributes := got[1]
+	if withAttributes.SpanKind != trace.SpanKindInternal {
+		t.Errorf("StartSpanWithAttributes() SpanKind = %v, want internal", withAttributes.SpanKind)
+	}
+	if attrs := withAttributes.Attributes; len(attrs) != 1 || attrs[0] != attribute.Int("items", 3) {
+		t.Errorf("StartSpanWithAttributes() attributes = %v, want items=3", attrs)
+	}
+}
+
+// enrichingSpanProcessor enriches the spans started and records the names of
+// the spans ended.
+type enrichingSpanProcessor struct {
+	ended []string
+}
+
+func (p *enrichingSpanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	s.SetAttributes(attribute.Bool("enriched", true))
+}
+
+func (p *enrichingSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	p.ended = append(p.ended, s.Name())
+}
+
+func (p *enrichingSpanProcessor) Shutdown(context.Context) error   { return nil }
+func (p *enrichingSpanProcessor) ForceFlush(context.Context) error { return nil }
+
+// enrichingLogProcessor enriches the records emitted and records their bodies.
+type enrichingLogProcessor struct {
+	emitted []string
+}
+
+func (p *enrichingLogProcessor) Enabled(context.Context, sdklog.EnabledParameters) bool {
+	return true
+}
+
+func (p *enrichingLogProcessor) OnEmit(_ context.Context, record *sdklog.Record) error {
+	p.emitted = append(p.emitted, record.Body().AsString())
+	record.AddAttributes(otellog.Bool("enriched", true))
+	return nil
+}
+
+func (p *enrichingLogProcessor) Shutdown(context.Context) error   { return nil }
+func (p *enrichingLogProcessor) ForceFlush(context.Context) error { return nil }
+
+func TestTelemetry_UserProcessors(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	var calls int
+	spanProcessor := &enrichingSpanProcessor{}
+	logProcessor := &enrichingLogProcessor{}
+	spans := tracetest.NewInMemoryExporter()
+	logs := &telemetrytest.LogRecorder{}
+	opts := &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+		SpanProcessors: func() []sdktrace.SpanProcessor {
+			calls++
+			return []sdktrace.SpanProcessor{spanProcessor}
+		},
+		LogProcessors: func() []sdklog.Processor {
+			return []sdklog.Processor{logProcessor}
+		},
+	}
+
+	tel, err := New(ctx, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	_, span := tel.StartSpan(ctx, "enriched")
+	span.End()
+	if len(spanProcessor.ended) != 1 || spanProcessor.ended[0] != "enriched" {
+		t.Errorf("span processor saw %v, want [enriched]", spanProcessor.ended)
+	}
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	if attrs := got[0].Attributes; len(attrs) != 1 || attrs[0] != attribute.Bool("enriched", true) {
+		t.Errorf("exported span attributes = %v, want enriched=true from the user processor", attrs)
+	}
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("hello"))
+	record.SetSeverity(otellog.SeverityInfo)
+	tel.Logger().Emit(ctx, record)
+	if len(logProcessor.emitted) != 1 || logProcessor.emitted[0] != "hello" {
+		t.Errorf("log processor saw %v, want [hello]", logProcessor.emitted)
+	}
+	logs.AssertLogged(t, telemetrytest.WithMessage("hello"), telemetrytest.WithAttr("enriched", otellog.BoolValue(true)))
+
+	if err := tel.Reconfigure(ctx, opts); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("SpanProcessors called %d times, want once per provider build", calls)
+	}
+}
+
+// recordingLogExporter records the bodies of exported log records.
+type recordingLogExporter struct {
+	bodies []string
+}
+
+func (e *recordingLogExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, record := range records {
+		e.bodies = append(e.bodies, record.Body().AsString())
+	}
+	return nil
+}
+
+func (e *recordingLogExporter) ForceFlush(context.Context) error { return nil }
+func (e *recordingLogExporter) Shutdown(context.Context) error   { return nil }
+
+func TestTelemetry_CustomExporters(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	spans := tracetest.NewInMemoryExporter()
+	logs := &recordingLogExporter{}
+	reader := sdkmetric.NewManualReader()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+		CustomLogExporter:  logs,
+		CustomMetricReader: reader,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if !tel.TracesEnabled() || !tel.MetricsEnabled() || !tel.LogsEnabled() {
+		t.Fatalf("signals enabled = (%v, %v, %v), want all enabled by the custom exporters",
+			tel.TracesEnabled(), tel.MetricsEnabled(), tel.LogsEnabled())
+	}
+
+	want := []ExporterEndpoint{
+		{Signal: "logs", Exporter: "custom"},
+		{Signal: "traces", Exporter: "custom"},
+		{Signal: "metrics", Exporter: "custom"},
+	}
+	if got := tel.ExporterEndpoints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExporterEndpoints() = %+v, want %+v", got, want)
+	}
+
+	_, span := tel.StartSpan(ctx, "custom-span")
+	span.End()
+	if got := spans.GetSpans(); len(got) != 1 || got[0].Name != "custom-span" {
+		t.Errorf("custom span exporter got %v, want custom-span", got)
+	}
+
+	var record otellog.Record
+	record.SetBody(otellog.StringValue("custom-log"))
+	tel.Logger().Emit(ctx, record)
+	if len(logs.bodies) != 1 || logs.bodies[0] != "custom-log" {
+		t.Errorf("custom log exporter got %v, want custom-log", logs.bodies)
+	}
+
+	counter, err := tel.MeterProvider().Meter("test").Int64Counter("requests")
+	if err != nil {
+		t.Fatalf("Int64Counter() error = %v", err)
+	}
+	counter.Add(ctx, 1)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(rm.ScopeMetrics) == 0 {
+		t.Error("custom metric reader collected no metrics")
+	}
+}
+
+func TestTelemetry_SpanFromContext(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: tracetest.NewInMemoryExporter(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if tel.IsRecording(ctx) {
+		t.Error("IsRecording() = true without a span, want false")
+	}
+	if tel.SpanFromContext(ctx).SpanContext().IsValid() {
+		t.Error("SpanFromContext() returned a valid span without a span in ctx")
+	}
+
+	spanCtx, span := tel.StartSpan(ctx, "current")
+	defer span.End()
+
+	if got := tel.SpanFromContext(spanCtx); got != span {
+		t.Errorf("SpanFromContext() = %v, want the started span", got)
+	}
+	if !tel.IsRecording(spanCtx) {
+		t.Error("IsRecording() = false, want true for a sampled span")
+	}
+}
+
+func TestTelemetry_BuildInfo(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+		EnableBuildInfo: true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if v, _ := rm.Resource.Set().Value("process.runtime.version"); v.AsString() != runtime.Version() {
+		t.Errorf("process.runtime.version = %q, want %q", v.AsString(), runtime.Version())
+	}
+
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "build_info" {
+				continue
+			}
+			gauge, ok := m.Data.(metricdata.Gauge[int64])
+			if !ok || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 1 {
+				t.Fatalf("build_info = %+v, want one data point with value 1", m.Data)
+			}
+			if v, _ := gauge.DataPoints[0].Attributes.Value("process.runtime.name"); v.AsString() != "go" {
+				t.Errorf("build_info process.runtime.name = %q, want go", v.AsString())
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Error("build_info metric was not collected")
+	}
+
+	disabled, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer disabled.Shutdown(ctx)
+
+	rm, err = disabled.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	if _, ok := rm.Resource.Set().Value("process.runtime.version"); ok {
+		t.Error("process.runtime.version is set, want it omitted by default")
+	}
+	if len(rm.ScopeMetrics) != 0 {
+		t.Errorf("CollectMetrics() = %+v, want no metrics by default", rm.ScopeMetrics)
+	}
+}
+
+func TestTelemetry_UptimeMetrics(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:     "test-service",
+		MetricsExporter: "manual",
+		UptimeMetrics:   true,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	rm, err := tel.CollectMetrics(ctx)
+	if err != nil {
+		t.Fatalf("CollectMetrics() error = %v", err)
+	}
+	metrics := map[string]metricdata.Metrics{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+
+	start, ok := metrics["process.start_time"].Data.(metricdata.Gauge[float64])
+	if !ok || len(start.DataPoints) != 1 {
+		t.Fatalf("process.start_time = %+v, want one gauge data point", metrics["process.start_time"].Data)
+	}
+	if got := start.DataPoints[0].Value; got <= 0 || got > float64(time.Now().UnixNano())/1e9 {
+		t.Errorf("process.start_time = %v, want a Unix time in the past", got)
+	}
+
+	uptime, ok := metrics["process.uptime"].Data.(metricdata.Sum[float64])
+	if !ok || len(uptime.DataPoints) != 1 || !uptime.IsMonotonic {
+		t.Fatalf("process.uptime = %+v, want one monotonic sum data point", metrics["process.uptime"].Data)
+	}
+	if uptime.DataPoints[0].Value <= 0 {
+		t.Errorf("process.uptime = %v, want > 0", uptime.DataPoints[0].Value)
+	}
+}
+
+func TestTelemetry_PrometheusExemplars(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:         "test-service",
+		MetricsExporter:     "prometheus",
+		PrometheusExemplars: true,
+		CustomSpanExporter:  tracetest.NewInMemoryExporter(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	histogram, err := tel.MeterProvider().Meter("test").Float64Histogram("latency", metric.WithUnit("s"))
+	if err != nil {
+		t.Fatalf("Float64Histogram() error = %v", err)
+	}
+
+	spanCtx, span := tel.StartSpan(ctx, "request")
+	histogram.Record(spanCtx, 0.25)
+	span.End()
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text;version=1.0.0")
+	rec := httptest.NewRecorder()
+	tel.PrometheusHandler().ServeHTTP(rec, req)
+
+	traceID := span.SpanContext().TraceID().String()
+	if body := rec.Body.String(); !strings.Contains(body, `trace_id="`+traceID+`"`) {
+		t.Errorf("scrape did not include an exemplar for trace %s:\n%s", traceID, body)
+	}
+}
+
+func TestNew_DoesNotModifyOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	os.Setenv("OTEL_SERVICE_NAME", "env-service")
+
+	ctx := context.Background()
+	opts := &Options{ServiceName: "test-service", LogsExporter: "none"}
+	want := *opts
+
+	tel, err := New(ctx, opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	if err := tel.Reconfigure(ctx, opts); err != nil {
+		t.Fatalf("Reconfigure() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(*opts, want) {
+		t.Errorf("Options after New and Reconfigure = %+v, want %+v", *opts, want)
+	}
+	if tel.ServiceName() != "env-service" {
+		t.Errorf("ServiceName() = %v, want 'env-service'", tel.ServiceName())
+	}
+}
@@ -0,0 +1,251 @@
+package telemetrytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden write
+// the golden files instead of comparing against them, e.g.
+// UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// redacted replaces the values of the attributes passed to RedactAttributes.
+const redacted = "<redacted>"
+
+// GoldenOption configures AssertGolden.
+type GoldenOption func(*goldenConfig)
+
+type goldenConfig struct {
+	redact map[string]bool
+}
+
+// RedactAttributes replaces the values of the span, event, and log attributes
+// with the given keys by "<redacted>", for values that change between runs
+// such as durations, ports, or generated IDs.
+func RedactAttributes(keys ...string) GoldenOption {
+	return func(c *goldenConfig) {
+		for _, key := range keys {
+			c.redact[key] = true
+		}
+	}
+}
+
+// Snapshot is the stable JSON form of recorded spans and logs compared by
+// AssertGolden. Timestamps are left out, and trace and span IDs are replaced
+// by "trace-N" and "span-N" in order of first appearance, so the same
+// instrumentation produces the same snapshot on every run and logs still
+// point at the spans they were emitted in.
+type Snapshot struct {
+	Spans []SpanSnapshot `json:"spans,omitempty"`
+	Logs  []LogSnapshot  `json:"logs,omitempty"`
+}
+
+// SpanSnapshot is the stable form of a span.
+type SpanSnapshot struct {
+	Name              string          `json:"name"`
+	Kind              string          `json:"kind"`
+	Scope             string          `json:"scope"`
+	TraceID           string          `json:"trace_id"`
+	SpanID            string          `json:"span_id"`
+	ParentSpanID      string          `json:"parent_span_id,omitempty"`
+	StatusCode        string          `json:"status_code"`
+	StatusDescription string          `json:"status_description,omitempty"`
+	Attributes        map[string]any  `json:"attributes,omitempty"`
+	Events            []EventSnapshot `json:"events,omitempty"`
+	Links             []string        `json:"links,omitempty"`
+}
+
+// EventSnapshot is the stable form of a span event.
+type EventSnapshot struct {
+	Name       string         `json:"name"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// LogSnapshot is the stable form of a log record.
+type LogSnapshot struct {
+	Scope        string         `json:"scope"`
+	Severity     int            `json:"severity"`
+	SeverityText string         `json:"severity_text,omitempty"`
+	Body         any            `json:"body"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+	TraceID      string         `json:"trace_id,omitempty"`
+	SpanID       string         `json:"span_id,omitempty"`
+}
+
+// NewSnapshot returns the snapshot of spans, as returned by
+// tracetest.InMemoryExporter.GetSpans, and logs, as returned by
+// LogRecorder.Records, in the order given.
+func NewSnapshot(spans tracetest.SpanStubs, logs []LogRecord, opts ...GoldenOption) Snapshot {
+	cfg := &goldenConfig{redact: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ids := &idRedactor{traces: make(map[trace.TraceID]string), spans: make(map[trace.SpanID]string)}
+
+	var snapshot Snapshot
+	for _, span := range spans {
+		s := SpanSnapshot{
+			Name:              span.Name,
+			Kind:              span.SpanKind.String(),
+			Scope:             span.InstrumentationScope.Name,
+			TraceID:           ids.trace(span.SpanContext.TraceID()),
+			SpanID:            ids.span(span.SpanContext.SpanID()),
+			StatusCode:        span.Status.Code.String(),
+			StatusDescription: span.Status.Description,
+			Attributes:        cfg.attributes(span.Attributes),
+		}
+		if span.Parent.HasSpanID() {
+			s.ParentSpanID = ids.span(span.Parent.SpanID())
+		}
+		for _, event := range span.Events {
+			s.Events = append(s.Events, EventSnapshot{Name: event.Name, Attributes: cfg.attributes(event.Attributes)})
+		}
+		for _, link := range span.Links {
+			s.Links = append(s.Links, ids.span(link.SpanContext.SpanID()))
+		}
+		snapshot.Spans = append(snapshot.Spans, s)
+	}
+
+	for _, record := range logs {
+		l := LogSnapshot{
+			Scope:        record.Scope,
+			Severity:     int(record.Severity),
+			SeverityText: record.SeverityText,
+			Body:         logValue(record.Body),
+		}
+		if len(record.Attributes) > 0 {
+			l.Attributes = make(map[string]any, len(record.Attributes))
+			for key, value := range record.Attributes {
+				l.Attributes[key] = cfg.value(key, logValue(value))
+			}
+		}
+		if record.TraceID.IsValid() {
+			l.TraceID = ids.trace(record.TraceID)
+		}
+		if record.SpanID.IsValid() {
+			l.SpanID = ids.span(record.SpanID)
+		}
+		snapshot.Logs = append(snapshot.Logs, l)
+	}
+	return snapshot
+}
+
+// AssertGolden compares the snapshot of spans and logs (see NewSnapshot) with
+// the golden file at path, typically under testdata, and fails the test with
+// both versions if they differ. With UPDATE_GOLDEN set, it writes the golden
+// file instead, so instrumentation changes show up as a reviewable diff.
+func AssertGolden(t testing.TB, path string, spans tracetest.SpanStubs, logs []LogRecord, opts ...GoldenOption) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(NewSnapshot(spans, logs, opts...)); err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	got := buf.Bytes()
+
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with %s=1 to create it): %v", UpdateGoldenEnv, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("snapshot differs from %s (run with %s=1 to update it)\ngot:\n%s\nwant:\n%s", path, UpdateGoldenEnv, got, want)
+	}
+}
+
+// attributes returns attrs as a map, with the configured keys redacted.
+func (c *goldenConfig) attributes(attrs []attribute.KeyValue) map[string]any {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(attrs))
+	for _, kv := range attrs {
+		m[string(kv.Key)] = c.value(string(kv.Key), kv.Value.AsInterface())
+	}
+	return m
+}
+
+// value returns v, or the redacted placeholder if key is redacted.
+func (c *goldenConfig) value(key string, v any) any {
+	if c.redact[key] {
+		return redacted
+	}
+	return v
+}
+
+// logValue converts a log value to its JSON form.
+func logValue(v otellog.Value) any {
+	switch v.Kind() {
+	case otellog.KindBool:
+		return v.AsBool()
+	case otellog.KindInt64:
+		return v.AsInt64()
+	case otellog.KindFloat64:
+		return v.AsFloat64()
+	case otellog.KindString:
+		return v.AsString()
+	case otellog.KindBytes:
+		return v.AsBytes()
+	case otellog.KindSlice:
+		values := make([]any, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			values = append(values, logValue(item))
+		}
+		return values
+	case otellog.KindMap:
+		m := make(map[string]any, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			m[kv.Key] = logValue(kv.Value)
+		}
+		return m
+	default:
+		return nil
+	}
+}
+
+// idRedactor replaces trace and span IDs by placeholders numbered in order of
+// first appearance.
+type idRedactor struct {
+	traces map[trace.TraceID]string
+	spans  map[trace.SpanID]string
+}
+
+func (r *idRedactor) trace(id trace.TraceID) string {
+	if p, ok := r.traces[id]; ok {
+		return p
+	}
+	p := fmt.Sprintf("trace-%d", len(r.traces)+1)
+	r.traces[id] = p
+	return p
+}
+
+func (r *idRedactor) span(id trace.SpanID) string {
+	if p, ok := r.spans[id]; ok {
+		return p
+	}
+	p := fmt.Sprintf("span-%d", len(r.spans)+1)
+	r.spans[id] = p
+	return p
+}
@@ -0,0 +1,93 @@
+package telemetrytest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// goldenFixture returns a parent and child span and a log record emitted in
+// the child, with IDs that differ from run to run in real exports.
+func goldenFixture(traceID trace.TraceID, rootID, childID trace.SpanID) (tracetest.SpanStubs, []LogRecord) {
+	spanContext := func(id trace.SpanID) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: id})
+	}
+	scope := instrumentation.Scope{Name: "test"}
+
+	spans := tracetest.SpanStubs{
+		{
+			Name:                 "child",
+			SpanKind:             trace.SpanKindClient,
+			SpanContext:          spanContext(childID),
+			Parent:               spanContext(rootID),
+			InstrumentationScope: scope,
+			Attributes: []attribute.KeyValue{
+				attribute.String("db.system", "postgresql"),
+				attribute.Int("duration_ms", 12),
+			},
+		},
+		{
+			Name:                 "root",
+			SpanKind:             trace.SpanKindServer,
+			SpanContext:          spanContext(rootID),
+			InstrumentationScope: scope,
+			Status:               sdktrace.Status{Code: codes.Error, Description: "boom"},
+			Events: []sdktrace.Event{
+				{Name: "exception", Attributes: []attribute.KeyValue{attribute.String("exception.message", "boom")}},
+			},
+		},
+	}
+	logs := []LogRecord{
+		{
+			Scope:        "test",
+			Severity:     otellog.SeverityError,
+			SeverityText: "ERROR",
+			Body:         otellog.StringValue("query failed"),
+			Attributes:   map[string]otellog.Value{"attempt": otellog.Int64Value(2)},
+			TraceID:      traceID,
+			SpanID:       childID,
+		},
+	}
+	return spans, logs
+}
+
+func TestAssertGolden(t *testing.T) {
+	golden := filepath.Join("testdata", "snapshot.golden.json")
+
+	// Different IDs produce the same snapshot
+	spans, logs := goldenFixture(trace.TraceID{1}, trace.SpanID{1}, trace.SpanID{2})
+	AssertGolden(t, golden, spans, logs, RedactAttributes("duration_ms"))
+
+	spans, logs = goldenFixture(trace.TraceID{9}, trace.SpanID{8}, trace.SpanID{7})
+	AssertGolden(t, golden, spans, logs, RedactAttributes("duration_ms"))
+}
+
+func TestAssertGolden_Mismatch(t *testing.T) {
+	golden := filepath.Join("testdata", "snapshot.golden.json")
+	spans, logs := goldenFixture(trace.TraceID{1}, trace.SpanID{1}, trace.SpanID{2})
+
+	// Without redaction, duration_ms is in the snapshot
+	ft := &fakeT{}
+	AssertGolden(ft, golden, spans, logs)
+	if len(ft.errors) != 1 {
+		t.Errorf("AssertGolden() reported %d errors for a changed snapshot, want 1", len(ft.errors))
+	}
+}
+
+func TestAssertGolden_Update(t *testing.T) {
+	t.Setenv(UpdateGoldenEnv, "1")
+	golden := filepath.Join(t.TempDir(), "testdata", "new.golden.json")
+	spans, logs := goldenFixture(trace.TraceID{1}, trace.SpanID{1}, trace.SpanID{2})
+
+	AssertGolden(t, golden, spans, logs)
+
+	t.Setenv(UpdateGoldenEnv, "")
+	AssertGolden(t, golden, spans, logs)
+}
@@ -0,0 +1,242 @@
+// Package telemetrytest provides helpers for verifying telemetry in tests,
+// such as an in-memory log exporter that records what the OTel logger hooks emit.
+package telemetrytest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogRecord is a snapshot of an emitted OTel log record.
+type LogRecord struct {
+	// Scope is the instrumentation scope name of the logger that emitted the record.
+	Scope string
+	// ScopeVersion is the instrumentation scope version of that logger.
+	ScopeVersion string
+	// Timestamp is the time the event occurred.
+	Timestamp time.Time
+	// Severity is the OTel severity number.
+	Severity otellog.Severity
+	// SeverityText is the severity as reported by the logger (e.g., "INFO").
+	SeverityText string
+	// Body is the log message.
+	Body otellog.Value
+	// Attributes holds the record attributes by key.
+	Attributes map[string]otellog.Value
+	// TraceID and SpanID identify the span the record was emitted in, if any.
+	TraceID trace.TraceID
+	SpanID  trace.SpanID
+}
+
+// newLogRecord takes a snapshot of an exported record.
+func newLogRecord(record sdklog.Record) LogRecord {
+	attrs := make(map[string]otellog.Value, record.AttributesLen())
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+
+	return LogRecord{
+		Scope:        record.InstrumentationScope().Name,
+		ScopeVersion: record.InstrumentationScope().Version,
+		Timestamp:    record.Timestamp(),
+		Severity:     record.Severity(),
+		SeverityText: record.SeverityText(),
+		Body:         record.Body(),
+		Attributes:   attrs,
+		TraceID:      record.TraceID(),
+		SpanID:       record.SpanID(),
+	}
+}
+
+// Message returns the body as a string.
+func (r LogRecord) Message() string {
+	return r.Body.AsString()
+}
+
+// Attr returns the attribute with the given key and whether it was set.
+func (r LogRecord) Attr(key string) (otellog.Value, bool) {
+	v, ok := r.Attributes[key]
+	return v, ok
+}
+
+// String returns a compact description of the record, with attributes sorted by key.
+func (r LogRecord) String() string {
+	var b strings.Builder
+	b.WriteString(r.SeverityText)
+	b.WriteString(" ")
+	b.WriteString(r.Body.String())
+	for _, k := range slices.Sorted(maps.Keys(r.Attributes)) {
+		b.WriteString(" ")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(r.Attributes[k].String())
+	}
+	return b.String()
+}
+
+// LogRecorder is an sdklog.Exporter that keeps every exported record in memory.
+// It is safe for concurrent use.
+type LogRecorder struct {
+	mu      sync.Mutex
+	records []LogRecord
+}
+
+var _ sdklog.Exporter = (*LogRecorder)(nil)
+
+// NewLoggerProvider returns a logger provider that exports synchronously to a
+// new LogRecorder, so records are available as soon as the logger call returns.
+// Pass the provider to a hook (e.g., logrushook.New) and assert on the recorder.
+func NewLoggerProvider() (*sdklog.LoggerProvider, *LogRecorder) {
+	recorder := &LogRecorder{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(recorder)))
+	return lp, recorder
+}
+
+// Export records the given records.
+func (r *LogRecorder) Export(ctx context.Context, records []sdklog.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		r.records = append(r.records, newLogRecord(record))
+	}
+	return nil
+}
+
+// Shutdown is a no-op; recorded records remain available.
+func (r *LogRecorder) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// ForceFlush is a no-op; records are recorded as they are exported.
+func (r *LogRecorder) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// Records returns a copy of all recorded records in the order they were exported.
+func (r *LogRecorder) Records() []LogRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]LogRecord(nil), r.records...)
+}
+
+// Find returns the recorded records that satisfy all matchers.
+func (r *LogRecorder) Find(matchers ...LogMatcher) []LogRecord {
+	var found []LogRecord
+	for _, record := range r.Records() {
+		if matchAll(record, matchers) {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+// Reset discards all recorded records.
+func (r *LogRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// AssertLogged fails the test unless at least one recorded record satisfies all matchers.
+func (r *LogRecorder) AssertLogged(t testing.TB, matchers ...LogMatcher) {
+	t.Helper()
+	if len(r.Find(matchers...)) == 0 {
+		t.Errorf("no log record matched; recorded:\n%s", r.describe())
+	}
+}
+
+// AssertNotLogged fails the test if any recorded record satisfies all matchers.
+func (r *LogRecorder) AssertNotLogged(t testing.TB, matchers ...LogMatcher) {
+	t.Helper()
+	if found := r.Find(matchers...); len(found) > 0 {
+		t.Errorf("unexpected log record matched: %s", found[0])
+	}
+}
+
+// describe lists the recorded records, one per line.
+func (r *LogRecorder) describe() string {
+	records := r.Records()
+	if len(records) == 0 {
+		return "  (none)"
+	}
+	lines := make([]string, 0, len(records))
+	for _, record := range records {
+		lines = append(lines, "  "+record.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// LogMatcher reports whether a record matches a condition.
+type LogMatcher func(LogRecord) bool
+
+// WithSeverity matches records with the given severity.
+func WithSeverity(severity otellog.Severity) LogMatcher {
+	return func(r LogRecord) bool {
+		return r.Severity == severity
+	}
+}
+
+// WithMessage matches records whose body is the given string.
+func WithMessage(msg string) LogMatcher {
+	return func(r LogRecord) bool {
+		return r.Body.Kind() == otellog.KindString && r.Body.AsString() == msg
+	}
+}
+
+// WithMessageContaining matches records whose body contains substr.
+func WithMessageContaining(substr string) LogMatcher {
+	return func(r LogRecord) bool {
+		return strings.Contains(r.Body.AsString(), substr)
+	}
+}
+
+// WithAttr matches records that have the attribute key set to value.
+func WithAttr(key string, value otellog.Value) LogMatcher {
+	return func(r LogRecord) bool {
+		v, ok := r.Attributes[key]
+		return ok && v.Equal(value)
+	}
+}
+
+// WithAttrKey matches records that have the attribute key set to any value.
+func WithAttrKey(key string) LogMatcher {
+	return func(r LogRecord) bool {
+		_, ok := r.Attributes[key]
+		return ok
+	}
+}
+
+// WithTraceID matches records emitted within a span of the given trace.
+func WithTraceID(traceID trace.TraceID) LogMatcher {
+	return func(r LogRecord) bool {
+		return r.TraceID == traceID
+	}
+}
+
+// WithScope matches records emitted by a logger with the given instrumentation scope name.
+func WithScope(name string) LogMatcher {
+	return func(r LogRecord) bool {
+		return r.Scope == name
+	}
+}
+
+// matchAll reports whether the record satisfies every matcher.
+func matchAll(record LogRecord, matchers []LogMatcher) bool {
+	for _, m := range matchers {
+		if !m(record) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package telemetrytest
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestLogRecorder(t *testing.T) {
+	ctx := context.Background()
+
+	lp, recorder := NewLoggerProvider()
+	defer lp.Shutdown(ctx)
+
+	var record otellog.Record
+	record.SetSeverity(otellog.SeverityWarn)
+	record.SetSeverityText("WARN")
+	record.SetBody(otellog.StringValue("disk almost full"))
+	record.AddAttributes(otellog.Int64("percent", 93))
+	lp.Logger("storage").Emit(ctx, record)
+
+	records := recorder.Records()
+	if len(records) != 1 {
+		t.Fatalf("Records() returned %d records, want 1", len(records))
+	}
+	if records[0].Message() != "disk almost full" {
+		t.Errorf("Message() = %q, want %q", records[0].Message(), "disk almost full")
+	}
+
+	recorder.AssertLogged(t,
+		WithScope("storage"),
+		WithSeverity(otellog.SeverityWarn),
+		WithMessageContaining("full"),
+		WithAttr("percent", otellog.Int64Value(93)),
+	)
+	recorder.AssertNotLogged(t, WithAttrKey("error"))
+
+	if found := recorder.Find(WithAttr("percent", otellog.Int64Value(50))); len(found) != 0 {
+		t.Errorf("Find() returned %d records for a non-matching value, want 0", len(found))
+	}
+
+	recorder.Reset()
+	if len(recorder.Records()) != 0 {
+		t.Error("Records() not empty after Reset()")
+	}
+}
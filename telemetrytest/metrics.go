@@ -0,0 +1,95 @@
+package telemetrytest
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// FindMetric returns the first metric with the given instrument name in rm,
+// as returned by Telemetry.CollectMetrics.
+func FindMetric(rm metricdata.ResourceMetrics, name string) (metricdata.Metrics, bool) {
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m, true
+			}
+		}
+	}
+	return metricdata.Metrics{}, false
+}
+
+// SumValue returns the total of all data points of a counter or up-down counter.
+// The second result is false if the metric is not found or is not a sum.
+func SumValue(rm metricdata.ResourceMetrics, name string) (float64, bool) {
+	m, ok := FindMetric(rm, name)
+	if !ok {
+		return 0, false
+	}
+
+	var total float64
+	switch data := m.Data.(type) {
+	case metricdata.Sum[int64]:
+		for _, dp := range data.DataPoints {
+			total += float64(dp.Value)
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range data.DataPoints {
+			total += dp.Value
+		}
+	default:
+		return 0, false
+	}
+	return total, true
+}
+
+// HistogramCount returns the number of recorded measurements across all data
+// points of a histogram. The second result is false if the metric is not found
+// or is not a histogram.
+func HistogramCount(rm metricdata.ResourceMetrics, name string) (uint64, bool) {
+	m, ok := FindMetric(rm, name)
+	if !ok {
+		return 0, false
+	}
+
+	var count uint64
+	switch data := m.Data.(type) {
+	case metricdata.Histogram[int64]:
+		for _, dp := range data.DataPoints {
+			count += dp.Count
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range data.DataPoints {
+			count += dp.Count
+		}
+	default:
+		return 0, false
+	}
+	return count, true
+}
+
+// AssertSum fails the test unless the named counter or up-down counter sums to want.
+func AssertSum(t testing.TB, rm metricdata.ResourceMetrics, name string, want float64) {
+	t.Helper()
+	got, ok := SumValue(rm, name)
+	if !ok {
+		t.Errorf("sum metric %q not found", name)
+		return
+	}
+	if got != want {
+		t.Errorf("sum of %q = %v, want %v", name, got, want)
+	}
+}
+
+// AssertHistogramCount fails the test unless the named histogram recorded want measurements.
+func AssertHistogramCount(t testing.TB, rm metricdata.ResourceMetrics, name string, want uint64) {
+	t.Helper()
+	got, ok := HistogramCount(rm, name)
+	if !ok {
+		t.Errorf("histogram metric %q not found", name)
+		return
+	}
+	if got != want {
+		t.Errorf("count of %q = %v, want %v", name, got, want)
+	}
+}
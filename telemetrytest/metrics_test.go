@@ -0,0 +1,43 @@
+package telemetrytest
+
+import (
+	"context"
+	"testing"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetricHelpers(t *testing.T) {
+	ctx := context.Background()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer mp.Shutdown(ctx)
+
+	meter := mp.Meter("test")
+	counter, _ := meter.Int64Counter("requests")
+	counter.Add(ctx, 2)
+	counter.Add(ctx, 3)
+	histogram, _ := meter.Float64Histogram("latency")
+	histogram.Record(ctx, 0.5)
+	histogram.Record(ctx, 1.5)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	if _, ok := FindMetric(rm, "requests"); !ok {
+		t.Error("FindMetric() did not find 'requests'")
+	}
+	if _, ok := FindMetric(rm, "missing"); ok {
+		t.Error("FindMetric() found 'missing', want not found")
+	}
+	if _, ok := SumValue(rm, "latency"); ok {
+		t.Error("SumValue() accepted a histogram, want false")
+	}
+
+	AssertSum(t, rm, "requests", 5)
+	AssertHistogramCount(t, rm, "latency", 2)
+}
@@ -0,0 +1,158 @@
+package telemetrytest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// OTLPReceiver is an in-process OTLP gRPC receiver for logs, traces, and
+// metrics. Point the real exporters at it with SetEnv to test the full export
+// path end to end.
+type OTLPReceiver struct {
+	listener net.Listener
+	server   *grpc.Server
+
+	mu      sync.Mutex
+	logs    []*logspb.ResourceLogs
+	spans   []*tracepb.ResourceSpans
+	metrics []*metricspb.ResourceMetrics
+}
+
+// NewOTLPReceiver starts a receiver on a random local port.
+// The receiver is stopped when the test finishes.
+func NewOTLPReceiver(t testing.TB) *OTLPReceiver {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for OTLP receiver: %v", err)
+	}
+
+	r := &OTLPReceiver{
+		listener: listener,
+		server:   grpc.NewServer(),
+	}
+	collogspb.RegisterLogsServiceServer(r.server, &logsService{r: r})
+	coltracepb.RegisterTraceServiceServer(r.server, &traceService{r: r})
+	colmetricspb.RegisterMetricsServiceServer(r.server, &metricsService{r: r})
+
+	go func() {
+		_ = r.server.Serve(listener)
+	}()
+	t.Cleanup(r.server.Stop)
+
+	return r
+}
+
+// Endpoint returns the receiver address as an http URL, suitable for
+// OTEL_EXPORTER_OTLP_ENDPOINT (the http scheme disables TLS).
+func (r *OTLPReceiver) Endpoint() string {
+	return "http://" + r.listener.Addr().String()
+}
+
+// SetEnv points the OTLP exporters at the receiver for the duration of the test.
+func (r *OTLPReceiver) SetEnv(t testing.TB) {
+	t.Helper()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", r.Endpoint())
+	t.Setenv("OTEL_EXPORTER_OTLP_INSECURE", "true")
+}
+
+// ResourceLogs returns the received logs, in the order they arrived.
+func (r *OTLPReceiver) ResourceLogs() []*logspb.ResourceLogs {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*logspb.ResourceLogs(nil), r.logs...)
+}
+
+// ResourceSpans returns the received spans, in the order they arrived.
+func (r *OTLPReceiver) ResourceSpans() []*tracepb.ResourceSpans {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*tracepb.ResourceSpans(nil), r.spans...)
+}
+
+// ResourceMetrics returns the received metrics, in the order they arrived.
+func (r *OTLPReceiver) ResourceMetrics() []*metricspb.ResourceMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*metricspb.ResourceMetrics(nil), r.metrics...)
+}
+
+// LogRecords returns every received log record across all resources and scopes.
+func (r *OTLPReceiver) LogRecords() []*logspb.LogRecord {
+	var records []*logspb.LogRecord
+	for _, rl := range r.ResourceLogs() {
+		for _, sl := range rl.GetScopeLogs() {
+			records = append(records, sl.GetLogRecords()...)
+		}
+	}
+	return records
+}
+
+// Spans returns every received span across all resources and scopes.
+func (r *OTLPReceiver) Spans() []*tracepb.Span {
+	var spans []*tracepb.Span
+	for _, rs := range r.ResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			spans = append(spans, ss.GetSpans()...)
+		}
+	}
+	return spans
+}
+
+// Metrics returns every received metric across all resources and scopes.
+func (r *OTLPReceiver) Metrics() []*metricspb.Metric {
+	var metrics []*metricspb.Metric
+	for _, rm := range r.ResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			metrics = append(metrics, sm.GetMetrics()...)
+		}
+	}
+	return metrics
+}
+
+type logsService struct {
+	collogspb.UnimplementedLogsServiceServer
+	r *OTLPReceiver
+}
+
+func (s *logsService) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.r.logs = append(s.r.logs, req.GetResourceLogs()...)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+type traceService struct {
+	coltracepb.UnimplementedTraceServiceServer
+	r *OTLPReceiver
+}
+
+func (s *traceService) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.r.spans = append(s.r.spans, req.GetResourceSpans()...)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+type metricsService struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	r *OTLPReceiver
+}
+
+func (s *metricsService) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	s.r.mu.Lock()
+	defer s.r.mu.Unlock()
+	s.r.metrics = append(s.r.metrics, req.GetResourceMetrics()...)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
@@ -0,0 +1,58 @@
+package telemetrytest
+
+import (
+	"context"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// TLogExporter is an sdklog.Exporter that writes each record through t.Logf,
+// so logs emitted by code under test appear in the output of the test that
+// produced them.
+type TLogExporter struct {
+	t testing.TB
+	// failOnError reports records at error severity or above with t.Errorf
+	failOnError bool
+}
+
+var _ sdklog.Exporter = (*TLogExporter)(nil)
+
+// NewTLoggerProvider returns a logger provider that writes records through t.Logf.
+// Pass it to a hook (e.g., logrushook.New) to get readable, test-scoped output.
+// If failOnError is true, records at error severity or above fail the test.
+// The provider is shut down when the test finishes.
+func NewTLoggerProvider(t testing.TB, failOnError bool) *sdklog.LoggerProvider {
+	exporter := &TLogExporter{t: t, failOnError: failOnError}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	t.Cleanup(func() {
+		_ = lp.Shutdown(context.Background())
+	})
+	return lp
+}
+
+// Export writes the records through t.Logf, or t.Errorf for error records
+// when failOnError is set.
+func (e *TLogExporter) Export(ctx context.Context, records []sdklog.Record) error {
+	e.t.Helper()
+	for _, record := range records {
+		line := newLogRecord(record).String()
+		if e.failOnError && record.Severity() >= otellog.SeverityError {
+			e.t.Errorf("%s", line)
+			continue
+		}
+		e.t.Logf("%s", line)
+	}
+	return nil
+}
+
+// Shutdown is a no-op.
+func (e *TLogExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// ForceFlush is a no-op; records are written as they are exported.
+func (e *TLogExporter) ForceFlush(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package telemetrytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// fakeT captures Logf and Errorf output.
+type fakeT struct {
+	testing.TB
+	logs   []string
+	errors []string
+}
+
+func (f *fakeT) Helper()        {}
+func (f *fakeT) Cleanup(func()) {}
+
+func (f *fakeT) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func emit(lp *sdklog.LoggerProvider, severity otellog.Severity, text, msg string) {
+	var record otellog.Record
+	record.SetSeverity(severity)
+	record.SetSeverityText(text)
+	record.SetBody(otellog.StringValue(msg))
+	record.AddAttributes(otellog.String("user", "alice"))
+	lp.Logger("test").Emit(context.Background(), record)
+}
+
+func TestTLoggerProvider(t *testing.T) {
+	ft := &fakeT{}
+	lp := NewTLoggerProvider(ft, true)
+
+	emit(lp, otellog.SeverityInfo, "INFO", "hello")
+	emit(lp, otellog.SeverityError, "ERROR", "boom")
+
+	if len(ft.logs) != 1 || ft.logs[0] != "INFO hello user=alice" {
+		t.Errorf("Logf output = %q, want [\"INFO hello user=alice\"]", ft.logs)
+	}
+	if len(ft.errors) != 1 || ft.errors[0] != "ERROR boom user=alice" {
+		t.Errorf("Errorf output = %q, want [\"ERROR boom user=alice\"]", ft.errors)
+	}
+}
+
+func TestTLoggerProvider_NoFailOnError(t *testing.T) {
+	ft := &fakeT{}
+	lp := NewTLoggerProvider(ft, false)
+
+	emit(lp, otellog.SeverityError, "ERROR", "boom")
+
+	if len(ft.logs) != 1 || len(ft.errors) != 0 {
+		t.Errorf("got %d logs and %d errors, want 1 and 0", len(ft.logs), len(ft.errors))
+	}
+}
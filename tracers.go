@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	traceembedded "go.opentelemetry.io/otel/trace/embedded"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerKey identifies a tracer returned by TracerFor.
+type tracerKey struct {
+	name    string
+	version string
+}
+
+// TracerFor returns a tracer for the named library or subsystem, so its spans
+// carry their own instrumentation scope instead of the service-level one.
+// Tracers are cached by name and version, and stay valid across Reconfigure.
+// Spans are noops while OTel traces are disabled.
+func (t *Telemetry) TracerFor(name, version string) trace.Tracer {
+	key := tracerKey{name: name, version: version}
+	if tracer, ok := t.tracers.Load(key); ok {
+		return tracer.(trace.Tracer)
+	}
+	tracer, _ := t.tracers.LoadOrStore(key, &scopedTracer{t: t, name: name, version: version})
+	return tracer.(trace.Tracer)
+}
+
+// scopedTracer is a tracer for one instrumentation scope that forwards to the
+// tracer provider of the current configuration, so it stays valid across
+// Reconfigure.
+type scopedTracer struct {
+	traceembedded.Tracer
+	t       *Telemetry
+	name    string
+	version string
+}
+
+func (s *scopedTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	tp := s.t.TracerProvider()
+	if tp == nil {
+		return tracenoop.NewTracerProvider().Tracer(s.name).Start(ctx, name, opts...)
+	}
+	// The provider caches tracers by scope, so this is a map lookup
+	return tp.Tracer(s.name,
+		trace.WithInstrumentationVersion(s.version),
+		trace.WithInstrumentationAttributes(s.t.scopeAttrs...),
+	).Start(ctx, name, opts...)
+}
@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry_TracerFor(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, err := New(ctx, &Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer tel.Shutdown(ctx)
+
+	tracer := tel.TracerFor("github.com/acme/cache", "1.2.0")
+	if tel.TracerFor("github.com/acme/cache", "1.2.0") != tracer {
+		t.Error("TracerFor() returned a new tracer for the same scope, want the cached one")
+	}
+
+	_, span := tracer.Start(ctx, "get")
+	span.End()
+
+	got := spans.GetSpans()
+	if len(got) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(got))
+	}
+	scope := got[0].InstrumentationScope
+	if scope.Name != "github.com/acme/cache" || scope.Version != "1.2.0" {
+		t.Errorf("InstrumentationScope = %s@%s, want github.com/acme/cache@1.2.0", scope.Name, scope.Version)
+	}
+}
@@ -0,0 +1,43 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// processStartTime approximates the process start time with the time this
+// package was initialized, which happens before main runs.
+var processStartTime = time.Now()
+
+// registerUptimeMetrics registers the process.start_time gauge and the
+// process.uptime counter on mp.
+func registerUptimeMetrics(mp metric.MeterProvider) {
+	meter := mp.Meter(selfMeterName)
+
+	_, err := meter.Float64ObservableGauge("process.start_time",
+		metric.WithDescription("Start time of the process since the Unix epoch."),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(float64(processStartTime.UnixNano()) / float64(time.Second))
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	_, err = meter.Float64ObservableCounter("process.uptime",
+		metric.WithDescription("Time since the process started."),
+		metric.WithUnit("s"),
+		metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+			o.Observe(time.Since(processStartTime).Seconds())
+			return nil
+		}),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+}
@@ -0,0 +1,129 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// supportedMetricsExporters and supportedLogsExporters are the exporter names
+// accepted by MetricsExporter and LogsExporter.
+var (
+	supportedMetricsExporters = []string{"otlp", "prometheus", "manual", "none"}
+	supportedLogsExporters    = []string{"otlp", "fluentforward", "none"}
+)
+
+// movedExporters maps exporter names that moved out of this module to the
+// module providing them, whose exporter is set as CustomLogExporter or
+// CustomMetricReader instead.
+var movedExporters = map[string]string{
+	"journald":      "github.com/ekristen/go-telemetry/exporters/journald/v2",
+	"elasticsearch": "github.com/ekristen/go-telemetry/exporters/elasticsearch/v2",
+	"loki":          "github.com/ekristen/go-telemetry/exporters/loki/v2",
+	"emf":           "github.com/ekristen/go-telemetry/exporters/emf/v2",
+}
+
+// Validate reports contradictory or out-of-range settings, so a misconfiguration
+// fails at startup instead of silently misbehaving at runtime. Every problem is
+// reported, joined with errors.Join. New and Reconfigure call Validate after
+// applying environment variable overrides.
+func (o *Options) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("invalid telemetry options: "+format, args...))
+	}
+
+	if o.Strict && (o.ServiceName == "" || o.ServiceName == "unknown") {
+		invalid("ServiceName is required in strict mode (set it or OTEL_SERVICE_NAME)")
+	}
+
+	metricsExporters := exporterNames(o.MetricsExporter)
+	for _, name := range metricsExporters {
+		if module, ok := movedExporters[name]; ok {
+			invalid("MetricsExporter %q moved to %s (set a reader for its exporter as CustomMetricReader)", name, module)
+		} else if !slices.Contains(supportedMetricsExporters, name) {
+			invalid("unknown MetricsExporter %q (supported: %s)", name, strings.Join(supportedMetricsExporters, ", "))
+		}
+	}
+	for _, name := range exporterNames(o.LogsExporter) {
+		if module, ok := movedExporters[name]; ok {
+			invalid("LogsExporter %q moved to %s (set its exporter as CustomLogExporter)", name, module)
+		} else if !slices.Contains(supportedLogsExporters, name) {
+			invalid("unknown LogsExporter %q (supported: %s)", name, strings.Join(supportedLogsExporters, ", "))
+		}
+	}
+
+	if o.PrometheusPort < 0 || o.PrometheusPort > 65535 {
+		invalid("PrometheusPort %d is out of range (0-65535)", o.PrometheusPort)
+	}
+	if o.PrometheusServer && !slices.Contains(metricsExporters, "prometheus") {
+		invalid("PrometheusServer requires the prometheus metrics exporter (set MetricsExporter to \"prometheus\")")
+	}
+	if o.PrometheusPath != "" && !strings.HasPrefix(o.PrometheusPath, "/") {
+		invalid("PrometheusPath %q must start with \"/\"", o.PrometheusPath)
+	}
+	if o.PrometheusTimeout < 0 {
+		invalid("PrometheusTimeout %v must not be negative", o.PrometheusTimeout)
+	}
+	if o.PrometheusMaxRequestsInFlight < 0 {
+		invalid("PrometheusMaxRequestsInFlight %d must not be negative", o.PrometheusMaxRequestsInFlight)
+	}
+
+	if o.TracesSamplerRatio < 0 || o.TracesSamplerRatio > 1 {
+		invalid("TracesSamplerRatio %v is out of range (0-1)", o.TracesSamplerRatio)
+	}
+	for _, rule := range o.TracesSamplingRules {
+		if rule.Match == "" {
+			invalid("TracesSamplingRules entry has an empty Match")
+		}
+		if rule.Ratio < 0 || rule.Ratio > 1 {
+			invalid("TracesSamplingRules ratio %v for %q is out of range (0-1)", rule.Ratio, rule.Match)
+		}
+	}
+	if o.TracesSampler != "" {
+		if _, err := newSampler(&Options{TracesSampler: o.TracesSampler}); err != nil {
+			invalid("%v", err)
+		}
+	}
+
+	for _, rewrite := range o.SpanNameRewrites {
+		if _, err := regexp.Compile(rewrite.Pattern); err != nil {
+			invalid("SpanNameRewrites pattern %q: %v", rewrite.Pattern, err)
+		}
+	}
+	if o.MaxSpanNames < 0 {
+		invalid("MaxSpanNames %d must not be negative", o.MaxSpanNames)
+	}
+	for pattern, preset := range o.HistogramBucketPresets {
+		if BucketPreset(preset) == nil {
+			invalid("unknown HistogramBucketPresets preset %q for %q (supported: %s)", preset, pattern, strings.Join(bucketPresetNames(), ", "))
+		}
+	}
+	if o.MetricCardinalityLimit < 0 {
+		invalid("MetricCardinalityLimit %d must not be negative", o.MetricCardinalityLimit)
+	}
+	if o.AsyncLogQueueSize < 0 {
+		invalid("AsyncLogQueueSize %d must not be negative", o.AsyncLogQueueSize)
+	}
+	if o.SpoolMaxBytes < 0 {
+		invalid("SpoolMaxBytes %d must not be negative", o.SpoolMaxBytes)
+	}
+	if o.ShutdownTimeout < 0 {
+		invalid("ShutdownTimeout %v must not be negative", o.ShutdownTimeout)
+	}
+
+	return errors.Join(errs...)
+}
+
+// exporterNames splits a comma-separated exporter list, skipping empty names.
+func exporterNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
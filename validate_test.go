@@ -0,0 +1,162 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			opts: *DefaultOptions(),
+		},
+		{
+			name: "prometheus server with prometheus exporter",
+			opts: Options{MetricsExporter: "otlp, prometheus", PrometheusServer: true, PrometheusPort: 9090},
+		},
+		{
+			name:    "prometheus server without prometheus exporter",
+			opts:    Options{MetricsExporter: "otlp", PrometheusServer: true},
+			wantErr: "PrometheusServer requires the prometheus metrics exporter",
+		},
+		{
+			name:    "negative port",
+			opts:    Options{PrometheusPort: -1},
+			wantErr: "PrometheusPort -1 is out of range",
+		},
+		{
+			name:    "port too large",
+			opts:    Options{PrometheusPort: 70000},
+			wantErr: "PrometheusPort 70000 is out of range",
+		},
+		{
+			name:    "relative prometheus path",
+			opts:    Options{PrometheusPath: "metrics"},
+			wantErr: "PrometheusPath \"metrics\" must start with \"/\"",
+		},
+		{
+			name:    "negative prometheus timeout",
+			opts:    Options{PrometheusTimeout: -time.Second},
+			wantErr: "PrometheusTimeout -1s must not be negative",
+		},
+		{
+			name:    "unknown metrics exporter",
+			opts:    Options{MetricsExporter: "prometheus,statsd"},
+			wantErr: "unknown MetricsExporter \"statsd\"",
+		},
+		{
+			name:    "unknown logs exporter",
+			opts:    Options{LogsExporter: "carrier-pigeon"},
+			wantErr: "unknown LogsExporter \"carrier-pigeon\"",
+		},
+		{
+			name:    "moved metrics exporter",
+			opts:    Options{MetricsExporter: "emf"},
+			wantErr: "MetricsExporter \"emf\" moved to github.com/ekristen/go-telemetry/exporters/emf/v2",
+		},
+		{
+			name:    "moved logs exporter",
+			opts:    Options{LogsExporter: "otlp,journald"},
+			wantErr: "LogsExporter \"journald\" moved to github.com/ekristen/go-telemetry/exporters/journald/v2",
+		},
+		{
+			name:    "unknown sampler",
+			opts:    Options{TracesSampler: "sometimes"},
+			wantErr: "unsupported traces sampler: sometimes",
+		},
+		{
+			name:    "sampler ratio out of range",
+			opts:    Options{TracesSampler: "traceidratio", TracesSamplerRatio: 1.5},
+			wantErr: "TracesSamplerRatio 1.5 is out of range",
+		},
+		{
+			name:    "sampling rule without match",
+			opts:    Options{TracesSamplingRules: []SamplingRule{{Ratio: 0.5}}},
+			wantErr: "TracesSamplingRules entry has an empty Match",
+		},
+		{
+			name:    "sampling rule ratio out of range",
+			opts:    Options{TracesSamplingRules: []SamplingRule{{Match: "/healthz", Ratio: -1}}},
+			wantErr: "TracesSamplingRules ratio -1 for \"/healthz\" is out of range",
+		},
+		{
+			name:    "unknown bucket preset",
+			opts:    Options{HistogramBucketPresets: map[string]string{"db.*": "db_latency_s"}},
+			wantErr: "unknown HistogramBucketPresets preset \"db_latency_s\" for \"db.*\"",
+		},
+		{
+			name:    "negative shutdown timeout",
+			opts:    Options{ShutdownTimeout: -time.Second},
+			wantErr: "ShutdownTimeout -1s must not be negative",
+		},
+		{
+			name: "empty service name",
+			opts: Options{ServiceName: ""},
+		},
+		{
+			name:    "empty service name in strict mode",
+			opts:    Options{Strict: true},
+			wantErr: "ServiceName is required in strict mode",
+		},
+		{
+			name:    "default service name in strict mode",
+			opts:    Options{Strict: true, ServiceName: "unknown"},
+			wantErr: "ServiceName is required in strict mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptions_Validate_ReportsEveryProblem(t *testing.T) {
+	opts := Options{PrometheusPort: -1, LogsExporter: "carrier-pigeon"}
+
+	err := opts.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "PrometheusPort") || !strings.Contains(err.Error(), "LogsExporter") {
+		t.Errorf("Validate() error = %v, want both problems reported", err)
+	}
+}
+
+func TestNew_ValidatesOptions(t *testing.T) {
+	clearOTelEnvVars()
+	defer clearOTelEnvVars()
+
+	_, err := New(context.Background(), &Options{
+		ServiceName:      "test-service",
+		PrometheusServer: true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "PrometheusServer requires") {
+		t.Errorf("New() error = %v, want validation error", err)
+	}
+
+	// Environment variable overrides are applied before validation
+	t.Setenv("OTEL_SERVICE_NAME", "from-env")
+	tel, err := New(context.Background(), &Options{Strict: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tel.Shutdown(context.Background())
+}
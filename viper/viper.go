@@ -0,0 +1,77 @@
+// Package viper builds Options from a viper instance, for apps that already
+// load their configuration with viper.
+package viper
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// Keys read by Options, below the prefix.
+const (
+	ServiceNameKey      = "service-name"
+	ServiceVersionKey   = "service-version"
+	LogLevelKey         = "log-level"
+	OTLPEndpointKey     = "otlp-endpoint"
+	InsecureKey         = "insecure"
+	MetricsExporterKey  = "metrics-exporter"
+	LogsExporterKey     = "logs-exporter"
+	TraceSampleRatioKey = "trace-sample-ratio"
+)
+
+// Options returns Options built from the keys set in v below prefix, e.g.
+// "telemetry.log-level" for the prefix "telemetry"; an empty prefix reads the
+// top-level keys. viper resolves each key from flags, environment variables,
+// the config file, and defaults in its usual order, so binding flags and
+// environment variables to the keys is left to the caller. Keys that aren't
+// set leave their fields empty, and the OTel environment variables applied by
+// telemetry.New still override them.
+//
+//	v.SetEnvPrefix("MYAPP")
+//	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+//	v.AutomaticEnv()
+//	opts, err := telemetryviper.Options(v, "telemetry")
+func Options(v *viper.Viper, prefix string) (*telemetry.Options, error) {
+	key := func(name string) string {
+		if prefix == "" {
+			return name
+		}
+		return prefix + "." + name
+	}
+
+	opts := &telemetry.Options{
+		ServiceName:      v.GetString(key(ServiceNameKey)),
+		ServiceVersion:   v.GetString(key(ServiceVersionKey)),
+		MetricsExporter:  v.GetString(key(MetricsExporterKey)),
+		LogsExporter:     v.GetString(key(LogsExporterKey)),
+		ExporterInsecure: v.GetBool(key(InsecureKey)),
+	}
+
+	if v.IsSet(key(LogLevelKey)) {
+		severity, err := telemetry.ParseSeverity(v.GetString(key(LogLevelKey)))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key(LogLevelKey), err)
+		}
+		opts.LogsMinSeverity = severity
+	}
+
+	if endpoint := v.GetString(key(OTLPEndpointKey)); endpoint != "" {
+		opts.TracesEndpoint = endpoint
+		opts.MetricsEndpoint = endpoint
+		opts.LogsEndpoint = endpoint
+	}
+
+	if v.IsSet(key(TraceSampleRatioKey)) {
+		ratio := v.GetFloat64(key(TraceSampleRatioKey))
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("%s: ratio %v is not between 0 and 1", key(TraceSampleRatioKey), ratio)
+		}
+		opts.TracesSampler = "parentbased_traceidratio"
+		opts.TracesSamplerRatio = ratio
+	}
+
+	return opts, nil
+}
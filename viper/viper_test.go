@@ -0,0 +1,58 @@
+package viper
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+func TestOptions(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	config := `
+telemetry:
+  service-name: from-file
+  log-level: warn
+  otlp-endpoint: collector:4317
+  metrics-exporter: prometheus
+  trace-sample-ratio: 0.1
+`
+	if err := v.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+
+	// An explicit value takes precedence over the config file
+	v.Set("telemetry.service-name", "from-flag")
+
+	opts, err := Options(v, "telemetry")
+	if err != nil {
+		t.Fatalf("Options() error = %v", err)
+	}
+
+	if opts.ServiceName != "from-flag" {
+		t.Errorf("ServiceName = %q, want from-flag", opts.ServiceName)
+	}
+	if opts.LogsMinSeverity != otellog.SeverityWarn {
+		t.Errorf("LogsMinSeverity = %v, want %v", opts.LogsMinSeverity, otellog.SeverityWarn)
+	}
+	if opts.TracesEndpoint != "collector:4317" || opts.LogsEndpoint != "collector:4317" {
+		t.Errorf("endpoints = %q, %q, want collector:4317", opts.TracesEndpoint, opts.LogsEndpoint)
+	}
+	if opts.MetricsExporter != "prometheus" {
+		t.Errorf("MetricsExporter = %q, want prometheus", opts.MetricsExporter)
+	}
+	if opts.TracesSampler != "parentbased_traceidratio" || opts.TracesSamplerRatio != 0.1 {
+		t.Errorf("sampler = %s(%v), want parentbased_traceidratio(0.1)", opts.TracesSampler, opts.TracesSamplerRatio)
+	}
+}
+
+func TestOptions_Invalid(t *testing.T) {
+	v := viper.New()
+	v.Set("log-level", "loud")
+
+	if _, err := Options(v, ""); err == nil {
+		t.Error("Options() with log-level loud error = nil, want error")
+	}
+}
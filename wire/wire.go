@@ -0,0 +1,58 @@
+// Package wire provides a wire provider set that constructs a Telemetry from
+// the *telemetry.Options in the graph.
+package wire
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+// ProviderSet provides *telemetry.Telemetry and its otellog.Logger,
+// trace.Tracer, and metric.Meter from a context.Context and the
+// *telemetry.Options supplied by the injector. The injector's cleanup
+// function shuts the Telemetry down.
+//
+//	func initializeApp(ctx context.Context, opts *telemetry.Options) (*App, func(), error) {
+//		wire.Build(telemetrywire.ProviderSet, NewApp)
+//		return nil, nil, nil
+//	}
+var ProviderSet = wire.NewSet(
+	New,
+	Logger,
+	Tracer,
+	Meter,
+)
+
+// New returns a Telemetry built from opts and a cleanup function that shuts
+// it down.
+func New(ctx context.Context, opts *telemetry.Options) (*telemetry.Telemetry, func(), error) {
+	t, err := telemetry.New(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = t.Shutdown(context.WithoutCancel(ctx))
+	}
+	return t, cleanup, nil
+}
+
+// Logger returns t's OTel logger.
+func Logger(t *telemetry.Telemetry) otellog.Logger {
+	return t.Logger()
+}
+
+// Tracer returns t's tracer.
+func Tracer(t *telemetry.Telemetry) trace.Tracer {
+	return t.Tracer()
+}
+
+// Meter returns t's meter.
+func Meter(t *telemetry.Telemetry) metric.Meter {
+	return t.Meter()
+}
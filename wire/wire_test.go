@@ -0,0 +1,35 @@
+package wire
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ekristen/go-telemetry/v2"
+)
+
+func TestNew_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	spans := tracetest.NewInMemoryExporter()
+
+	tel, cleanup, err := New(ctx, &telemetry.Options{
+		ServiceName:        "test-service",
+		CustomSpanExporter: spans,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, span := Tracer(tel).Start(ctx, "work")
+	span.End()
+	if got := len(spans.GetSpans()); got != 1 {
+		t.Fatalf("exported %d spans, want 1", got)
+	}
+
+	// cleanup shuts the Telemetry down, which shuts the exporter down and clears it
+	cleanup()
+	if got := len(spans.GetSpans()); got != 0 {
+		t.Errorf("%d spans left after cleanup, want 0", got)
+	}
+}